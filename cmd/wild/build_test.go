@@ -0,0 +1,35 @@
+// Go test guarding against a package that no longer builds.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/kirkjkrauss/MatchingWildcardsInGo/wildcard"
+)
+
+// Fails to compile, rather than failing at run time, if any file in the
+// package doesn't build, including main.go with its testcase flags.  The
+// testcases themselves run via main().
+func TestBuild(t *testing.T) {
+	if !wildcard.FastWildCompareAscii("*", "") ||
+		!wildcard.FastWildCompareRuneSlices([]rune("?"), []rune("★")) {
+		t.Error("the package builds, but its basic matches fail")
+	}
+
+	_ = main
+}
@@ -0,0 +1,525 @@
+// Go tame/wild pairs for the correctness and performance tests.
+//
+// Copyright 2025 Kirk J Krauss.  This is a Derivative Work based on
+// material that is copyright 2018 IBM Corporation and available at
+//
+//	https://developforperformance.com/MatchingWildcardsInRust.html
+//
+// Licensed under the Apache License, Version 2.0 (the "License")
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// The pairs are checked by the go tests in cases_test.go, and are passed
+// through test() by main() for the differential and performance tests.
+package main
+
+// A tame/wild pair along with its expected result.
+type testPair struct {
+	strTame   string
+	strWild   string
+	bExpected bool
+}
+
+// A named set of tame/wild pairs, checked together as one sub-test.
+type testCategory struct {
+	strName  string
+	slcPairs []testPair
+	bFolded  bool // Expected results hold only for case-insensitive matching
+	bUntimed bool // Left out of performance comparisons
+}
+
+// A set of wildcard comparison cases.
+var slcWildCases = []testCategory{
+	// Case with first wildcard after total match.
+	{
+		strName: "total match then wildcard",
+		slcPairs: []testPair{
+			{"Hi", "Hi*", true},
+		},
+	},
+	// Cases with a literal run ahead of the first '*' that's longer than
+	// the tame string.
+	{
+		strName: "tame ends before first wildcard",
+		slcPairs: []testPair{
+			{"ab", "abc*", false},
+			{"abc", "abcd*", false},
+			{"ab", "ab?*", false},
+			{"a", "ab*b", false},
+			{"abc", "abc*", true},
+		},
+	},
+	// Case with mismatch after '*'.
+	{
+		strName: "mismatch after star",
+		slcPairs: []testPair{
+			{"abc", "ab*d", false},
+		},
+	},
+	// Cases with repeating character sequences.
+	{
+		strName: "repeating sequences",
+		slcPairs: []testPair{
+			{"abcccd", "*ccd", true},
+			{"mississipissippi", "*issip*ss*", true},
+			{"xxxx*zzzzzzzzy*f", "xxxx*zzy*fffff", false},
+			{"xxxx*zzzzzzzzy*f", "xxx*zzy*f", true},
+			{"xxxxzzzzzzzzyf", "xxxx*zzy*fffff", false},
+			{"xxxxzzzzzzzzyf", "xxxx*zzy*f", true},
+			{"xyxyxyzyxyz", "xy*z*xyz", true},
+			{"mississippi", "*sip*", true},
+			{"xyxyxyxyz", "xy*xyz", true},
+			{"mississippi", "mi*sip*", true},
+			{"ababac", "*abac*", true},
+			{"ababac", "*abac*", true},
+			{"aaazz", "a*zz*", true},
+			{"a12b12", "*12*23", false},
+			{"a12b12", "a12b", false},
+			{"a12b12", "*12*12*", true},
+		},
+	},
+	// From DDJ reader Andy Belf: a case of repeating text matching the
+	// different kinds of wildcards in order of '*' and then '?'.
+	{
+		strName:  "Belf",
+		bUntimed: true,
+		slcPairs: []testPair{
+			{"caaab", "*a?b", true},
+		},
+	},
+	// This similar case was found, probably independently, by Dogan Kurt.
+	{
+		strName:  "Kurt",
+		bUntimed: true,
+		slcPairs: []testPair{
+			{"aaaaa", "*aa?", true},
+		},
+	},
+	// Additional cases where the '*' char appears in the tame string.
+	{
+		strName: "star in tame",
+		slcPairs: []testPair{
+			{"*", "*", true},
+			{"a*abab", "a*b", true},
+			{"a*r", "a*", true},
+			{"a*ar", "a*aar", false},
+		},
+	},
+	// More double wildcard scenarios.
+	{
+		strName: "double wildcards",
+		slcPairs: []testPair{
+			{"XYXYXYZYXYz", "XY*Z*XYz", true},
+			{"missisSIPpi", "*SIP*", true},
+			{"mississipPI", "*issip*PI", true},
+			{"xyxyxyxyz", "xy*xyz", true},
+			{"miSsissippi", "mi*sip*", true},
+			{"abAbac", "*Abac*", true},
+			{"abAbac", "*Abac*", true},
+			{"aAazz", "a*zz*", true},
+			{"A12b12", "*12*23", false},
+			{"a12B12", "*12*12*", true},
+			{"oWn", "*oWn*", true},
+		},
+	},
+	// Completely tame (no wildcards) cases.
+	{
+		strName: "no wildcards",
+		slcPairs: []testPair{
+			{"bLah", "bLah", true},
+		},
+	},
+	// Simple mixed wildcard tests suggested by Marlin Deckert.
+	{
+		strName: "Deckert",
+		slcPairs: []testPair{
+			{"a", "*?", true},
+			{"ab", "*?", true},
+			{"abc", "*?", true},
+		},
+	},
+	// More mixed wildcard tests including coverage for false positives.
+	{
+		strName: "mixed wildcards",
+		slcPairs: []testPair{
+			{"a", "??", false},
+			{"ab", "?*?", true},
+			{"ab", "*?*?*", true},
+			{"abc", "?**?*?", true},
+			{"abc", "?**?*&?", false},
+			{"abcd", "?b*??", true},
+			{"abcd", "?a*??", false},
+			{"abcd", "?**?c?", true},
+			{"abcd", "?**?d?", false},
+			{"abcde", "?*b*?*d*?", true},
+		},
+	},
+	// Single-character-match cases.
+	{
+		strName: "single character",
+		slcPairs: []testPair{
+			{"bLah", "bL?h", true},
+			{"bLaaa", "bLa?", false},
+			{"bLah", "bLa?", true},
+			{"bLaH", "?Lah", false},
+			{"bLaH", "?LaH", true},
+		},
+	},
+	// Many-wildcard scenarios.
+	{
+		strName: "many wildcards",
+		slcPairs: []testPair{
+			{"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaab",
+				"a*a*a*a*a*a*aa*aaa*a*a*b", true},
+			{"abababababababababababababababababababaacacacacacacacadaeafagahaiajakalaaaaaaaaaaaaaaaaaffafagaagggagaaaaaaaab",
+				"*a*b*ba*ca*a*aa*aaa*fa*ga*b*", true},
+			{"abababababababababababababababababababaacacacacacacacadaeafagahaiajakalaaaaaaaaaaaaaaaaaffafagaagggagaaaaaaaab",
+				"*a*b*ba*ca*a*x*aaa*fa*ga*b*", false},
+			{"abababababababababababababababababababaacacacacacacacadaeafagahaiajakalaaaaaaaaaaaaaaaaaffafagaagggagaaaaaaaab",
+				"*a*b*ba*ca*aaaa*fa*ga*gggg*b*", false},
+			{"abababababababababababababababababababaacacacacacacacadaeafagahaiajakalaaaaaaaaaaaaaaaaaffafagaagggagaaaaaaaab",
+				"*a*b*ba*ca*aaaa*fa*ga*ggg*b*", true},
+			{"aaabbaabbaab", "*aabbaa*a*", true},
+			{"a*a*a*a*a*a*a*a*a*a*a*a*a*a*a*a*a*",
+				"a*a*a*a*a*a*a*a*a*a*a*a*a*a*a*a*a*", true},
+			{"aaaaaaaaaaaaaaaaa", "*a*a*a*a*a*a*a*a*a*a*a*a*a*a*a*a*a*", true},
+			{"aaaaaaaaaaaaaaaa", "*a*a*a*a*a*a*a*a*a*a*a*a*a*a*a*a*a*", false},
+			{"abc*abcd*abcde*abcdef*abcdefg*abcdefgh*abcdefghi*abcdefghij*abcdefghijk*abcdefghijkl*abcdefghijklm*abcdefghijklmn",
+				"abc*abc*abc*abc*abc*abc*abc*abc*abc*abc*abc*abc*abc*abc*abc*abc*a            bc*", false},
+			{"abc*abcd*abcde*abcdef*abcdefg*abcdefgh*abcdefghi*abcdefghij*abcdefghijk*abcdefghijkl*abcdefghijklm*abcdefghijklmn",
+				"abc*abc*abc*abc*abc*abc*abc*abc*abc*abc*abc*abc*", true},
+			{"abc*abcd*abcd*abc*abcd", "abc*abc*abc*abc*abc", false},
+			{"abc*abcd*abcd*abc*abcd*abcd*abc*abcd*abc*abc*abcd",
+				"abc*abc*abc*abc*abc*abc*abc*abc*abc*abc*abcd", true},
+			{"abc", "********a********b********c********", true},
+			{"********a********b********c********", "abc", false},
+			{"abc", "********a********b********b********", false},
+			{"*abc*", "***a*b*c***", true},
+		},
+	},
+	// Case-insensitive algorithm tests.
+	{
+		strName: "case insensitive",
+		bFolded: true,
+		slcPairs: []testPair{
+			{"mississippi", "*issip*PI", true},
+			{"miSsissippi", "mi*Sip*", true},
+			{"bLah", "bLaH", true},
+			{"bLaH", "?Lah", true},
+		},
+	},
+	// Tests suggested by other DDJ readers.
+	{
+		strName: "DDJ readers",
+		slcPairs: []testPair{
+			{"", "?", false},
+			{"", "*?", false},
+			{"", "", true},
+			{"a", "", false},
+		},
+	},
+}
+
+// A set of cases with (almost) no '*' wildcards.
+var slcTameCases = []testCategory{
+	// Case with last character mismatch.
+	{
+		strName: "last character mismatch",
+		slcPairs: []testPair{
+			{"abc", "abd", false},
+		},
+	},
+	// Cases with repeating character sequences.
+	{
+		strName: "repeating sequences",
+		slcPairs: []testPair{
+			{"abcccd", "abcccd", true},
+			{"mississipissippi", "mississipissippi", true},
+			{"xxxxzzzzzzzzyf", "xxxxzzzzzzzzyfffff", false},
+			{"xxxxzzzzzzzzyf", "xxxxzzzzzzzzyf", true},
+			{"xxxxzzzzzzzzyf", "xxxxzzy.fffff", false},
+			{"xxxxzzzzzzzzyf", "xxxxzzzzzzzzyf", true},
+			{"xyxyxyzyxyz", "xyxyxyzyxyz", true},
+			{"mississippi", "mississippi", true},
+			{"xyxyxyxyz", "xyxyxyxyz", true},
+			{"m ississippi", "m ississippi", true},
+			{"ababac", "ababac?", false},
+			{"dababac", "ababac", false},
+			{"aaazz", "aaazz", true},
+			{"a12b12", "1212", false},
+			{"a12b12", "a12b", false},
+			{"a12b12", "a12b12", true},
+		},
+	},
+	// A mix of cases
+	{
+		strName: "mix",
+		slcPairs: []testPair{
+			{"n", "n", true},
+			{"aabab", "aabab", true},
+			{"ar", "ar", true},
+			{"aar", "aaar", false},
+			{"XYXYXYZYXYz", "XYXYXYZYXYz", true},
+			{"missisSIPpi", "missisSIPpi", true},
+			{"mississipPI", "mississipPI", true},
+			{"xyxyxyxyz", "xyxyxyxyz", true},
+			{"miSsissippi", "miSsissippi", true},
+		},
+	},
+	{
+		strName: "mix case insensitive",
+		bFolded: true,
+		slcPairs: []testPair{
+			{"miSsissippi", "miSsisSippi", true},
+			{"abAbac", "abAbac", true},
+			{"abAbac", "abAbac", true},
+			{"bLah", "bLaH", true},
+		},
+	},
+	{
+		strName: "mix continued",
+		slcPairs: []testPair{
+			{"aAazz", "aAazz", true},
+			{"A12b12", "A12b123", false},
+			{"a12B12", "a12B12", true},
+			{"oWn", "oWn", true},
+			{"bLah", "bLah", true},
+		},
+	},
+	// Single '?' cases.
+	{
+		strName: "single question mark",
+		slcPairs: []testPair{
+			{"a", "a", true},
+			{"ab", "a?", true},
+			{"abc", "ab?", true},
+		},
+	},
+	// Mixed '?' cases.
+	{
+		strName: "mixed question marks",
+		slcPairs: []testPair{
+			{"a", "??", false},
+			{"ab", "??", true},
+			{"abc", "???", true},
+			{"abcd", "????", true},
+			{"abc", "????", false},
+			{"abcd", "?b??", true},
+			{"abcd", "?a??", false},
+			{"abcd", "??c?", true},
+			{"abcd", "??d?", false},
+			{"abcde", "?b?d*?", true},
+		},
+	},
+	// Longer string scenarios.
+	{
+		strName: "longer strings",
+		slcPairs: []testPair{
+			{"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaab",
+				"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaab", true},
+			{"abababababababababababababababababababaacacacacacacacadaeafagahaiajakalaaaaaaaaaaaaaaaaaffafagaagggagaaaaaaaab",
+				"abababababababababababababababababababaacacacacacacacadaeafagahaiajakalaaaaaaaaaaaaaaaaaffafagaagggagaaaaaaaab", true},
+			{"abababababababababababababababababababaacacacacacacacadaeafagahaiajakalaaaaaaaaaaaaaaaaaffafagaagggagaaaaaaaab",
+				"abababababababababababababababababababaacacacacacacacadaeafagahaiajaxalaaaaaaaaaaaaaaaaaffafagaagggagaaaaaaaab", false},
+			{"abababababababababababababababababababaacacacacacacacadaeafagahaiajakalaaaaaaaaaaaaaaaaaffafagaagggagaaaaaaaab",
+				"abababababababababababababababababababaacacacacacacacadaeafagahaiajakalaaaaaaaaaaaaaaaaaffafagaggggagaaaaaaaab", false},
+			{"abababababababababababababababababababaacacacacacacacadaeafagahaiajakalaaaaaaaaaaaaaaaaaffafagaagggagaaaaaaaab",
+				"abababababababababababababababababababaacacacacacacacadaeafagahaiajakalaaaaaaaaaaaaaaaaaffafagaagggagaaaaaaaab", true},
+			{"aaabbaabbaab", "aaabbaabbaab", true},
+			{"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+				"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", true},
+			{"aaaaaaaaaaaaaaaaa", "aaaaaaaaaaaaaaaaa", true},
+			{"aaaaaaaaaaaaaaaa", "aaaaaaaaaaaaaaaaa", false},
+			{"abcabcdabcdeabcdefabcdefgabcdefghabcdefghiabcdefghijabcdefghijkabcdefghijklabcdefghijklmabcdefghijklmn",
+				"abcabcabcabcabcabcabcabcabcabcabcabcabcabcabcabcabc", false},
+			{"abcabcdabcdeabcdefabcdefgabcdefghabcdefghiabcdefghijabcdefghijkabcdefghijklabcdefghijklmabcdefghijklmn",
+				"abcabcdabcdeabcdefabcdefgabcdefghabcdefghiabcdefghijabcdefghijkabcdefghijklabcdefghijklmabcdefghijklmn", true},
+			{"abcabcdabcdabcabcd", "abcabc?abcabcabc", false},
+			{"abcabcdabcdabcabcdabcdabcabcdabcabcabcd",
+				"abcabc?abc?abcabc?abc?abc?bc?abc?bc?bcd", true},
+			{"?abc?", "?abc?", true},
+		},
+	},
+}
+
+// A set of cases with empty input.
+var slcEmptyCases = []testCategory{
+	// A simple case.
+	{
+		strName: "empty tame",
+		slcPairs: []testPair{
+			{"", "abd", false},
+		},
+	},
+	// Cases with repeating character sequences.
+	{
+		strName: "empty tame repeating sequences",
+		slcPairs: []testPair{
+			{"", "abcccd", false},
+			{"", "mississipissippi", false},
+			{"", "xxxxzzzzzzzzyfffff", false},
+			{"", "xxxxzzzzzzzzyf", false},
+			{"", "xxxxzzy.fffff", false},
+			{"", "xxxxzzzzzzzzyf", false},
+			{"", "xyxyxyzyxyz", false},
+			{"", "mississippi", false},
+			{"", "xyxyxyxyz", false},
+			{"", "m ississippi", false},
+			{"", "ababac*", false},
+			{"", "ababac", false},
+			{"", "aaazz", false},
+			{"", "1212", false},
+			{"", "a12b", false},
+			{"", "a12b12", false},
+		},
+	},
+	// A mix of cases.
+	{
+		strName: "empty tame mix",
+		slcPairs: []testPair{
+			{"", "n", false},
+			{"", "aabab", false},
+			{"", "ar", false},
+			{"", "aaar", false},
+			{"", "XYXYXYZYXYz", false},
+			{"", "missisSIPpi", false},
+			{"", "mississipPI", false},
+			{"", "xyxyxyxyz", false},
+			{"", "miSsissippi", false},
+			{"", "miSsisSippi", false},
+			{"", "abAbac", false},
+			{"", "abAbac", false},
+			{"", "aAazz", false},
+			{"", "A12b123", false},
+			{"", "a12B12", false},
+			{"", "oWn", false},
+			{"", "bLah", false},
+			{"", "bLaH", false},
+		},
+	},
+	// Both strings empty.
+	{
+		strName: "both empty",
+		slcPairs: []testPair{
+			{"", "", true},
+		},
+	},
+	// Another simple case.
+	{
+		strName: "empty wild",
+		slcPairs: []testPair{
+			{"abc", "", false},
+		},
+	},
+	// More cases with repeating character sequences.
+	{
+		strName: "empty wild repeating sequences",
+		slcPairs: []testPair{
+			{"abcccd", "", false},
+			{"mississipissippi", "", false},
+			{"xxxxzzzzzzzzyf", "", false},
+			{"xxxxzzzzzzzzyf", "", false},
+			{"xxxxzzzzzzzzyf", "", false},
+			{"xxxxzzzzzzzzyf", "", false},
+			{"xyxyxyzyxyz", "", false},
+			{"mississippi", "", false},
+			{"xyxyxyxyz", "", false},
+			{"m ississippi", "", false},
+			{"ababac", "", false},
+			{"dababac", "", false},
+			{"aaazz", "", false},
+			{"a12b12", "", false},
+			{"a12b12", "", false},
+			{"a12b12", "", false},
+		},
+	},
+	// Another mix of cases.
+	{
+		strName: "empty wild mix",
+		slcPairs: []testPair{
+			{"n", "", false},
+			{"aabab", "", false},
+			{"ar", "", false},
+			{"aar", "", false},
+			{"XYXYXYZYXYz", "", false},
+			{"missisSIPpi", "", false},
+			{"mississipPI", "", false},
+			{"xyxyxyxyz", "", false},
+			{"miSsissippi", "", false},
+			{"miSsissippi", "", false},
+			{"abAbac", "", false},
+			{"abAbac", "", false},
+			{"aAazz", "", false},
+			{"A12b12", "", false},
+			{"a12B12", "", false},
+			{"oWn", "", false},
+			{"bLah", "", false},
+			{"bLah", "", false},
+		},
+	},
+}
+
+// Cases involving various UTF-8 symbols and international content, for a
+// UTF-8-enabled routine for matching wildcards.
+var slcUtf8Cases = []testCategory{
+	// Simple correctness tests involving various UTF-8 symbols and
+	// international content.
+	{
+		strName: "international",
+		slcPairs: []testPair{
+			{"🐂🚀♥🍀貔貅🦁★□√🚦€¥☯🐴😊🍓🐕🎺🧊☀☂🐉", "*☂🐉", true},
+		},
+	},
+	{
+		strName: "international case insensitive",
+		bFolded: true,
+		slcPairs: []testPair{
+			{"AbCD", "abc?", true},
+			{"AbC★", "abc?", true},
+			{"⚛⚖☁o", "⚛⚖☁O", true},
+		},
+	},
+	{
+		strName: "international continued",
+		slcPairs: []testPair{
+			{"▲●🐎✗🤣🐶♫🌻ॐ", "▲●☂*", false},
+			{"𓋍𓋔𓎍", "𓋍𓋔?", true},
+			{"𓋍𓋔𓎍", "𓋍?𓋔𓎍", false},
+			{"♅☌♇", "♅☌♇", true},
+			{"⚛⚖☁", "⚛🍄☁", false},
+			{"⚛⚖☁O", "⚛⚖☁0", false},
+			{"गते गते पारगते पारसंगते बोधि स्वाहा",
+				"गते गते पारगते प????गते बोधि स्वाहा", true},
+			{"Мне нужно выучить русский язык, чтобы лучше оценить Пушкина.",
+				"Мне нужно выучить * язык, чтобы лучше оценить *.", true},
+			{"אני צריך ללמוד אנגלית כדי להעריך את גינסברג",
+				" אני צריך ללמוד אנגלית כדי להעריך את ???????", false},
+			{"ગિન્સબર્ગની શ્રેષ્ઠ પ્રશંસા કરવા માટે મારે અંગ્રેજી શીખવું પડશે.",
+				"* શ્રેષ્ઠ પ્રશંસા કરવા માટે મારે * શીખવું પડશે.", true},
+			{"ગિન્સબર્ગની શ્રેષ્ઠ પ્રશંસા કરવા માટે મારે અંગ્રેજી શીખવું પડશે.",
+				"??????????? શ્રેષ્ઠ પ્રશંસા કરવા માટે મારે * શીખવું પડશે.", true},
+			{"ગિન્સબર્ગની શ્રેષ્ઠ પ્રશંસા કરવા માટે મારે અંગ્રેજી શીખવું પડશે.",
+				"ગિન્સબર્ગની શ્રેષ્ઠ પ્રશંસા કરવા માટે મારે હિબ્રુ ભાષા શીખવી પડશે.", false},
+		},
+	},
+	// These tests involve multibyte code points that contain bytes identical
+	// to the single-byte code points for '*' and '?'.
+	{
+		strName: "multibyte wildcard bytes",
+		slcPairs: []testPair{
+			{"ḪؿꜪἪꜿ", "ḪؿꜪἪꜿ", true},
+			{"ḪؿUἪꜿ", "ḪؿꜪἪꜿ", false},
+			{"ḪؿꜪἪꜿ", "ḪؿꜪἪꜿЖ", false},
+			{"ḪؿꜪἪꜿ", "ЬḪؿꜪἪꜿ", false},
+			{"ḪؿꜪἪꜿ", "?ؿꜪ*ꜿ", true},
+		},
+	},
+}
@@ -0,0 +1,88 @@
+// Go tests checking the tame/wild pairs in cases.go.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/kirkjkrauss/MatchingWildcardsInGo/wildcard"
+)
+
+// Checks each pair of a set of cases, with one sub-test per category.  The
+// pairs are compared via FastWildCompareRuneSlices() for UTF-8 cases, or
+// else via both FastWildCompareAscii() and SlowWildCompareAscii().  The
+// pairs of a folded category, whose expected results hold only for
+// case-insensitive matching, are compared via
+// FastWildCompareRuneSlicesFold() instead.
+func checkCases(t *testing.T, slcCategories []testCategory, bUtf8 bool) {
+	for _, category := range slcCategories {
+		t.Run(category.strName, func(t *testing.T) {
+			for _, pair := range category.slcPairs {
+				var bMatch bool
+				var strMatcher string
+
+				switch {
+				case category.bFolded:
+					strMatcher = "FastWildCompareRuneSlicesFold"
+					bMatch = wildcard.FastWildCompareRuneSlicesFold(
+						[]rune(pair.strWild), []rune(pair.strTame))
+				case bUtf8:
+					strMatcher = "FastWildCompareRuneSlices"
+					bMatch = wildcard.FastWildCompareRuneSlices(
+						[]rune(pair.strWild), []rune(pair.strTame))
+				default:
+					strMatcher = "FastWildCompareAscii"
+					bMatch = wildcard.FastWildCompareAscii(pair.strWild,
+						pair.strTame)
+
+					if bSlow := wildcard.SlowWildCompareAscii(pair.strWild,
+						pair.strTame); bSlow != pair.bExpected {
+						t.Errorf("SlowWildCompareAscii(%q, %q) = %t, want "+
+							"%t", pair.strWild, pair.strTame, bSlow,
+							pair.bExpected)
+					}
+				}
+
+				if bMatch != pair.bExpected {
+					t.Errorf("%s(%q, %q) = %t, want %t", strMatcher,
+						pair.strWild, pair.strTame, bMatch, pair.bExpected)
+				}
+			}
+		})
+	}
+}
+
+// A set of wildcard comparison tests.
+func TestWild(t *testing.T) {
+	checkCases(t, slcWildCases, false)
+}
+
+// A set of tests with (almost) no '*' wildcards.
+func TestTame(t *testing.T) {
+	checkCases(t, slcTameCases, false)
+}
+
+// A set of tests with empty input.
+func TestEmpty(t *testing.T) {
+	checkCases(t, slcEmptyCases, false)
+}
+
+// Correctness tests for a case-sensitive arrangement for invoking a
+// UTF-8-enabled routine for matching wildcards.
+func TestUtf8(t *testing.T) {
+	checkCases(t, slcUtf8Cases, true)
+}
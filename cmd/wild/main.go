@@ -0,0 +1,448 @@
+// Go testcases for matching wildcards.
+//
+// Copyright 2025 Kirk J Krauss.  This is a Derivative Work based on
+// material that is copyright 2018 IBM Corporation and available at
+//
+//	https://developforperformance.com/MatchingWildcardsInRust.html
+//
+// Licensed under the Apache License, Version 2.0 (the "License")
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// This file provides sets of correctness and performance tests, for
+// matching wildcards in Go, along with a main() routine that invokes the
+// testcases and outputs the results.
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/kirkjkrauss/MatchingWildcardsInGo/wildcard"
+)
+
+// Package-scope testcase selection flags.
+//
+// For a fair comparison involving implementations that aren't UTF-8-ready,
+// set bTestUtf8 = false.
+const (
+	bComparePerformance  = false // Compares using ASCII tests
+	bTestWild            = true
+	bTestTame            = true
+	bTestEmpty           = true
+	bTestUtf8            = true // Skips ASCII test timings
+	bTestCaseInsensitive = true
+)
+
+// Package-scope variables for low-latency accumulation of performance data.
+var (
+	iAccumulatedTimeAscii         int64
+	iAccumulatedTimeUTF8          int64
+	iAccumulatedTimePrefix        int64
+	iAccumulatedTimePrefixGeneral int64
+	iAccumulatedTimeBestMatcher   int64
+	iAccumulatedTimeHandPicked    int64
+	iAccumulatedTimeFoldTable     int64
+	iAccumulatedTimeFoldSimple    int64
+	iAccumulatedTimeStarRun       int64
+	iAccumulatedTimeOneStar       int64
+	iAccumulatedTimeDNA           int64
+	iAccumulatedTimeDNAGeneral    int64
+	// Can add accumulator variables for more performance comparisons here...
+	bTestingUtf8 bool
+
+	// Every tame/wild pair passed to test(), for differential testing.
+	slcTestPairs []testPair
+)
+
+// This function records a tame/wild string pair for the differential tests,
+// or else times its comparison via each included routine.  Correctness is
+// checked by the go tests in cases_test.go.
+func test(tame_string, wild_string string, bExpectedResult bool) {
+	timeStart := time.Now()
+	timeFinish := time.Now()
+
+	if !bComparePerformance {
+		slcTestPairs = append(slcTestPairs,
+			testPair{tame_string, wild_string, bExpectedResult})
+		return
+	}
+
+	if !bTestingUtf8 {
+		// Get execution times for our two matching wildcards routines.
+		timeStart = time.Now()
+		wildcard.FastWildCompareAscii(wild_string, tame_string)
+		timeFinish = time.Now()
+		iAccumulatedTimeAscii += timeFinish.Sub(timeStart).Nanoseconds()
+	}
+
+	timeStart = time.Now()
+
+	// Allocate array-style memory and initialize with each input string's
+	// 32-bit UTF-8 code points.
+	//
+	// A memory allocation failure can be associated with a panic.  In a
+	// situation involving many calls to this routine, arrangements to
+	// catch allocation failures may be placed around that entire set of
+	// calls.
+	//
+	wildcard.FastWildCompareRuneSlices([]rune(wild_string),
+		[]rune(tame_string))
+	timeFinish = time.Now()
+	iAccumulatedTimeUTF8 += timeFinish.Sub(timeStart).Nanoseconds()
+
+	// Can add more performance comparisons here...
+}
+
+// Passes each tame/wild pair of a set of cases through test().  The UTF-8
+// cases are passed through once, while the others are repeated many times
+// when comparing performance.
+func runCases(slcCategories []testCategory, bUtf8 bool) {
+	iReps := 1
+	bTestingUtf8 = bUtf8
+
+	if bComparePerformance && !bUtf8 {
+		// Can choose as many repetitions as you might expect in production.
+		iReps = 1000000
+	}
+
+	for iReps > 0 {
+		iReps--
+
+		for _, category := range slcCategories {
+			if (category.bFolded && !bTestCaseInsensitive) ||
+				(category.bUntimed && bComparePerformance) {
+				continue
+			}
+
+			for _, pair := range category.slcPairs {
+				test(pair.strTame, pair.strWild, pair.bExpected)
+			}
+		}
+	}
+}
+
+// Times HasWildPrefix() against FastWildCompareAscii() on the same "prefix*"
+// patterns.
+func timePrefix() {
+	slcPrefixCases := []struct {
+		strTame   string
+		strPrefix string
+	}{
+		{"Hi", "Hi"},
+		{"Hi", "H"},
+		{"Hi", ""},
+		{"H", "Hi"},
+		{"abc", "abd"},
+		{"mississippi", "mississ"},
+		{"mississippi", "mississippi!"},
+		{"abcabcdabcdeabcdefabcdefgabcdefghabcdefghiabcdefghijabcdefghijk",
+			"abcabcdabcdeabcdefabcdefgabcdefghabcdefghi"},
+		{"abcabcdabcdeabcdefabcdefgabcdefghabcdefghiabcdefghijabcdefghijk",
+			"abcabcdabcdeabcdefabcdefgabcdefghabcdefghj"},
+		{"🐂🚀♥🍀貔貅🦁★□√", "🐂🚀♥"},
+	}
+	slcWild := make([]string, len(slcPrefixCases))
+
+	for i, prefixCase := range slcPrefixCases {
+		slcWild[i] = prefixCase.strPrefix + "*"
+	}
+
+	// Can choose as many repetitions as you might expect in production.
+	iReps := 1000000
+	timeStart := time.Now()
+
+	for iRep := 0; iRep < iReps; iRep++ {
+		for _, prefixCase := range slcPrefixCases {
+			wildcard.HasWildPrefix(prefixCase.strPrefix, prefixCase.strTame)
+		}
+	}
+
+	iAccumulatedTimePrefix += time.Since(timeStart).Nanoseconds()
+	timeStart = time.Now()
+
+	for iRep := 0; iRep < iReps; iRep++ {
+		for i, prefixCase := range slcPrefixCases {
+			wildcard.FastWildCompareAscii(slcWild[i], prefixCase.strTame)
+		}
+	}
+
+	iAccumulatedTimePrefixGeneral += time.Since(timeStart).Nanoseconds()
+}
+
+// Times BestMatcher() against a hand-picked HasWildPrefix() for the same
+// prefix pattern.
+func timeBestMatcher() {
+	strTame := "abcabcdabcdeabcdefabcdefgabcdefghabcdefghiabcdefghijabcdefghijk"
+	strPrefix := "abcabcdabcdeabcdefabcdefgabcdefghabcdefghi"
+	fnMatch := wildcard.BestMatcher(strPrefix + "*")
+
+	// Can choose as many repetitions as you might expect in production.
+	iReps := 10000000
+	timeStart := time.Now()
+
+	for iRep := 0; iRep < iReps; iRep++ {
+		fnMatch(strTame)
+	}
+
+	iAccumulatedTimeBestMatcher += time.Since(timeStart).Nanoseconds()
+	timeStart = time.Now()
+
+	for iRep := 0; iRep < iReps; iRep++ {
+		wildcard.HasWildPrefix(strPrefix, strTame)
+	}
+
+	iAccumulatedTimeHandPicked += time.Since(timeStart).Nanoseconds()
+}
+
+// Times FastWildCompareRuneSlicesFoldFast() against folding via
+// unicode.SimpleFold() on every call.
+func timeFoldFast() {
+	slcFoldCases := []testPair{
+		{"ΣΟΦΟΣ", "σ*Σ", true},
+		{"σοφος", "ΣΟΦΟΣ", true},
+		{"σοφος", "ΣΟΦ?Σ", true},
+		{"σοφια", "ΣΟΦ*Σ", false},
+		{"ПУШКИН", "пушк??", true},
+		{"Пушкин", "*ШКИН", true},
+		{"Пушкин", "*ШКИНА", false},
+		{"\u212a", "k", true},
+		{"\u212aelvin", "K?LVIN", true},
+		{"straße", "STRASSE", false},
+		{"Straße", "STRA?E", true},
+		{"ǅemal", "ǆ*", true},
+		{"𐐨𐐯", "𐐀*", true},
+		{"🐂🚀♥🍀貔貅", "*♥🍀???", false},
+		{"🐂🚀♥🍀貔貅", "*♥🍀??", true},
+		{"AbC★", "abc?", true},
+		{"⚛⚖☁o", "⚛⚖☁O", true},
+		{"⚛⚖☁O", "⚛⚖☁0", false},
+	}
+	slcRslcWild := make([][]rune, len(slcFoldCases))
+	slcRslcTame := make([][]rune, len(slcFoldCases))
+
+	for i, foldCase := range slcFoldCases {
+		slcRslcWild[i] = []rune(foldCase.strWild)
+		slcRslcTame[i] = []rune(foldCase.strTame)
+	}
+
+	// Can choose as many repetitions as you might expect in production.
+	iReps := 1000000
+	timeStart := time.Now()
+
+	for iRep := 0; iRep < iReps; iRep++ {
+		for i := range slcRslcWild {
+			wildcard.FastWildCompareRuneSlicesFoldFast(slcRslcWild[i],
+				slcRslcTame[i])
+		}
+	}
+
+	iAccumulatedTimeFoldTable += time.Since(timeStart).Nanoseconds()
+	timeStart = time.Now()
+
+	for iRep := 0; iRep < iReps; iRep++ {
+		for i := range slcRslcWild {
+			wildcard.FastWildCompareRuneSlicesFold(slcRslcWild[i],
+				slcRslcTame[i])
+		}
+	}
+
+	iAccumulatedTimeFoldSimple += time.Since(timeStart).Nanoseconds()
+}
+
+// Times a pathological all-star pattern against the same pattern with its
+// run collapsed to one '*'.  The run costs time in proportion to its
+// length, which should be comparable to the time spent on the tame content.
+func timeLongStarRuns() {
+	strTame := strings.Repeat("ab", 10000) + "abc"
+	strWild := strings.Repeat("*", 1000000) + "abc"
+	iReps := 1000
+	timeStart := time.Now()
+
+	for iRep := 0; iRep < iReps; iRep++ {
+		wildcard.FastWildCompareAscii(strWild, strTame)
+	}
+
+	iAccumulatedTimeStarRun += time.Since(timeStart).Nanoseconds()
+	timeStart = time.Now()
+
+	for iRep := 0; iRep < iReps; iRep++ {
+		wildcard.FastWildCompareAscii("*abc", strTame)
+	}
+
+	iAccumulatedTimeOneStar += time.Since(timeStart).Nanoseconds()
+}
+
+// Times DNAMatcher against FastWildCompareAscii().
+func timeDNA() {
+	// Sequences of pseudorandom bases, from a linear congruential
+	// generator, for repeatable timings.
+	slcSequences := make([][]byte, 100)
+	uSeed := uint32(1)
+
+	for i := range slcSequences {
+		slcSequences[i] = make([]byte, 10000)
+
+		for j := range slcSequences[i] {
+			uSeed = uSeed*1664525 + 1013904223
+			slcSequences[i][j] = "ACGT"[uSeed>>30]
+		}
+	}
+
+	slcStrSequences := make([]string, len(slcSequences))
+
+	for i := range slcSequences {
+		slcStrSequences[i] = string(slcSequences[i])
+	}
+
+	for _, strWild := range []string{
+		"*GATTACA*", "*A?G*T?C*GGGG*", "*TTTTTTTTTT*", "*ACGT??ACGT",
+	} {
+		matcher, _ := wildcard.CompileDNA(strWild)
+		timeStart := time.Now()
+
+		for iRep := 0; iRep < 100; iRep++ {
+			for _, slcSequence := range slcSequences {
+				matcher.Match(slcSequence)
+			}
+		}
+
+		iAccumulatedTimeDNA += time.Since(timeStart).Nanoseconds()
+		timeStart = time.Now()
+
+		for iRep := 0; iRep < 100; iRep++ {
+			for _, strSequence := range slcStrSequences {
+				wildcard.FastWildCompareAscii(strWild, strSequence)
+			}
+		}
+
+		iAccumulatedTimeDNAGeneral += time.Since(timeStart).Nanoseconds()
+	}
+}
+
+// Entry point for the Go executable.  Performance findings (if any) are
+// displayed here, once all tests have run.
+func main() {
+	// Accumulate timing data for all implementations invoked in test().
+	if bTestTame {
+		runCases(slcTameCases, false)
+	}
+
+	if bTestEmpty {
+		runCases(slcEmptyCases, false)
+	}
+
+	if bTestWild {
+		runCases(slcWildCases, false)
+	}
+
+	if bTestUtf8 {
+		runCases(slcUtf8Cases, true)
+	}
+
+	if bComparePerformance {
+		timePrefix()
+	}
+
+	if bComparePerformance {
+		timeBestMatcher()
+	}
+
+	if bComparePerformance {
+		timeFoldFast()
+	}
+
+	if bComparePerformance {
+		timeLongStarRuns()
+	}
+
+	if bComparePerformance {
+		timeDNA()
+	}
+
+	if bComparePerformance {
+		// Timings have been accumulated via package-scope data.
+		fBase := 10.0
+		fExpNanoseconds := 9.0
+		fExpMilliseconds := 3.0
+
+		// Represent the timings in seconds, to millisecond precision.
+		fTimeCumulativeAsciiVersion := (float64(iAccumulatedTimeAscii) /
+			math.Pow(fBase, fExpNanoseconds)) * math.Pow(fBase, fExpMilliseconds)
+		fTimeCumulativeUtf8Version := (float64(iAccumulatedTimeUTF8) /
+			math.Pow(fBase, fExpNanoseconds)) * math.Pow(fBase, fExpMilliseconds)
+		fTimeCumulativePrefix := (float64(iAccumulatedTimePrefix) /
+			math.Pow(fBase, fExpNanoseconds)) * math.Pow(fBase, fExpMilliseconds)
+		fTimeCumulativePrefixGeneral := (float64(iAccumulatedTimePrefixGeneral) /
+			math.Pow(fBase, fExpNanoseconds)) * math.Pow(fBase, fExpMilliseconds)
+		fTimeCumulativeBestMatcher := (float64(iAccumulatedTimeBestMatcher) /
+			math.Pow(fBase, fExpNanoseconds)) * math.Pow(fBase, fExpMilliseconds)
+		fTimeCumulativeHandPicked := (float64(iAccumulatedTimeHandPicked) /
+			math.Pow(fBase, fExpNanoseconds)) * math.Pow(fBase, fExpMilliseconds)
+		fTimeCumulativeFoldTable := (float64(iAccumulatedTimeFoldTable) /
+			math.Pow(fBase, fExpNanoseconds)) * math.Pow(fBase, fExpMilliseconds)
+		fTimeCumulativeFoldSimple := (float64(iAccumulatedTimeFoldSimple) /
+			math.Pow(fBase, fExpNanoseconds)) * math.Pow(fBase, fExpMilliseconds)
+		fTimeCumulativeStarRun := (float64(iAccumulatedTimeStarRun) /
+			math.Pow(fBase, fExpNanoseconds)) * math.Pow(fBase, fExpMilliseconds)
+		fTimeCumulativeOneStar := (float64(iAccumulatedTimeOneStar) /
+			math.Pow(fBase, fExpNanoseconds)) * math.Pow(fBase, fExpMilliseconds)
+		fTimeCumulativeDNA := (float64(iAccumulatedTimeDNA) /
+			math.Pow(fBase, fExpNanoseconds)) * math.Pow(fBase, fExpMilliseconds)
+		fTimeCumulativeDNAGeneral := (float64(iAccumulatedTimeDNAGeneral) /
+			math.Pow(fBase, fExpNanoseconds)) * math.Pow(fBase, fExpMilliseconds)
+		// Can add similar calculations for more performance comparisons...
+
+		fUtf8VersionTimeInSeconds := fTimeCumulativeUtf8Version / 1000
+		fAsciiVersionTimeInSeconds := fTimeCumulativeAsciiVersion / 1000
+
+		// Show the timing results.
+		fmt.Printf(
+			"FastWildCompareAscii() - for ASCII strings: %.3f seconds\n",
+			fAsciiVersionTimeInSeconds)
+		// Can add results for more performance comparisons here...
+		fmt.Printf(
+			"FastWildCompareRuneSlices() - for UTF-8-encoded strings: %.3f seconds\n",
+			fUtf8VersionTimeInSeconds)
+		fmt.Printf(
+			"HasWildPrefix() - for prefix patterns: %.3f seconds\n",
+			fTimeCumulativePrefix/1000)
+		fmt.Printf(
+			"FastWildCompareAscii() - for prefix patterns: %.3f seconds\n",
+			fTimeCumulativePrefixGeneral/1000)
+		fmt.Printf(
+			"BestMatcher() - for a prefix pattern: %.3f seconds\n",
+			fTimeCumulativeBestMatcher/1000)
+		fmt.Printf(
+			"HasWildPrefix() - for the same prefix pattern: %.3f seconds\n",
+			fTimeCumulativeHandPicked/1000)
+		fmt.Printf(
+			"FastWildCompareRuneSlicesFoldFast() - with the fold table: %.3f seconds\n",
+			fTimeCumulativeFoldTable/1000)
+		fmt.Printf(
+			"Folding via unicode.SimpleFold() - for the same cases: %.3f seconds\n",
+			fTimeCumulativeFoldSimple/1000)
+		fmt.Printf(
+			"FastWildCompareAscii() - with a run of a million '*'s: %.3f seconds\n",
+			fTimeCumulativeStarRun/1000)
+		fmt.Printf(
+			"FastWildCompareAscii() - with the run collapsed to one '*': %.3f seconds\n",
+			fTimeCumulativeOneStar/1000)
+		fmt.Printf(
+			"DNAMatcher.Match() - for genomic-style sequences: %.3f seconds\n",
+			fTimeCumulativeDNA/1000)
+		fmt.Printf(
+			"FastWildCompareAscii() - for the same sequences: %.3f seconds\n",
+			fTimeCumulativeDNAGeneral/1000)
+	}
+}
@@ -1,3 +1,5 @@
-module wild
+module github.com/kirkjkrauss/MatchingWildcardsInGo
 
 go 1.25.4
+
+require golang.org/x/text v0.30.0
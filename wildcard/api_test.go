@@ -0,0 +1,69 @@
+// Go test guarding the signatures of the stable matchers.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Tests that the signatures of the stable matchers still agree with those
+// recorded in testdata/api.golden, so that no change to them slips by.
+func TestApi(t *testing.T) {
+	mapStable := map[string]interface{}{
+		"FastWildCompareAscii":      FastWildCompareAscii,
+		"FastWildCompareRuneSlices": FastWildCompareRuneSlices,
+	}
+	slcGolden, err := os.ReadFile("testdata/api.golden")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mapChecked := map[string]bool{}
+
+	for _, strLine := range strings.Split(string(slcGolden), "\n") {
+		strLine = strings.TrimSpace(strLine)
+
+		if strLine == "" || strings.HasPrefix(strLine, "#") {
+			continue
+		}
+
+		strName, strSignature, _ := strings.Cut(strLine, " ")
+		fn, bStable := mapStable[strName]
+
+		if !bStable {
+			t.Errorf("api.golden lists %s, which isn't a stable matcher",
+				strName)
+		} else if strType := reflect.TypeOf(fn).String(); strType !=
+			strSignature {
+			t.Errorf("%s has the signature %s, but api.golden records %s",
+				strName, strType, strSignature)
+		}
+
+		mapChecked[strName] = true
+	}
+
+	// Every stable matcher has a recorded signature.
+	for strName := range mapStable {
+		if !mapChecked[strName] {
+			t.Errorf("api.golden records no signature for %s", strName)
+		}
+	}
+}
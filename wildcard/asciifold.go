@@ -0,0 +1,239 @@
+// Go routine for matching wildcards in ASCII strings, ignoring case.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import "strings"
+
+// Returns a copy of a string with 'A' through 'Z' lowercased, and every
+// other byte, including those of any UTF-8 sequences, left as is.
+func lowerAsciiString(str string) string {
+	slcLower := []byte(str)
+
+	for i, c := range slcLower {
+		slcLower[i] = lowerAscii(c)
+	}
+
+	return string(slcLower)
+}
+
+// Makes each uppercase ASCII letter a member of the set just if its
+// lowercase form is, for a set compiled from a lowercased pattern.
+func (set *byteSet) mirrorLowercase() {
+	for c := byte('A'); c <= 'Z'; c++ {
+		if set.has(lowerAscii(c)) {
+			set.add(c)
+		} else {
+			set.remove(c)
+		}
+	}
+}
+
+// Compares two ASCII strings as FastWildCompareAscii() compares them, but
+// with each uppercase ASCII letter matching its lowercase form, so that
+// "*.TXT" matches "notes.txt".  Letters are folded byte by byte, as they
+// are compared, so no lowercased copy of either string is made.  Bytes
+// other than 'A' through 'Z' and 'a' through 'z', including any that aren't
+// ASCII, match only themselves.  Bracket classes are accepted, and compared
+// as if both strings were lowercased, so "[A-C]" matches 'b' and "[!X]"
+// matches neither 'x' nor 'X'.
+func FastWildCompareAsciiFold(strWild, strTame string) bool {
+	var iWild int = 0     // Index for pattern content
+	var iTame int = 0     // Index for tame content
+	var iWildSequence int // Index for prospective match after '*'
+	var iTameSequence int // Index for match in tame content
+
+	// A pattern without wildcards can only match content of the same length.
+	if !hasWildcards(strWild) {
+		if len(strWild) != len(strTame) {
+			return false
+		}
+
+		for i := 0; i < len(strWild); i++ {
+			if lowerAscii(strWild[i]) != lowerAscii(strTame[i]) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	// Bracket classes take more than one pattern byte apiece, so they are
+	// compiled to tokens, each matching one byte, like a '?'.  The tokens
+	// are compiled from a lowercased copy of the pattern, and then each
+	// uppercase letter is matched just where its lowercase form is, so
+	// that "[!X]" matches neither 'x' nor 'X'.
+	if strings.IndexByte(strWild, '[') >= 0 {
+		slcTokens, _ := compileClassTokens(lowerAsciiString(strWild), false)
+
+		for i := range slcTokens {
+			slcTokens[i].set.mirrorLowercase()
+		}
+
+		return matchClassTokens(slcTokens, strTame)
+	}
+
+	// Find a first wildcard, if one exists, and the beginning of any
+	// prospectively matching sequence after it.  Until a '*' turns up,
+	// iWild and iTame advance together, so they're equal throughout this
+	// loop, but each is used only to index its own string.
+	for {
+		// Check for the end from the start.  Get out fast, if possible.
+		if len(strTame) <= iTame {
+			if len(strWild) > iWild {
+				for strWild[iWild] == '*' {
+					iWild++
+
+					if len(strWild) <= iWild {
+						return true // "ab" matches "ab*".
+					}
+				}
+
+				return false // "abcd" doesn't match "abc".
+			} else {
+				return true // "abc" matches "abc".
+			}
+		} else if len(strWild) <= iWild {
+			return false // "abc" doesn't match "abcd".
+		} else if strWild[iWild] == '*' {
+			// Got wild: set up for the second loop and skip on down there.
+			for {
+				iWild++
+
+				if len(strWild) <= iWild {
+					return true // "abc*" matches "abcd".
+				}
+
+				if strWild[iWild] != '*' {
+					break
+				}
+			}
+
+			// Search for the next prospective match.
+			if strWild[iWild] != '?' {
+				for lowerAscii(strWild[iWild]) != lowerAscii(strTame[iTame]) {
+					iTame++
+
+					if len(strTame) <= iTame {
+						return false // "a*bc" doesn't match "ab".
+					}
+				}
+			}
+
+			// Keep fallback positions for retry in case of incomplete match.
+			iWildSequence = iWild
+			iTameSequence = iTame
+			break
+		} else if strWild[iWild] != '?' &&
+			lowerAscii(strWild[iWild]) != lowerAscii(strTame[iTame]) {
+			return false // "abc" doesn't match "abd".
+		}
+
+		iWild++ // Everything's a match, so far.
+		iTame++
+	}
+
+	// Find any further wildcards and any further matching sequences.
+	for {
+		if len(strWild) > iWild && strWild[iWild] == '*' {
+			// Got wild again.
+			for {
+				iWild++
+
+				if len(strWild) <= iWild {
+					return true // "ab*c*" matches "abcd".
+				}
+
+				if strWild[iWild] != '*' {
+					break
+				}
+			}
+
+			if len(strTame) <= iTame {
+				return false // "*bcd*" doesn't match "abc".
+			}
+
+			// Search for the next prospective match.
+			if strWild[iWild] != '?' {
+				for lowerAscii(strWild[iWild]) != lowerAscii(strTame[iTame]) {
+					iTame++
+
+					if len(strTame) <= iTame {
+						return false // "a*b*c" doesn't match "ab".
+					}
+				}
+			}
+
+			// Keep the new fallback positions.
+			iWildSequence = iWild
+			iTameSequence = iTame
+		} else {
+			// The equivalent portion of the upper loop is really simple.
+			if len(strTame) <= iTame {
+				if len(strWild) <= iWild {
+					return true // "*b*c" matches "abc".
+				}
+
+				return false // "*bcd" doesn't match "abc".
+			}
+
+			if len(strWild) <= iWild ||
+				strWild[iWild] != '?' &&
+					lowerAscii(strWild[iWild]) != lowerAscii(strTame[iTame]) {
+				// A fine time for questions.
+				for len(strWild) > iWildSequence &&
+					strWild[iWildSequence] == '?' {
+					iWildSequence++
+					iTameSequence++
+				}
+
+				iWild = iWildSequence
+
+				// Fall back, but never so far again.
+				for {
+					iTameSequence++
+
+					if len(strTame) <= iTameSequence {
+						if len(strWild) <= iWild {
+							return true // "*a*b" matches "ab".
+						} else {
+							return false // "*a*b" doesn't match "ac".
+						}
+					}
+
+					if len(strWild) > iWild && lowerAscii(strWild[iWild]) ==
+						lowerAscii(strTame[iTameSequence]) {
+						break
+					}
+				}
+
+				iTame = iTameSequence
+			}
+		}
+
+		// Another check for the end, at the end.
+		if len(strTame) <= iTame {
+			if len(strWild) <= iWild {
+				return true // "*bc" matches "abc".
+			}
+
+			return false // "*bc" doesn't match "abcd".
+		}
+
+		iWild++ // Everything's still a match.
+		iTame++
+	}
+}
@@ -0,0 +1,94 @@
+// Go tests for matching wildcards in ASCII strings, ignoring case.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import "testing"
+
+// Tests for FastWildCompareAsciiFold(), which must fold only the ASCII
+// letters.
+func TestAsciiFold(t *testing.T) {
+	for _, testCase := range []struct {
+		strWild   string
+		strTame   string
+		bExpected bool
+	}{
+		{"*.TXT", "notes.txt", true},
+		{"ReadMe", "README", true},
+		{"a?C*z", "AbcXYZ", true},
+		{"a?C*z", "Ab", false},
+		{"*B*b", "abaB", true},
+
+		// Letters next to digits and symbols, where setting the 0x20 bit of
+		// every byte would confuse '@' with '`', '[' with '{', ']' with '}',
+		// '^' with '~', '_' with DEL, and control characters with digits.
+		{"A1@b", "a1@B", true},
+		{"A1@b", "a1`B", false},
+		{"a{b", "A[B", false},
+		{"*}Z", "x]z", false},
+		{"X~*", "x^y", false},
+		{"my_*", "MY\x7fFILE", false},
+		{"my_*", "MY_FILE", true},
+		{"*0a", "\x10A", false},
+		{"é", "É", false},
+
+		// A bracket class is compared as if both strings were lowercased,
+		// and a '[' in the tame string is still just a '['.
+		{"[a-c]*", "Beta", true},
+		{"[!X]?", "ab", true},
+		{"[!X]?", "xb", false},
+		{"[!x]?", "Xb", false},
+		{"[A-C]?", "bB", true},
+		{"*[[]A", "x[a", true},
+		{"*[[]A", "x{a", false},
+	} {
+		if FastWildCompareAsciiFold(testCase.strWild,
+			testCase.strTame) != testCase.bExpected {
+			t.Errorf("FastWildCompareAsciiFold(%q, %q) = %t, want %t",
+				testCase.strWild, testCase.strTame, !testCase.bExpected,
+				testCase.bExpected)
+		}
+	}
+
+	// Returns a copy of a string with just 'A' through 'Z' lowercased.
+	lowerAscii := func(str string) string {
+		slcLower := []byte(str)
+
+		for i, c := range slcLower {
+			if 'A' <= c && c <= 'Z' {
+				slcLower[i] = c + 'a' - 'A'
+			}
+		}
+
+		return string(slcLower)
+	}
+
+	// Each comparison gets the same result as from lowercasing both strings
+	// up front.
+	for _, strWild := range allStrings([]string{"*", "?", "a", "B", "@"},
+		4) {
+		for _, strTame := range allStrings([]string{"a", "b", "A", "B",
+			"`"}, 4) {
+			bExpected := FastWildCompareAscii(lowerAscii(strWild),
+				lowerAscii(strTame))
+
+			if FastWildCompareAsciiFold(strWild, strTame) != bExpected {
+				t.Errorf("FastWildCompareAsciiFold(%q, %q) = %t, want %t",
+					strWild, strTame, !bExpected, bExpected)
+			}
+		}
+	}
+}
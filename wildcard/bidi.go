@@ -0,0 +1,54 @@
+// Go routines for matching wildcards against bidirectional text.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import "unicode"
+
+// Returns the runes of a string, in logical order, without any of the
+// bidirectional formatting characters, such as the left-to-right and
+// right-to-left marks and the directional embeddings, overrides, and
+// isolates.
+func runesWithoutBidiControls(str string) []rune {
+	rslc := make([]rune, 0, len(str))
+
+	for _, r := range str {
+		if !unicode.Is(unicode.Bidi_Control, r) {
+			rslc = append(rslc, r)
+		}
+	}
+
+	return rslc
+}
+
+// Compares two UTF-8 strings as FastWildCompareRuneSlices() does, for text
+// that may mix right-to-left scripts such as Hebrew and Arabic with
+// left-to-right content.  Matching is done in logical order, the order in
+// which the runes are stored and typed, which is the order that editors and
+// input methods produce.  Text stored in visual order, as some legacy
+// systems did, looks the same on screen but is stored reversed, and can't
+// be told apart from logical-order text by its content, so it must be
+// converted by the caller.
+//
+// The bidirectional formatting characters listed in unicode.Bidi_Control
+// only affect how text is displayed, and are often inserted invisibly by
+// editors and copy-and-paste.  They're ignored in both strings, so that a
+// right-to-left mark at the start of a line doesn't keep it from matching,
+// and so that a '?' never matches one.
+func FastWildCompareBidi(strWild, strTame string) bool {
+	return FastWildCompareRuneSlices(runesWithoutBidiControls(strWild),
+		runesWithoutBidiControls(strTame))
+}
@@ -0,0 +1,113 @@
+// Go tests for the routines for matching wildcards against bidirectional
+// text.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import "testing"
+
+// Tests for matching right-to-left text in logical order, including tests
+// for FastWildCompareBidi().
+func TestBidi(t *testing.T) {
+	// Hebrew, stored in logical order: "shalom olam", with the shin first.
+	strHebrew := "שלום עולם"
+	strMarked := "\u200Fשלום\u200E 2"
+
+	for _, testCase := range []struct {
+		strWild   string
+		strTame   string
+		bExpected bool
+	}{
+		{"שלום*", strHebrew, true},
+		{"*עולם", strHebrew, true},
+		{"ש?ום ?ולם", strHebrew, true},
+		{"*ום עו*", strHebrew, true},
+		{"?*?", strHebrew, true},
+		{"*שלום", strHebrew, false},
+		{"עולם*", strHebrew, false},
+
+		// The same text in visual order, reversed, doesn't match.
+		{"םולש*", strHebrew, false},
+		{"*םלוע", strHebrew, false},
+		{strHebrew, "םלוע םולש", false},
+
+		// Hebrew points are runes of their own.
+		{"ש\u05B8\u05C1לו\u05B9ם", "ש\u05B8\u05C1לו\u05B9ם", true},
+		{"שלום", "ש\u05B8\u05C1לו\u05B9ם", false},
+		{"ש*ל*ו*ם", "ש\u05B8\u05C1לו\u05B9ם", true},
+
+		// Arabic, and right-to-left text mixed with left-to-right content.
+		{"مرحبا*", "مرحبا بالعالم", true},
+		{"*بالعالم", "مرحبا بالعالم", true},
+		{"גרסה ? *", "גרסה 2 beta", true},
+		{"Release: *", "Release: גרסה 2", true},
+		{"*2", "Release: גרסה 2", true},
+
+		// The rune comparison treats formatting characters as runes.
+		{"שלום*", strMarked, false},
+		{"?שלום?*", strMarked, true},
+	} {
+		if FastWildCompareRuneSlices([]rune(testCase.strWild),
+			[]rune(testCase.strTame)) != testCase.bExpected {
+			t.Errorf("FastWildCompareRuneSlices(%q, %q) = %t, want %t",
+				testCase.strWild, testCase.strTame, !testCase.bExpected,
+				testCase.bExpected)
+		}
+	}
+
+	// The byte-oriented comparison agrees on logical-order UTF-8 text.
+	if !FastWildCompareAscii("שלום*", strHebrew) ||
+		FastWildCompareAscii("םולש*", strHebrew) {
+		t.Errorf("FastWildCompareAscii() disagrees on %q", strHebrew)
+	}
+
+	// FastWildCompareBidi() ignores formatting characters.
+	for _, testCase := range []struct {
+		strWild   string
+		strTame   string
+		bExpected bool
+	}{
+		{"שלום*", strMarked, true},
+		{"שלום 2", strMarked, true},
+		{"?שלום?*", strMarked, false},
+		{"\u202Bשלום\u202C*", strHebrew, true},
+		{"\u2067?\u2069", "\u061Cx", true},
+		{"?", "\u200F", false},
+		{"", "\u200E\u200F", true},
+		{"םולש*", strMarked, false},
+	} {
+		if FastWildCompareBidi(testCase.strWild,
+			testCase.strTame) != testCase.bExpected {
+			t.Errorf("FastWildCompareBidi(%q, %q) = %t, want %t",
+				testCase.strWild, testCase.strTame, !testCase.bExpected,
+				testCase.bExpected)
+		}
+	}
+
+	// Without formatting characters, results are the same as
+	// FastWildCompareRuneSlices().
+	for _, strWild := range allStrings([]string{"*", "?", "ש", "a"}, 4) {
+		for _, strTame := range allStrings([]string{"ש", "a", "ם"}, 4) {
+			bExpected := FastWildCompareRuneSlices([]rune(strWild),
+				[]rune(strTame))
+
+			if FastWildCompareBidi(strWild, strTame) != bExpected {
+				t.Errorf("FastWildCompareBidi(%q, %q) = %t, want %t",
+					strWild, strTame, !bExpected, bExpected)
+			}
+		}
+	}
+}
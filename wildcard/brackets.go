@@ -0,0 +1,247 @@
+// Go routines for matching bracket classes, such as "[a-z]", in patterns.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// The error reported by MatchValidated() for a bracket class with a
+	// range that ends before it starts, such as "[z-a]".
+	ErrEmptyRange = errors.New("range in bracket class ends before it starts")
+
+	// The error reported by Validate() for a '[' that's never closed.
+	ErrUnclosedClass = errors.New("unclosed character class")
+
+	// The error reported by Validate() for a backslash that ends a pattern,
+	// leaving nothing to escape.
+	ErrTrailingEscape = errors.New("trailing backslash has nothing to escape")
+)
+
+// Parses a bracket class, such as "[abc]" or "[a-z_]", starting at the '['
+// at strWild[i].  Returns the set of bytes it matches and the index just
+// past its closing ']', or -1 if there's no closing ']', meaning that the
+// '[' is just a literal.  A leading '!' or '^' negates the set, and a ']'
+// right after the opening '[' or the negation is a member rather than the
+// end.  A '-' between two members makes a range, while a '-' at either
+// end is a member.  With bEscape, a backslash makes the byte after it a
+// member, and never a range's '-' or the closing ']'.
+//
+// A range whose end is lower than its start, such as "z-a", matches
+// nothing.  The first such range is reported via the error result, which
+// wraps ErrEmptyRange with the offset of the range, and is otherwise nil.
+// The range doesn't keep the class from being parsed.
+func parseClass(strWild string, i int, bEscape bool) (byteSet, int,
+	error) {
+	var set byteSet
+	var err error
+	bNegated := false
+	i++
+
+	if i < len(strWild) && (strWild[i] == '!' || strWild[i] == '^') {
+		bNegated = true
+		i++
+	}
+
+	// Reads the member at strWild[i], which must exist.
+	readMember := func() byte {
+		if bEscape && strWild[i] == '\\' && i+1 < len(strWild) {
+			i += 2
+		} else {
+			i++
+		}
+
+		return strWild[i-1]
+	}
+
+	for iFirst := i; ; {
+		if i >= len(strWild) {
+			return set, -1, nil
+		} else if strWild[i] == ']' && i > iFirst {
+			break
+		}
+
+		iMember := i
+		cFirst := readMember()
+		cLast := cFirst
+
+		if i+1 < len(strWild) && strWild[i] == '-' && strWild[i+1] != ']' {
+			i++
+			cLast = readMember()
+		}
+
+		if cLast >= cFirst {
+			set.addRange(cFirst, cLast)
+		} else if err == nil {
+			err = fmt.Errorf("%w at offset %d", ErrEmptyRange, iMember)
+		}
+	}
+
+	if bNegated {
+		for j := range set {
+			set[j] = ^set[j]
+		}
+	}
+
+	return set, i + 1, err
+}
+
+// Compiles an ASCII pattern with bracket classes, as accepted by
+// FastWildCompareAscii(), to tokens.  With bEscape, backslash escapes are
+// accepted as well, as described for FastWildCompareAsciiEscaped().  The
+// error result is that of the first class with a range that matches
+// nothing, if any; the tokens are complete regardless.
+func compileClassTokens(strWild string, bEscape bool) ([]wildToken, error) {
+	var errFirst error
+	slcTokens := make([]wildToken, 0, len(strWild))
+
+	for i := 0; i < len(strWild); i++ {
+		switch strWild[i] {
+		case '*':
+			slcTokens = append(slcTokens, runToken(byteSetAll))
+			continue
+		case '?':
+			slcTokens = append(slcTokens, singleToken(byteSetAll))
+			continue
+		case '\\':
+			if bEscape && i+1 < len(strWild) {
+				i++
+			}
+		case '[':
+			set, iNext, err := parseClass(strWild, i, bEscape)
+
+			if iNext >= 0 {
+				if errFirst == nil {
+					errFirst = err
+				}
+
+				slcTokens = append(slcTokens, singleToken(set))
+				i = iNext - 1
+				continue
+			}
+		}
+
+		slcTokens = append(slcTokens, singleToken(byteSetOf(strWild[i])))
+	}
+
+	return slcTokens, errFirst
+}
+
+// Reports whether the tame string or byte slice, in its entirety, matches
+// tokens compiled by compileClassTokens(), each a '*' or a single byte.
+// Like the '?'s of FastWildCompareAscii(), each bracket class matches one
+// byte, found via a set-membership check, so the tokens are compared via
+// the single-fallback approach: only the last '*' ever takes more bytes,
+// since a later '*' can always stand in for an earlier one.  So nothing is
+// allocated, and the cost grows with the product of the lengths at worst,
+// never with its square, as it can for the search of matchWildTokens().
+//
+// A '*' token matches runs of the bytes in its set.  A byte withheld from
+// any '*' must be withheld from every token other than those that match
+// nothing else, as a path separator is, so that such bytes split the tame
+// content at the same places for every match.  The last '*' then never
+// needs to stand in for a '*' before one of them.
+func matchClassTokens[T string | []byte](slcTokens []wildToken,
+	tame T) bool {
+	bMatch, _ := matchClassTokensBudget(slcTokens, tame, 0, false)
+	return bMatch
+}
+
+// Reports whether the tame string or byte slice matches the tokens, as
+// matchClassTokens() does, while charging each step, as defined for
+// FastWildCompareAsciiBudget(), against a budget.  Returns the budget less
+// the steps taken, along with the result.  If bLimit is true, gives up as
+// soon as the budget is overspent, returning false.
+func matchClassTokensBudget[T string | []byte](slcTokens []wildToken,
+	tame T, iBudget int, bLimit bool) (bool, int) {
+	iToken := 0
+	iTame := 0
+	iTokenStar := -1 // Index of the '*' we can fall back to, if any
+	iTameStar := 0   // Where content consumed by that '*' ends
+
+	for iTame < len(tame) {
+		iBudget--
+
+		if bLimit && iBudget < 0 {
+			return false, iBudget
+		} else if iToken < len(slcTokens) && slcTokens[iToken].iMax < 0 {
+			iTokenStar = iToken
+			iTameStar = iTame
+			iToken++
+		} else if iToken < len(slcTokens) &&
+			slcTokens[iToken].set.has(tame[iTame]) {
+			iToken++
+			iTame++
+		} else if iTokenStar >= 0 &&
+			slcTokens[iTokenStar].set.has(tame[iTameStar]) {
+			// Let the last '*' consume one more byte, and retry.
+			iTameStar++
+			iToken = iTokenStar + 1
+			iTame = iTameStar
+		} else {
+			return false, iBudget
+		}
+	}
+
+	for iToken < len(slcTokens) && slcTokens[iToken].iMax < 0 {
+		iBudget--
+		iToken++
+
+		if bLimit && iBudget < 0 {
+			return false, iBudget
+		}
+	}
+
+	return iToken == len(slcTokens), iBudget
+}
+
+// Reports the first problem with an ASCII pattern, as accepted by
+// FastWildCompareAsciiEscaped(), so that callers can check user-supplied
+// patterns up front, rather than having a malformed part of one silently
+// match as literals.  The problems are a '[' that's never closed, reported
+// via ErrUnclosedClass, a range that ends before it starts, as in "[z-a]",
+// reported via ErrEmptyRange, and a backslash that ends the pattern,
+// reported via ErrTrailingEscape.  The error names the byte offset of the
+// problem, as in "unclosed character class at offset 5", and errors.Is()
+// recognizes it as the error it wraps.  Returns nil for a well-formed
+// pattern.
+func Validate(strWild string) error {
+	for i := 0; i < len(strWild); i++ {
+		switch strWild[i] {
+		case '\\':
+			if i+1 >= len(strWild) {
+				return fmt.Errorf("%w at offset %d", ErrTrailingEscape, i)
+			}
+
+			i++
+		case '[':
+			_, iNext, err := parseClass(strWild, i, true)
+
+			if iNext < 0 {
+				return fmt.Errorf("%w at offset %d", ErrUnclosedClass, i)
+			} else if err != nil {
+				return err
+			}
+
+			i = iNext - 1
+		}
+	}
+
+	return nil
+}
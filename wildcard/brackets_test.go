@@ -0,0 +1,201 @@
+// Go tests for bracket classes.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import (
+	"errors"
+	"testing"
+)
+
+// Tests for Validate(), with well-formed and malformed patterns, checking
+// each error's kind and the offset in its message.
+func TestValidate(t *testing.T) {
+	// Well-formed patterns, including escaped brackets and backslashes.
+	for _, strWild := range []string{"", "*", "a?c*", "[a-z]*", "[]]",
+		"[!]a]", "\\[", "a\\\\", "[\\]]", "[a-a]", "[-z]", "[a-]"} {
+		if err := Validate(strWild); err != nil {
+			t.Errorf("Validate(%q) = %v, want nil", strWild, err)
+		}
+	}
+
+	for _, testCase := range []struct {
+		strWild     string
+		errExpected error
+		strMessage  string
+	}{
+		// Unclosed brackets, reported at the '['.
+		{"abcd*[ef", ErrUnclosedClass,
+			"unclosed character class at offset 5"},
+		{"[", ErrUnclosedClass, "unclosed character class at offset 0"},
+		{"[]", ErrUnclosedClass, "unclosed character class at offset 0"},
+		{"[a\\]", ErrUnclosedClass, "unclosed character class at offset 0"},
+
+		// Reversed ranges, reported at the start of the range.
+		{"[z-a]", ErrEmptyRange,
+			"range in bracket class ends before it starts at offset 1"},
+		{"x[!ab9-0]*", ErrEmptyRange,
+			"range in bracket class ends before it starts at offset 5"},
+
+		// Trailing backslashes, reported at the backslash.
+		{"abc\\", ErrTrailingEscape,
+			"trailing backslash has nothing to escape at offset 3"},
+		{"a\\\\\\", ErrTrailingEscape,
+			"trailing backslash has nothing to escape at offset 3"},
+
+		// Only the first problem is reported.
+		{"[b-a][", ErrEmptyRange,
+			"range in bracket class ends before it starts at offset 1"},
+		{"[[z-a]", ErrEmptyRange,
+			"range in bracket class ends before it starts at offset 2"},
+	} {
+		err := Validate(testCase.strWild)
+
+		if !errors.Is(err, testCase.errExpected) ||
+			err.Error() != testCase.strMessage {
+			t.Errorf("Validate(%q) = %v, want %q", testCase.strWild, err,
+				testCase.strMessage)
+		}
+	}
+}
+
+// Tests for bracket classes, such as "[abc]" and "[a-z]", in
+// FastWildCompareAscii() and FastWildCompareRuneSlices().
+func TestBrackets(t *testing.T) {
+	for _, testCase := range []struct {
+		strWild   string
+		strTame   string
+		bExpected bool
+	}{
+		// Ranges.
+		{"[a-z]", "m", true},
+		{"[a-z]", "M", false},
+		{"file[0-9].txt", "file7.txt", true},
+		{"file[0-9].txt", "fileX.txt", false},
+		{"[a-cx-z][0-9]", "y5", true},
+		{"[a-cx-z][0-9]", "d5", false},
+
+		// Sets of several characters.
+		{"gr[ae]y", "gray", true},
+		{"gr[ae]y", "grey", true},
+		{"gr[ae]y", "groy", false},
+		{"gr[ae]y", "graey", false},
+		{"[-+]1", "-1", true},
+		{"[+-]1", "-1", true},
+		{"[a!]", "!", true},
+
+		// Interaction with '*' and '?'.
+		{"*.[ch]", "main.c", true},
+		{"*.[ch]", "main.go", false},
+		{"*[0-9]*", "abc123def", true},
+		{"*[0-9]*", "abcdef", false},
+		{"*[xy]?[xy]", "axaybx", true},
+		{"*[xy]?[xy]", "axaybz", false},
+		{"a*[bc]*d", "aXXcYYd", true},
+		{"a*[bc]*d", "aXXYYd", false},
+		{"*[ab][ab][ab]", "cabbab", true},
+
+		// A ']' first in the class is a member, not the end of the class.
+		{"[]]", "]", true},
+		{"[]a]", "a", true},
+		{"[]a]", "b", false},
+		{"x[]-a]", "x^", true},
+
+		// A '[' that's never closed is a literal.
+		{"[", "[", true},
+		{"a[b", "a[b", true},
+		{"a[b", "ab", false},
+		{"*[a-", "x[a-", true},
+		{"[]", "[]", true},
+
+		// Empty content never matches a class.
+		{"[a-z]", "", false},
+		{"*[a-z]*", "", false},
+		{"[]]", "", false},
+
+		// Negated classes match any one character not listed.
+		{"[!0-9]", "x", true},
+		{"[!0-9]", "5", false},
+		{"[^0-9]", "x", true},
+		{"[^0-9]", "5", false},
+		{"[!a]", "!", true},
+		{"[!a]", "a", false},
+		{"[!a]", "bc", false},
+		{"[!a]", "", false},
+		{"[!]]", "]", false},
+		{"[!]]", "a", true},
+		{"[!", "[!", true},
+		{"*[!z]", "abc", true},
+		{"*[!c]", "abc", false},
+		{"?[!b]?", "abc", false},
+		{"?[!x]?", "abc", true},
+		{"a*[!.]", "a.b", true},
+		{"a*[!.]", "ab.", false},
+		{"*[!a]*", "aaa", false},
+		{"*[!a]*", "aba", true},
+		{"*[!a]*", "", false},
+	} {
+		if FastWildCompareAscii(testCase.strWild,
+			testCase.strTame) != testCase.bExpected {
+			t.Errorf("FastWildCompareAscii(%q, %q) = %t, want %t",
+				testCase.strWild, testCase.strTame, !testCase.bExpected,
+				testCase.bExpected)
+		}
+
+		if FastWildCompareRuneSlices([]rune(testCase.strWild),
+			[]rune(testCase.strTame)) != testCase.bExpected {
+			t.Errorf("FastWildCompareRuneSlices(%q, %q) = %t, want %t",
+				testCase.strWild, testCase.strTame, !testCase.bExpected,
+				testCase.bExpected)
+		}
+	}
+
+	// Classes of runes beyond ASCII.
+	for _, testCase := range []struct {
+		strWild   string
+		strTame   string
+		bExpected bool
+	}{
+		{"[α-ω]", "λ", true},
+		{"[α-ω]", "Λ", false},
+		{"*[日月]", "今日", true},
+		{"*[日月]", "今年", false},
+		{"[α-ω]?", "λ😀", true},
+		{"[!α-ω]", "λ", false},
+		{"[!α-ω]", "Λ", true},
+		{"*[^日]", "今日", false},
+		{"*[^日]", "日本", true},
+		{"[!a]", "😀", true},
+	} {
+		if FastWildCompareRuneSlices([]rune(testCase.strWild),
+			[]rune(testCase.strTame)) != testCase.bExpected {
+			t.Errorf("FastWildCompareRuneSlices(%q, %q) = %t, want %t",
+				testCase.strWild, testCase.strTame, !testCase.bExpected,
+				testCase.bExpected)
+		}
+	}
+
+	// Patterns with brackets aren't mistaken for simpler shapes.
+	for _, strWild := range []string{"[ab]", "x[ab]*"} {
+		if kind := Classify(strWild); kind != PatternGeneral {
+			t.Errorf("Classify(%q) = %v, want PatternGeneral", strWild, kind)
+		}
+	}
+
+	if fnMatch := BestMatcher("*[0-9]"); !fnMatch("abc7") || fnMatch("abc") {
+		t.Errorf("BestMatcher(%q) doesn't respect its class", "*[0-9]")
+	}
+}
@@ -0,0 +1,279 @@
+// Go routines for matching wildcards with accounting for the work done.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import (
+	"context"
+	"strings"
+)
+
+// How many steps FastWildCompareAsciiCtx() takes between checks of its
+// context.  A check takes far longer than a step, so it's made rarely
+// enough that comparisons of typical lengths finish without ever making
+// one, while a pathological comparison is still cancelled within
+// microseconds.
+const ctxCheckSteps = 1 << 12
+
+// Compares two ASCII strings as FastWildCompareAscii() does, while charging
+// each step of the comparison against a budget, for callers that throttle
+// their matching adaptively.  A step is any comparison of a pattern
+// character against the tame content, including each retry after a '*'
+// takes one more character.  Returns whether the strings match, along with
+// the budget less the number of steps taken.  The comparison always runs
+// to completion, so the boolean result is exact, and a negative remainder
+// tells how far the comparison overshot the budget.
+//
+// The steps taken grow with the length of the tame content and, for
+// patterns whose '*'s must fall back repeatedly, with the length of the
+// literal runs after them, so the remainder gauges how costly a pattern is
+// for the content at hand.  A bracket class takes a step wherever a '?'
+// would.
+func FastWildCompareAsciiBudget(strWild, strTame string, iBudget int) (bool,
+	int) {
+	return compareAsciiBudget(strWild, strTame, iBudget, false)
+}
+
+// Compares two ASCII strings as FastWildCompareAscii() does, while counting
+// the steps taken, as defined for FastWildCompareAsciiBudget(), and giving
+// up once there have been more than iMaxSteps of them, for services that
+// cap the work done per match on untrusted input.  Pathological patterns
+// such as "*a*a*a*a*b", whose '*'s fall back over and over against long
+// content that doesn't match, are cut short this way.  Returns whether the
+// strings match, which is false if the comparison gave up, along with
+// whether it gave up.
+//
+// The steps taken never exceed WorstCaseSteps() for the pattern and the
+// tame length, so a limit of at least that always lets the comparison
+// finish.
+func FastWildCompareAsciiLimit(strWild, strTame string,
+	iMaxSteps int) (bool, bool) {
+	bMatch, iRemaining := compareAsciiBudget(strWild, strTame, iMaxSteps,
+		true)
+	return bMatch, iRemaining < 0
+}
+
+// Implements FastWildCompareAsciiBudget() and FastWildCompareAsciiLimit(),
+// giving up as soon as the budget is overspent if bLimit is true.
+func compareAsciiBudget(strWild, strTame string, iBudget int,
+	bLimit bool) (bool, int) {
+	// Bracket classes are compiled to tokens, each matching one byte.
+	if strings.IndexByte(strWild, '[') >= 0 {
+		slcTokens, _ := compileClassTokens(strWild, false)
+		return matchClassTokensBudget(slcTokens, strTame, iBudget, bLimit)
+	}
+
+	iWild := 0
+	iTame := 0
+	iWildStar := -1 // Index of the '*' we can fall back to, if any
+	iTameStar := 0  // Where content consumed by that '*' ends
+
+	for iTame < len(strTame) {
+		iBudget--
+
+		if bLimit && iBudget < 0 {
+			return false, iBudget
+		} else if iWild < len(strWild) && strWild[iWild] == '*' {
+			iWildStar = iWild
+			iTameStar = iTame
+			iWild++
+		} else if iWild < len(strWild) &&
+			(strWild[iWild] == '?' || strWild[iWild] == strTame[iTame]) {
+			iWild++
+			iTame++
+		} else if iWildStar >= 0 {
+			// Let the last '*' consume one more character, and retry.
+			iTameStar++
+			iWild = iWildStar + 1
+			iTame = iTameStar
+		} else {
+			return false, iBudget
+		}
+	}
+
+	for iWild < len(strWild) && strWild[iWild] == '*' {
+		iBudget--
+		iWild++
+
+		if bLimit && iBudget < 0 {
+			return false, iBudget
+		}
+	}
+
+	return iWild == len(strWild), iBudget
+}
+
+// Compares two ASCII strings as FastWildCompareAscii() does, while checking
+// for cancellation of a context, for servers that match user-supplied
+// patterns and cancel matches that run too long.  The context is checked
+// once per few thousand steps, as defined for FastWildCompareAsciiBudget(),
+// so that comparisons of typical lengths never pay for a check.  If the
+// context is found to be done, the comparison stops, and false is returned
+// along with ctx.Err().  Otherwise, the error result is nil, even if the
+// context has been done since the last check.
+func FastWildCompareAsciiCtx(ctx context.Context, strWild,
+	strTame string) (bool, error) {
+	// Bracket classes are compiled to tokens, each matching one byte.
+	if strings.IndexByte(strWild, '[') >= 0 {
+		slcTokens, _ := compileClassTokens(strWild, false)
+		return matchClassTokensCtx(ctx, slcTokens, strTame)
+	}
+
+	iWild := 0
+	iTame := 0
+	iWildStar := -1             // Index of the '*' we can fall back to, if any
+	iTameStar := 0              // Where content consumed by that '*' ends
+	iUnchecked := ctxCheckSteps // Steps left until the context is checked
+
+	for iTame < len(strTame) {
+		iUnchecked--
+
+		if iUnchecked == 0 {
+			if err := ctx.Err(); err != nil {
+				return false, err
+			}
+
+			iUnchecked = ctxCheckSteps
+		}
+
+		if iWild < len(strWild) && strWild[iWild] == '*' {
+			iWildStar = iWild
+			iTameStar = iTame
+			iWild++
+		} else if iWild < len(strWild) &&
+			(strWild[iWild] == '?' || strWild[iWild] == strTame[iTame]) {
+			iWild++
+			iTame++
+		} else if iWildStar >= 0 {
+			// Let the last '*' consume one more character, and retry.
+			iTameStar++
+			iWild = iWildStar + 1
+			iTame = iTameStar
+		} else {
+			return false, nil
+		}
+	}
+
+	for iWild < len(strWild) && strWild[iWild] == '*' {
+		iWild++
+	}
+
+	return iWild == len(strWild), nil
+}
+
+// Implements FastWildCompareAsciiCtx() for a pattern compiled to tokens
+// by compileClassTokens(), comparing them as matchClassTokens() does.
+func matchClassTokensCtx(ctx context.Context, slcTokens []wildToken,
+	strTame string) (bool, error) {
+	iToken := 0
+	iTame := 0
+	iTokenStar := -1            // Index of the '*' we can fall back to, if any
+	iTameStar := 0              // Where content consumed by that '*' ends
+	iUnchecked := ctxCheckSteps // Steps left until the context is checked
+
+	for iTame < len(strTame) {
+		iUnchecked--
+
+		if iUnchecked == 0 {
+			if err := ctx.Err(); err != nil {
+				return false, err
+			}
+
+			iUnchecked = ctxCheckSteps
+		}
+
+		if iToken < len(slcTokens) && slcTokens[iToken].iMax < 0 {
+			iTokenStar = iToken
+			iTameStar = iTame
+			iToken++
+		} else if iToken < len(slcTokens) &&
+			slcTokens[iToken].set.has(strTame[iTame]) {
+			iToken++
+			iTame++
+		} else if iTokenStar >= 0 {
+			// Let the last '*' consume one more byte, and retry.
+			iTameStar++
+			iToken = iTokenStar + 1
+			iTame = iTameStar
+		} else {
+			return false, nil
+		}
+	}
+
+	for iToken < len(slcTokens) && slcTokens[iToken].iMax < 0 {
+		iToken++
+	}
+
+	return iToken == len(slcTokens), nil
+}
+
+// Returns the most steps that FastWildCompareAsciiBudget() could take to
+// compare a pattern against any tame string of up to iMaxTameLen
+// characters, as worked out from the pattern alone.  This suits admission
+// control for user-submitted patterns, since a pattern's cost can be
+// bounded before it's ever matched.
+//
+// Each '*' takes a step, as does each character of the literal run before
+// the first '*', or one more than that if there's no '*'.  After that, the
+// comparison only ever falls back to later tame positions, one at a time,
+// so each tame position is a fallback position at most once.  From each
+// one, at a cost of one step to get there, up to as many characters are
+// compared as there are in the run after the '*' being retried, up to the
+// next '*'.  So the bound grows with the number of '*'s, plus the tame
+// length times the longest run that follows a '*'.
+func WorstCaseSteps(strWild string, iMaxTameLen int) int64 {
+	iTameLen := int64(max(iMaxTameLen, 0))
+
+	// The lengths of the runs between '*'s, counting each bracket class as
+	// one character, just as a '?' is counted.
+	slcTokens, _ := compileClassTokens(strWild, false)
+	slcRuns := []int64{0}
+
+	for _, token := range slcTokens {
+		if token.iMax < 0 {
+			slcRuns = append(slcRuns, 0)
+		} else {
+			slcRuns[len(slcRuns)-1]++
+		}
+	}
+
+	iPrefixLen := slcRuns[0]
+
+	// Without any '*', there's one more step to find extra tame content.
+	if len(slcRuns) == 1 {
+		return min(iPrefixLen+1, iTameLen)
+	}
+
+	var iLongest int64 // Length of the longest run following a '*'
+
+	for _, iRunLen := range slcRuns[1:] {
+		iLongest = max(iLongest, iRunLen)
+	}
+
+	// The tame positions that can be fallen back to, each with one step to
+	// get there, and with up to min(iLongest, iTail) characters compared
+	// from a position iTail characters from the end.
+	iStarts := iTameLen - min(iPrefixLen, iTameLen)
+	iSteps := min(iPrefixLen, iTameLen) + int64(len(slcRuns)-1) + iStarts
+
+	if iStarts <= iLongest {
+		iSteps += iStarts * (iStarts + 1) / 2
+	} else {
+		iSteps += iLongest*(iLongest+1)/2 + (iStarts-iLongest)*iLongest
+	}
+
+	return iSteps
+}
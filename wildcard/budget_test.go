@@ -0,0 +1,290 @@
+// Go tests for the routines that account for the work of matching.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Returns the steps taken to compare a pattern against a tame string, as
+// counted by FastWildCompareAsciiBudget().
+func stepsTaken(strWild, strTame string) int64 {
+	_, iRemaining := FastWildCompareAsciiBudget(strWild, strTame, 0)
+	return int64(-iRemaining)
+}
+
+// Tests for WorstCaseSteps(), whose bound must never be exceeded by the
+// steps counted by FastWildCompareAsciiBudget().
+func TestWorstCaseSteps(t *testing.T) {
+	// Crafted worst cases, where the run after the last '*' almost matches
+	// at every position, come within a factor of two of the bound.
+	for _, worstCase := range []struct {
+		strWild string
+		strTame string
+	}{
+		{"*", strings.Repeat("a", 1000)},
+		{"*aaaaaaaab", strings.Repeat("a", 1000)},
+		{"*a*a*a*a*a*a*a*a*b", strings.Repeat("a", 1000)},
+		{"x*" + strings.Repeat("?", 20) + "b", "x" + strings.Repeat("a", 999)},
+		{"abc", "abcd"},
+	} {
+		iBound := WorstCaseSteps(worstCase.strWild, len(worstCase.strTame))
+		iSteps := stepsTaken(worstCase.strWild, worstCase.strTame)
+
+		if iSteps > iBound || iSteps*2 < iBound {
+			t.Errorf("%q against %d bytes took %d steps, with a bound of %d",
+				worstCase.strWild, len(worstCase.strTame), iSteps, iBound)
+		}
+	}
+
+	// The bound for the longest tame string covers the shorter ones.
+	for _, strWild := range allStrings([]string{"*", "?", "a", "b"}, 5) {
+		for _, strTame := range allStrings([]string{"a", "b"}, 6) {
+			iBound := WorstCaseSteps(strWild, len(strTame))
+
+			for iLen := 0; iLen <= len(strTame); iLen++ {
+				if iSteps := stepsTaken(strWild, strTame[:iLen]); iSteps >
+					iBound {
+					t.Errorf("%q against %q took %d steps, with a bound "+
+						"of %d", strWild, strTame[:iLen], iSteps, iBound)
+				}
+			}
+		}
+	}
+
+	// The bound grows with the tame length, and more slowly for patterns
+	// whose '*'s are followed by shorter runs.
+	for _, testCase := range []struct {
+		strWild     string
+		iMaxTameLen int
+		iExpected   int64
+	}{
+		{"*abc", 0, 1},
+		{"abc", 0, 0},
+		{"abc", 2, 2},
+		{"abc", 100, 4},
+		{"*", 100, 101},
+		{"*abc", -1, 1},
+	} {
+		if iBound := WorstCaseSteps(testCase.strWild,
+			testCase.iMaxTameLen); iBound != testCase.iExpected {
+			t.Errorf("WorstCaseSteps(%q, %d) = %d, want %d",
+				testCase.strWild, testCase.iMaxTameLen, iBound,
+				testCase.iExpected)
+		}
+	}
+
+	if WorstCaseSteps("*abcd", 1000) <= WorstCaseSteps("*a*b*c*d", 1000) {
+		t.Errorf("the bound for \"*abcd\" doesn't exceed that for " +
+			"\"*a*b*c*d\"")
+	}
+
+	if WorstCaseSteps("*abcd", 2000) <= WorstCaseSteps("*abcd", 1000) {
+		t.Errorf("the bound doesn't grow with the tame length")
+	}
+}
+
+// Tests for FastWildCompareAsciiLimit(), including early bailout for a
+// pathological pattern.
+func TestLimit(t *testing.T) {
+	strTame := strings.Repeat("a", 100000)
+
+	// Each '*' followed by a long literal run falls back at each position
+	// of the tame content, comparing the whole run each time, for about ten
+	// million steps in all.  The comparison gives up after a thousand.
+	strWild := "*" + strings.Repeat("a", 100) + "b"
+
+	if iSteps := stepsTaken(strWild, strTame); iSteps <= 1000000 {
+		t.Errorf("%q took only %d steps", strWild, iSteps)
+	}
+
+	if bMatch, bExceeded := FastWildCompareAsciiLimit(strWild, strTame,
+		1000); bMatch || !bExceeded {
+		t.Errorf("FastWildCompareAsciiLimit(%q) with a limit of 1000 = "+
+			"%t, %t; want false, true", strWild, bMatch, bExceeded)
+	}
+
+	// With a limit of WorstCaseSteps(), it finishes.
+	iLimit := int(WorstCaseSteps(strWild, len(strTame)))
+
+	if bMatch, bExceeded := FastWildCompareAsciiLimit(strWild, strTame,
+		iLimit); bMatch || bExceeded {
+		t.Errorf("FastWildCompareAsciiLimit(%q) with a limit of %d = "+
+			"%t, %t; want false, false", strWild, iLimit, bMatch, bExceeded)
+	}
+
+	// Many '*'s, each followed by a short run, only ever fall back to the
+	// last of them, so the comparison stays within a linear limit.  The
+	// limit is crossed only when the steps exceed it, including the steps
+	// for trailing '*'s.
+	strWild = strings.Repeat("*a", 32) + "*b"
+
+	for _, testCase := range []struct {
+		strWild   string
+		strTame   string
+		iLimit    int
+		bMatch    bool
+		bExceeded bool
+	}{
+		{strWild, strTame, 2 * len(strTame), false, false},
+		{strWild, strTame + "b", 2 * len(strTame), true, false},
+		{"abc", "abc", 3, true, false},
+		{"abc", "abc", 2, false, true},
+		{"ab**", "ab", 4, true, false},
+		{"ab**", "ab", 3, false, true},
+		{"", "", 0, true, false},
+	} {
+		bMatch, bExceeded := FastWildCompareAsciiLimit(testCase.strWild,
+			testCase.strTame, testCase.iLimit)
+
+		if bMatch != testCase.bMatch || bExceeded != testCase.bExceeded {
+			t.Errorf("FastWildCompareAsciiLimit(%.20q, %d bytes, %d) = "+
+				"%t, %t; want %t, %t", testCase.strWild,
+				len(testCase.strTame), testCase.iLimit, bMatch, bExceeded,
+				testCase.bMatch, testCase.bExceeded)
+		}
+	}
+
+	// Given a limit of WorstCaseSteps(), each comparison finishes with the
+	// result of FastWildCompareAscii().
+	for _, strWild := range allStrings([]string{"*", "?", "a", "b"}, 5) {
+		for _, strTame := range allStrings([]string{"a", "b"}, 6) {
+			iLimit := int(WorstCaseSteps(strWild, len(strTame)))
+			bExpected := FastWildCompareAscii(strWild, strTame)
+
+			if bMatch, bExceeded := FastWildCompareAsciiLimit(strWild,
+				strTame, iLimit); bMatch != bExpected || bExceeded {
+				t.Errorf("FastWildCompareAsciiLimit(%q, %q, %d) = %t, %t; "+
+					"want %t, false", strWild, strTame, iLimit, bMatch,
+					bExceeded, bExpected)
+			}
+		}
+	}
+}
+
+// Tests for FastWildCompareAsciiCtx(), including cancellation of a
+// pathological comparison.
+func TestCtx(t *testing.T) {
+	strTame := strings.Repeat("a", 1000000)
+	strWild := "*" + strings.Repeat("a", 1000) + "b"
+
+	// A context with an immediate deadline stops a comparison that would
+	// otherwise take about a billion steps.
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now())
+	bMatch, err := FastWildCompareAsciiCtx(ctx, strWild, strTame)
+	cancel()
+
+	if bMatch || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("FastWildCompareAsciiCtx() past its deadline = %t, %v",
+			bMatch, err)
+	}
+
+	// So does a context cancelled while the comparison runs.
+	ctx, cancel = context.WithTimeout(context.Background(),
+		10*time.Millisecond)
+	bMatch, err = FastWildCompareAsciiCtx(ctx, strWild, strTame)
+	cancel()
+
+	if bMatch || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("FastWildCompareAsciiCtx() with a timeout = %t, %v",
+			bMatch, err)
+	}
+
+	// A short comparison finishes before the context is ever checked.
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+
+	if bMatch, err := FastWildCompareAsciiCtx(ctx, "a*c", "abc"); !bMatch ||
+		err != nil {
+		t.Errorf("FastWildCompareAsciiCtx(%q, %q) = %t, %v; want true, nil",
+			"a*c", "abc", bMatch, err)
+	}
+
+	// With a context that's never done, each comparison gets the result of
+	// FastWildCompareAscii().
+	for _, strWild := range allStrings([]string{"*", "?", "a", "b"}, 5) {
+		for _, strTame := range allStrings([]string{"a", "b"}, 6) {
+			bExpected := FastWildCompareAscii(strWild, strTame)
+
+			if bMatch, err := FastWildCompareAsciiCtx(context.Background(),
+				strWild, strTame); bMatch != bExpected || err != nil {
+				t.Errorf("FastWildCompareAsciiCtx(%q, %q) = %t, %v; "+
+					"want %t, nil", strWild, strTame, bMatch, err, bExpected)
+			}
+		}
+	}
+}
+
+// Tests for FastWildCompareAsciiBudget().
+func TestBudget(t *testing.T) {
+	strTame := strings.Repeat("a", 200) + "b"
+
+	// Results are exact, whether or not the budget is exceeded.
+	if bMatch, iRemaining := FastWildCompareAsciiBudget("a*b", "axxb",
+		100); !bMatch || iRemaining <= 0 || iRemaining >= 100 {
+		t.Errorf("FastWildCompareAsciiBudget(%q, %q, 100) = %t, %d",
+			"a*b", "axxb", bMatch, iRemaining)
+	}
+
+	if bMatch, iRemaining := FastWildCompareAsciiBudget("*a*ab", strTame,
+		10); !bMatch || iRemaining >= 0 {
+		t.Errorf("FastWildCompareAsciiBudget(%q, 10) = %t, %d", "*a*ab",
+			bMatch, iRemaining)
+	}
+
+	if bMatch, iRemaining := FastWildCompareAsciiBudget("", "",
+		5); !bMatch || iRemaining != 5 {
+		t.Errorf("FastWildCompareAsciiBudget(%q, %q, 5) = %t, %d", "", "",
+			bMatch, iRemaining)
+	}
+
+	// More complex patterns leave less of the budget, for the same content.
+	iBudget := 1000000
+	iPrevious := iBudget - len(strTame)
+
+	for _, strWild := range []string{strTame, "*b", "*aaaaaaaaab",
+		"*aaaaaaaaaaaaaaaaaaab", "*aaaaaaaaaaaaaaaaaaac"} {
+		_, iRemaining := FastWildCompareAsciiBudget(strWild, strTame,
+			iBudget)
+
+		if strWild == strTame && iRemaining != iPrevious ||
+			strWild != strTame && iRemaining >= iPrevious {
+			t.Errorf("FastWildCompareAsciiBudget(%q) left %d of the "+
+				"budget, after %d for a simpler pattern", strWild,
+				iRemaining, iPrevious)
+		}
+
+		iPrevious = iRemaining
+	}
+
+	// Every result matches FastWildCompareAscii().
+	for _, strWild := range allStrings([]string{"*", "?", "a", "b"}, 5) {
+		for _, strTame := range allStrings([]string{"a", "b"}, 6) {
+			bExpected := FastWildCompareAscii(strWild, strTame)
+
+			if bMatch, _ := FastWildCompareAsciiBudget(strWild, strTame,
+				0); bMatch != bExpected {
+				t.Errorf("FastWildCompareAsciiBudget(%q, %q, 0) = %t, "+
+					"want %t", strWild, strTame, bMatch, bExpected)
+			}
+		}
+	}
+}
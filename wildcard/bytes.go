@@ -0,0 +1,378 @@
+// Go routines for matching wildcards in byte slices.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import "bytes"
+
+// Compares two byte slices as FastWildCompareAscii() compares strings,
+// without first converting either of them to a string.  This suits content
+// read from files or network buffers, which would otherwise be copied.
+// Bracket classes are accepted, as they are by FastWildCompareAscii().  A
+// nil slice is treated as an empty one.
+func FastWildCompareBytes(slcWild, slcTame []byte) bool {
+	var iWild int = 0     // Index for pattern content
+	var iTame int = 0     // Index for tame content
+	var iWildSequence int // Index for prospective match after '*'
+	var iTameSequence int // Index for match in tame content
+
+	// A pattern without wildcards can only match identical content.
+	if !hasWildcards(slcWild) {
+		return bytes.Equal(slcWild, slcTame)
+	}
+
+	// Bracket classes take more than one pattern byte apiece, so they are
+	// compiled to tokens, each matching one byte, like a '?'.
+	if bytes.IndexByte(slcWild, '[') >= 0 {
+		slcTokens, _ := compileClassTokens(string(slcWild), false)
+		return matchClassTokens(slcTokens, slcTame)
+	}
+
+	// Find a first wildcard, if one exists, and the beginning of any
+	// prospectively matching sequence after it.  Until a '*' turns up,
+	// iWild and iTame advance together, so they're equal throughout this
+	// loop, but each is used only to index its own string.
+	for {
+		// Check for the end from the start.  Get out fast, if possible.
+		if len(slcTame) <= iTame {
+			if len(slcWild) > iWild {
+				for slcWild[iWild] == '*' {
+					iWild++
+
+					if len(slcWild) <= iWild {
+						return true // "ab" matches "ab*".
+					}
+				}
+
+				return false // "abcd" doesn't match "abc".
+			} else {
+				return true // "abc" matches "abc".
+			}
+		} else if len(slcWild) <= iWild {
+			return false // "abc" doesn't match "abcd".
+		} else if slcWild[iWild] == '*' {
+			// Got wild: set up for the second loop and skip on down there.
+			for {
+				iWild++
+
+				if len(slcWild) <= iWild {
+					return true // "abc*" matches "abcd".
+				}
+
+				if slcWild[iWild] != '*' {
+					break
+				}
+			}
+
+			// Search for the next prospective match.
+			if slcWild[iWild] != '?' {
+				for slcWild[iWild] != slcTame[iTame] {
+					iTame++
+
+					if len(slcTame) <= iTame {
+						return false // "a*bc" doesn't match "ab".
+					}
+				}
+			}
+
+			// Keep fallback positions for retry in case of incomplete match.
+			iWildSequence = iWild
+			iTameSequence = iTame
+			break
+		} else if slcWild[iWild] != slcTame[iTame] && slcWild[iWild] != '?' {
+			return false // "abc" doesn't match "abd".
+		}
+
+		iWild++ // Everything's a match, so far.
+		iTame++
+	}
+
+	// Find any further wildcards and any further matching sequences.
+	for {
+		if len(slcWild) > iWild && slcWild[iWild] == '*' {
+			// Got wild again.
+			for {
+				iWild++
+
+				if len(slcWild) <= iWild {
+					return true // "ab*c*" matches "abcd".
+				}
+
+				if slcWild[iWild] != '*' {
+					break
+				}
+			}
+
+			if len(slcTame) <= iTame {
+				return false // "*bcd*" doesn't match "abc".
+			}
+
+			// Search for the next prospective match.
+			if slcWild[iWild] != '?' {
+				for len(slcTame) > iTame &&
+					slcWild[iWild] != slcTame[iTame] {
+					iTame++
+
+					if len(slcTame) <= iTame {
+						return false // "a*b*c" doesn't match "ab".
+					}
+				}
+			}
+
+			// Keep the new fallback positions.
+			iWildSequence = iWild
+			iTameSequence = iTame
+		} else {
+			// The equivalent portion of the upper loop is really simple.
+			if len(slcTame) <= iTame {
+				if len(slcWild) <= iWild {
+					return true // "*b*c" matches "abc".
+				}
+
+				return false // "*bcd" doesn't match "abc".
+			}
+
+			if len(slcWild) <= iWild ||
+				slcWild[iWild] != '?' &&
+					slcWild[iWild] != slcTame[iTame] {
+				// A fine time for questions.
+				for len(slcWild) > iWildSequence &&
+					slcWild[iWildSequence] == '?' {
+					iWildSequence++
+					iTameSequence++
+				}
+
+				iWild = iWildSequence
+
+				// Fall back, but never so far again.
+				for {
+					iTameSequence++
+
+					if len(slcTame) <= iTameSequence {
+						if len(slcWild) <= iWild {
+							return true // "*a*b" matches "ab".
+						} else {
+							return false // "*a*b" doesn't match "ac".
+						}
+					}
+
+					if len(slcWild) > iWild &&
+						slcWild[iWild] == slcTame[iTameSequence] {
+						break
+					}
+				}
+
+				iTame = iTameSequence
+			}
+		}
+
+		// Another check for the end, at the end.
+		if len(slcTame) <= iTame {
+			if len(slcWild) <= iWild {
+				return true // "*bc" matches "abc".
+			}
+
+			return false // "*bc" doesn't match "abcd".
+		}
+
+		iWild++ // Everything's still a match.
+		iTame++
+	}
+}
+
+// Compares two byte slices as FastWildCompareAscii() compares strings,
+// except that literal bytes are compared via fnEqual, so that callers can
+// define what it means for two bytes to match: ignoring ASCII case, say,
+// or folding between encodings.  The first argument passed to fnEqual is
+// always from the pattern, and the second from the tame content.  A '*' or
+// '?' in the pattern is a wildcard regardless of fnEqual.  Since each
+// pattern byte is only ever compared against a tame byte, fnEqual needn't
+// be symmetric or transitive.  Bracket classes aren't supported, so a '['
+// is compared via fnEqual like any other literal byte.
+func FastWildCompareBytesFunc(slcWild, slcTame []byte,
+	fnEqual func(cWild, cTame byte) bool) bool {
+	var iWild int = 0     // Index for pattern content
+	var iTame int = 0     // Index for tame content
+	var iWildSequence int // Index for prospective match after '*'
+	var iTameSequence int // Index for match in tame content
+
+	// A pattern without wildcards can only match content of the same length.
+	if !hasWildcards(slcWild) {
+		if len(slcWild) != len(slcTame) {
+			return false
+		}
+
+		for i, c := range slcWild {
+			if !fnEqual(c, slcTame[i]) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	// Find a first wildcard, if one exists, and the beginning of any
+	// prospectively matching sequence after it.  Until a '*' turns up,
+	// iWild and iTame advance together, so they're equal throughout this
+	// loop, but each is used only to index its own string.
+	for {
+		// Check for the end from the start.  Get out fast, if possible.
+		if len(slcTame) <= iTame {
+			if len(slcWild) > iWild {
+				for slcWild[iWild] == '*' {
+					iWild++
+
+					if len(slcWild) <= iWild {
+						return true // "ab" matches "ab*".
+					}
+				}
+
+				return false // "abcd" doesn't match "abc".
+			} else {
+				return true // "abc" matches "abc".
+			}
+		} else if len(slcWild) <= iWild {
+			return false // "abc" doesn't match "abcd".
+		} else if slcWild[iWild] == '*' {
+			// Got wild: set up for the second loop and skip on down there.
+			for {
+				iWild++
+
+				if len(slcWild) <= iWild {
+					return true // "abc*" matches "abcd".
+				}
+
+				if slcWild[iWild] != '*' {
+					break
+				}
+			}
+
+			// Search for the next prospective match.
+			if slcWild[iWild] != '?' {
+				for !fnEqual(slcWild[iWild], slcTame[iTame]) {
+					iTame++
+
+					if len(slcTame) <= iTame {
+						return false // "a*bc" doesn't match "ab".
+					}
+				}
+			}
+
+			// Keep fallback positions for retry in case of incomplete match.
+			iWildSequence = iWild
+			iTameSequence = iTame
+			break
+		} else if slcWild[iWild] != '?' &&
+			!fnEqual(slcWild[iWild], slcTame[iTame]) {
+			return false // "abc" doesn't match "abd".
+		}
+
+		iWild++ // Everything's a match, so far.
+		iTame++
+	}
+
+	// Find any further wildcards and any further matching sequences.
+	for {
+		if len(slcWild) > iWild && slcWild[iWild] == '*' {
+			// Got wild again.
+			for {
+				iWild++
+
+				if len(slcWild) <= iWild {
+					return true // "ab*c*" matches "abcd".
+				}
+
+				if slcWild[iWild] != '*' {
+					break
+				}
+			}
+
+			if len(slcTame) <= iTame {
+				return false // "*bcd*" doesn't match "abc".
+			}
+
+			// Search for the next prospective match.
+			if slcWild[iWild] != '?' {
+				for len(slcTame) > iTame &&
+					!fnEqual(slcWild[iWild], slcTame[iTame]) {
+					iTame++
+
+					if len(slcTame) <= iTame {
+						return false // "a*b*c" doesn't match "ab".
+					}
+				}
+			}
+
+			// Keep the new fallback positions.
+			iWildSequence = iWild
+			iTameSequence = iTame
+		} else {
+			// The equivalent portion of the upper loop is really simple.
+			if len(slcTame) <= iTame {
+				if len(slcWild) <= iWild {
+					return true // "*b*c" matches "abc".
+				}
+
+				return false // "*bcd" doesn't match "abc".
+			}
+
+			if len(slcWild) <= iWild ||
+				slcWild[iWild] != '?' &&
+					!fnEqual(slcWild[iWild], slcTame[iTame]) {
+				// A fine time for questions.
+				for len(slcWild) > iWildSequence &&
+					slcWild[iWildSequence] == '?' {
+					iWildSequence++
+					iTameSequence++
+				}
+
+				iWild = iWildSequence
+
+				// Fall back, but never so far again.
+				for {
+					iTameSequence++
+
+					if len(slcTame) <= iTameSequence {
+						if len(slcWild) <= iWild {
+							return true // "*a*b" matches "ab".
+						} else {
+							return false // "*a*b" doesn't match "ac".
+						}
+					}
+
+					if len(slcWild) > iWild &&
+						fnEqual(slcWild[iWild], slcTame[iTameSequence]) {
+						break
+					}
+				}
+
+				iTame = iTameSequence
+			}
+		}
+
+		// Another check for the end, at the end.
+		if len(slcTame) <= iTame {
+			if len(slcWild) <= iWild {
+				return true // "*bc" matches "abc".
+			}
+
+			return false // "*bc" doesn't match "abcd".
+		}
+
+		iWild++ // Everything's still a match.
+		iTame++
+	}
+}
@@ -0,0 +1,143 @@
+// Go tests for the byte slice matching routines.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import "testing"
+
+// Tests for FastWildCompareBytes(), which must agree byte for byte with
+// FastWildCompareAscii().
+func TestFastWildCompareBytes(t *testing.T) {
+	// Every short pattern and tame string, including some with bracket
+	// classes.
+	for _, strWild := range allStrings([]string{"*", "?", "a", "b",
+		"[!a]", "[-]"}, 4) {
+		for _, strTame := range allStrings([]string{"a", "b", "-"}, 5) {
+			bExpected := FastWildCompareAscii(strWild, strTame)
+
+			if FastWildCompareBytes([]byte(strWild),
+				[]byte(strTame)) != bExpected {
+				t.Errorf("FastWildCompareBytes(%q, %q) = %t, want %t",
+					strWild, strTame, !bExpected, bExpected)
+			}
+		}
+	}
+
+	// Nil and empty slices are treated alike.
+	for _, testCase := range []struct {
+		slcWild   []byte
+		slcTame   []byte
+		bExpected bool
+	}{
+		{nil, nil, true},
+		{nil, []byte{}, true},
+		{[]byte{}, nil, true},
+		{[]byte("*"), nil, true},
+		{[]byte("?"), nil, false},
+		{nil, []byte("a"), false},
+		{[]byte("[a]"), nil, false},
+	} {
+		if FastWildCompareBytes(testCase.slcWild,
+			testCase.slcTame) != testCase.bExpected {
+			t.Errorf("FastWildCompareBytes(%q, %q) = %t, want %t",
+				testCase.slcWild, testCase.slcTame, !testCase.bExpected,
+				testCase.bExpected)
+		}
+	}
+
+	// The tame content may be a subslice of a larger buffer.
+	slcBuffer := []byte("GET /index.html HTTP/1.1")
+
+	if !FastWildCompareBytes([]byte("/*.html"), slcBuffer[4:15]) {
+		t.Errorf("\"/*.html\" doesn't match %q", slcBuffer[4:15])
+	}
+
+	if FastWildCompareBytes([]byte("/*.htm"), slcBuffer[4:15]) {
+		t.Errorf("\"/*.htm\" matches %q", slcBuffer[4:15])
+	}
+}
+
+// Tests for FastWildCompareBytesFunc().
+func TestFastWildCompareBytesFunc(t *testing.T) {
+	fnFoldEqual := func(cWild, cTame byte) bool {
+		return lowerAscii(cWild) == lowerAscii(cTame)
+	}
+	fnExactEqual := func(cWild, cTame byte) bool {
+		return cWild == cTame
+	}
+
+	// Treating '-' and '_' as the same, for identifiers spelled either way.
+	fnDashEqual := func(cWild, cTame byte) bool {
+		return cWild == cTame || cWild == '-' && cTame == '_' ||
+			cWild == '_' && cTame == '-'
+	}
+
+	for _, testCase := range []struct {
+		strWild   string
+		strTame   string
+		fnEqual   func(cWild, cTame byte) bool
+		bExpected bool
+	}{
+		{"*.TXT", "notes.txt", fnFoldEqual, true},
+		{"Read?e*", "README.md", fnFoldEqual, true},
+		{"MiSsIsSiPpI", "mississippi", fnFoldEqual, true},
+		{"*.TXT", "notes.tx", fnFoldEqual, false},
+		{"[@]", "{`}", fnFoldEqual, false},
+		{"a*B*c", "AbbbbbC", fnFoldEqual, true},
+		{"a*B*c", "AbbbbbD", fnFoldEqual, false},
+
+		// A pattern's '*' and '?' are wildcards even if fnEqual would match
+		// them to nothing at all.
+		{"a*?", "abc", func(cWild, cTame byte) bool {
+			return cWild == 'a' && cTame == 'a'
+		}, true},
+
+		{"max-*-size", "max_body_size", fnDashEqual, true},
+		{"max-*-size", "max.body.size", fnDashEqual, false},
+	} {
+		if FastWildCompareBytesFunc([]byte(testCase.strWild),
+			[]byte(testCase.strTame),
+			testCase.fnEqual) != testCase.bExpected {
+			t.Errorf("FastWildCompareBytesFunc(%q, %q) = %t, want %t",
+				testCase.strWild, testCase.strTame, !testCase.bExpected,
+				testCase.bExpected)
+		}
+	}
+
+	// Results match ASCII-folded comparisons via FastWildCompareAscii(), or
+	// with exact equality, FastWildCompareAscii() itself.
+	for _, strWild := range allStrings([]string{"*", "?", "a", "B"}, 4) {
+		for _, strTame := range allStrings([]string{"a", "A", "b"}, 5) {
+			bExpected := FastWildCompareAscii(lowerAsciiString(strWild),
+				lowerAsciiString(strTame))
+
+			if FastWildCompareBytesFunc([]byte(strWild), []byte(strTame),
+				fnFoldEqual) != bExpected {
+				t.Errorf("FastWildCompareBytesFunc(%q, %q) with folding "+
+					"= %t, want %t", strWild, strTame, !bExpected,
+					bExpected)
+			}
+
+			bExpected = FastWildCompareAscii(strWild, strTame)
+
+			if FastWildCompareBytesFunc([]byte(strWild), []byte(strTame),
+				fnExactEqual) != bExpected {
+				t.Errorf("FastWildCompareBytesFunc(%q, %q) = %t, want %t",
+					strWild, strTame, !bExpected, bExpected)
+			}
+		}
+	}
+}
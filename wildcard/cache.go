@@ -0,0 +1,104 @@
+// Go routines for matching wildcards via a cache of compiled patterns.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import (
+	"container/list"
+	"sync"
+)
+
+// The number of compiled patterns that MatchCached() keeps, until changed
+// via SetCacheSize().
+const DefaultCacheSize = 256
+
+// A pattern compiled via BestMatcher(), as kept in the cache.
+type cachedMatcher struct {
+	strWild string
+	fnMatch func(string) bool
+}
+
+// The cache of compiled patterns for MatchCached(), with the most recently
+// used pattern at the front of the list.  The mutex guards all of these.
+var (
+	mutexCache sync.Mutex
+	listCache  = list.New()
+	mapCache   = make(map[string]*list.Element)
+	iCacheSize = DefaultCacheSize
+)
+
+// Compares an ASCII pattern against a tame string, with the same results
+// as FastWildCompareAscii(), via a matcher compiled by BestMatcher() the
+// first time the pattern is seen and kept in a package-level cache.  Code
+// that matches against the same few patterns from many places can so
+// amortize the compilation without having to hold the compiled matchers
+// itself.  The cache keeps the DefaultCacheSize most recently used
+// patterns, or as many as set via SetCacheSize(), and is safe for
+// concurrent use by multiple goroutines.
+func MatchCached(strWild, strTame string) bool {
+	return cachedMatcherFor(strWild)(strTame)
+}
+
+// Sets how many compiled patterns MatchCached() keeps, discarding the least
+// recently used patterns beyond that number.  A size of 0 or less turns
+// the cache off, so that every call compiles its pattern anew.
+func SetCacheSize(n int) {
+	mutexCache.Lock()
+	defer mutexCache.Unlock()
+
+	iCacheSize = n
+	trimCache()
+}
+
+// Returns the compiled matcher for a pattern, from the cache if it's there,
+// or else compiling it and adding it to the cache.
+func cachedMatcherFor(strWild string) func(string) bool {
+	mutexCache.Lock()
+
+	if element, bFound := mapCache[strWild]; bFound {
+		listCache.MoveToFront(element)
+		fnMatch := element.Value.(*cachedMatcher).fnMatch
+		mutexCache.Unlock()
+		return fnMatch
+	}
+
+	mutexCache.Unlock()
+
+	// Compile without holding the lock, so that other goroutines aren't
+	// held up.  Two goroutines may compile the same pattern at once; the
+	// matchers they get are equivalent, and only one is kept.
+	fnMatch := BestMatcher(strWild)
+
+	mutexCache.Lock()
+	defer mutexCache.Unlock()
+
+	if _, bFound := mapCache[strWild]; !bFound && iCacheSize > 0 {
+		mapCache[strWild] = listCache.PushFront(&cachedMatcher{strWild, fnMatch})
+		trimCache()
+	}
+
+	return fnMatch
+}
+
+// Discards the least recently used patterns in excess of the cache size.
+// The caller must hold mutexCache.
+func trimCache() {
+	for listCache.Len() > max(iCacheSize, 0) {
+		element := listCache.Back()
+		delete(mapCache, element.Value.(*cachedMatcher).strWild)
+		listCache.Remove(element)
+	}
+}
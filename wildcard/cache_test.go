@@ -0,0 +1,114 @@
+// Go tests for the matcher cache.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import (
+	"sync"
+	"testing"
+)
+
+// The cache keeps just the most recently used patterns.
+func TestCacheEviction(t *testing.T) {
+	defer SetCacheSize(DefaultCacheSize)
+
+	SetCacheSize(2)
+
+	if listCache.Len() > 2 {
+		t.Errorf("cache holds %d patterns after resizing to 2",
+			listCache.Len())
+	}
+
+	MatchCached("a*", "ab")
+	MatchCached("b*", "ba")
+	MatchCached("a*", "ab")
+	MatchCached("c*", "cb")
+	_, bFoundA := mapCache["a*"]
+	_, bFoundB := mapCache["b*"]
+
+	if !bFoundA || bFoundB || listCache.Len() != 2 {
+		t.Errorf("cache holds %d patterns, with \"a*\" %v and \"b*\" %v; "+
+			"want 2, with just \"a*\"", listCache.Len(), bFoundA, bFoundB)
+	}
+
+	SetCacheSize(0)
+
+	if !MatchCached("a*", "ab") || listCache.Len() != 0 {
+		t.Errorf("cache holds %d patterns with caching off", listCache.Len())
+	}
+}
+
+// Results match the uncached path, whether patterns are compiled anew or
+// found in the cache, and whether the cache is large, small, or off.
+func TestMatchCached(t *testing.T) {
+	defer SetCacheSize(DefaultCacheSize)
+
+	slcPatterns := allStrings([]string{"*", "?", "a", "[!a]"}, 3)
+	slcTames := allStrings([]string{"a", "b"}, 4)
+
+	for _, iSize := range []int{DefaultCacheSize, 2, 0} {
+		SetCacheSize(iSize)
+
+		for iPass := 0; iPass < 2; iPass++ {
+			for _, strWild := range slcPatterns {
+				for _, strTame := range slcTames {
+					bExpected := FastWildCompareAscii(strWild, strTame)
+
+					if MatchCached(strWild, strTame) != bExpected {
+						t.Errorf("MatchCached(%q, %q) = %t, want %t, with "+
+							"a cache size of %d", strWild, strTame,
+							!bExpected, bExpected, iSize)
+					}
+				}
+			}
+		}
+	}
+}
+
+// Goroutines can share the cache, even as it's resized.  Run with
+// "go test -race" to check for data races.
+func TestMatchCachedConcurrent(t *testing.T) {
+	defer SetCacheSize(DefaultCacheSize)
+
+	slcPatterns := allStrings([]string{"*", "?", "a", "[!a]"}, 3)
+	slcTames := allStrings([]string{"a", "b"}, 3)
+	var wg sync.WaitGroup
+
+	for iGoroutine := 0; iGoroutine < 8; iGoroutine++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i, strWild := range slcPatterns {
+				if i%8 == iGoroutine {
+					SetCacheSize(i % 50)
+				}
+
+				for _, strTame := range slcTames {
+					bExpected := FastWildCompareAscii(strWild, strTame)
+
+					if MatchCached(strWild, strTame) != bExpected {
+						t.Errorf("MatchCached(%q, %q) = %t, want %t",
+							strWild, strTame, !bExpected, bExpected)
+					}
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+}
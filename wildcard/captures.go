@@ -0,0 +1,532 @@
+// Go routines for recovering what the wildcards in a pattern matched.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"strings"
+)
+
+// The tame content matched by one '*' or '?' wildcard, as byte offsets.
+type wildSpan struct {
+	iStart int
+	iEnd   int
+	bStar  bool
+}
+
+// Matches an ASCII pattern against tame content, as a string or a byte
+// slice, while recording the span of tame content matched by each
+// wildcard, in pattern order.
+//
+// This is the straightforward single-fallback form of the algorithm in
+// FastWildCompareAscii().  Each '*' consumes as little as possible, except
+// that the most recent '*' grows one character at a time whenever the
+// content after it fails to match.  Spans recorded after that '*' are
+// discarded and rebuilt on each such retry.  A '*' that matched nothing
+// yields an empty span.  Bracket classes aren't supported, so a '[' is
+// always a literal.
+func matchWildSpans[T string | []byte](strWild string, tame T) ([]wildSpan,
+	bool) {
+	var spans []wildSpan
+	iWild := 0
+	iTame := 0
+	iWildStar := -1 // Index of the '*' we can fall back to, if any
+	iTameStar := 0  // Where content consumed by that '*' ends
+	iSpanStar := 0  // Index of that '*' in spans
+
+	for iTame < len(tame) {
+		if iWild < len(strWild) && strWild[iWild] == '*' {
+			spans = append(spans, wildSpan{iTame, iTame, true})
+			iWildStar = iWild
+			iTameStar = iTame
+			iSpanStar = len(spans) - 1
+			iWild++
+		} else if iWild < len(strWild) &&
+			(strWild[iWild] == '?' || strWild[iWild] == tame[iTame]) {
+			if strWild[iWild] == '?' {
+				spans = append(spans, wildSpan{iTame, iTame + 1, false})
+			}
+
+			iWild++
+			iTame++
+		} else if iWildStar >= 0 {
+			// Let the last '*' consume one more character, and retry.
+			iTameStar++
+			spans = spans[:iSpanStar+1]
+			spans[iSpanStar].iEnd = iTameStar
+			iWild = iWildStar + 1
+			iTame = iTameStar
+		} else {
+			return nil, false
+		}
+	}
+
+	// Any further '*' wildcards match the empty remainder.
+	for iWild < len(strWild) && strWild[iWild] == '*' {
+		spans = append(spans, wildSpan{iTame, iTame, true})
+		iWild++
+	}
+
+	if iWild < len(strWild) {
+		return nil, false
+	}
+
+	return spans, true
+}
+
+// Matches an ASCII pattern against a tame byte slice, returning the content
+// captured by each '*', in pattern order, as for parsers that extract
+// fields from a buffer.  Each capture is a subslice of slcTame, so nothing
+// is copied.  Where the captures could be split more than one way, the
+// earlier '*'s capture as little as they can.  Returns nil, false if the
+// pattern doesn't match.  Bracket classes aren't supported, so a '[' is
+// always a literal.
+//
+// The captures alias slcTame: writing to a capture writes to slcTame, and
+// if slcTame is reused, as for reading the next record into the same
+// buffer, the captures change along with it.  Copy any capture that's
+// needed for longer, as via bytes.Clone().
+func CaptureBytes(strWild string, slcTame []byte) ([][]byte, bool) {
+	spans, bMatched := matchWildSpans(strWild, slcTame)
+
+	if !bMatched {
+		return nil, false
+	}
+
+	slcCaptures := make([][]byte, 0, len(spans))
+
+	for _, span := range spans {
+		if span.bStar {
+			// Cap each capture, so that appending to it can't overwrite
+			// the rest of slcTame.
+			slcCaptures = append(slcCaptures,
+				slcTame[span.iStart:span.iEnd:span.iEnd])
+		}
+	}
+
+	return slcCaptures, true
+}
+
+// Matches an ASCII pattern against a tame string, returning the byte
+// offsets where the content matched by the pattern starts and ends, not
+// counting whatever any leading or trailing '*'s consumed.  That's handy
+// for scanning logs, where a pattern such as "*ERROR*" finds a line and
+// the span shows just where on the line "ERROR" appeared.  For a pattern
+// that doesn't start with '*', the start is 0, and for one that doesn't
+// end with '*', the end is the length of the tame string.  Otherwise, as
+// with "*ccd" against "abcccd", the span begins where the characters after
+// the leading '*'s began matching, which is offset 3 here, and likewise
+// ends where the characters before the trailing '*'s stopped matching.
+// Where the leading '*'s could consume more than one way, they consume as
+// little as they can.
+//
+// A pattern made up of nothing but '*'s, or an empty pattern, matches an
+// empty span at offset 0.  The boolean result is that of
+// FastWildCompareAscii(), except that a '[' is always a literal here, and
+// the offsets are both 0 if the strings don't match.
+func FastWildCompareAsciiSpan(strWild, strTame string) (bool, int, int) {
+	spans, bMatched := matchWildSpans(strWild, strTame)
+
+	if !bMatched {
+		return false, 0, 0
+	}
+
+	strInner := strings.TrimLeft(strWild, "*")
+	iLeading := len(strWild) - len(strInner)
+	strInner = strings.TrimRight(strInner, "*")
+	iTrailing := len(strWild) - iLeading - len(strInner)
+
+	if strInner == "" {
+		return true, 0, 0
+	}
+
+	iStart := 0
+	iEnd := len(strTame)
+
+	// The spans of the leading '*'s come first, and those of the trailing
+	// '*'s last, with no '?' spans before or after them.
+	if iLeading > 0 {
+		iStart = spans[iLeading-1].iEnd
+	}
+
+	if iTrailing > 0 {
+		iEnd = spans[len(spans)-iTrailing].iStart
+	}
+
+	return true, iStart, iEnd
+}
+
+// Returns a hash summarizing the shape of a match: how many characters
+// each '*' wildcard consumed, in pattern order.  Two tame strings that
+// match a pattern the same way, with each '*' consuming the same number of
+// characters, hash equally regardless of their content.  The boolean
+// result is false, with a zero hash, if the strings don't match.
+//
+// The hash is the 64-bit FNV-1a hash of the consumed lengths, each encoded
+// as a little-endian uint64.  It doesn't cover the pattern itself, so
+// callers grouping matches across several patterns should key on the
+// pattern as well.  Bracket classes aren't supported, so a '[' is always a
+// literal.
+func MatchShapeHash(strWild, strTame string) (uint64, bool) {
+	spans, bMatched := matchWildSpans(strWild, strTame)
+
+	if !bMatched {
+		return 0, false
+	}
+
+	var bytesLen [8]byte
+	hash := fnv.New64a()
+
+	for _, span := range spans {
+		if span.bStar {
+			binary.LittleEndian.PutUint64(bytesLen[:],
+				uint64(span.iEnd-span.iStart))
+			hash.Write(bytesLen[:])
+		}
+	}
+
+	return hash.Sum64(), true
+}
+
+// Matches an ASCII pattern against a tame string, returning whether they
+// match along with the content captured by each '*', in pattern order, as
+// with regexp capture groups.  So "a*b*c" against "aXXbYYc" captures "XX"
+// and "YY".  A '*' that matched nothing captures an empty string, so each
+// '*' in "**" gets a capture, and a '?' captures nothing, so a pattern
+// without any '*' yields an empty slice.  Where the captures could be
+// split more than one way, the earlier '*'s capture as little as they can.
+// Returns false, nil if the strings don't match.  Bracket classes aren't
+// supported, so a '[' is always a literal.
+func FastWildCompareAsciiCaptures(strWild, strTame string) (bool, []string) {
+	spans, bMatched := matchWildSpans(strWild, strTame)
+
+	if !bMatched {
+		return false, nil
+	}
+
+	slcCaptures := make([]string, 0, len(spans))
+
+	for _, span := range spans {
+		if span.bStar {
+			slcCaptures = append(slcCaptures,
+				strTame[span.iStart:span.iEnd])
+		}
+	}
+
+	return true, slcCaptures
+}
+
+// Matches an ASCII pattern against a tame string, returning the content
+// captured by each '*', in pattern order, with leading and trailing
+// whitespace trimmed as by strings.TrimSpace().  That's handy for pulling
+// field values out of loosely formatted lines, as with "*=*" against
+// "  name = Mabel  ".  Trimming doesn't affect whether the strings match;
+// the boolean result is just that of FastWildCompareAscii(), except that a
+// '[' is always a literal here, since bracket classes aren't supported.
+// Where the captures could be split more than one way, the earlier '*'s
+// capture as little as they can.
+func CaptureTrimmed(strWild, strTame string) ([]string, bool) {
+	spans, bMatched := matchWildSpans(strWild, strTame)
+
+	if !bMatched {
+		return nil, false
+	}
+
+	slcCaptures := make([]string, 0, len(spans))
+
+	for _, span := range spans {
+		if span.bStar {
+			slcCaptures = append(slcCaptures,
+				strings.TrimSpace(strTame[span.iStart:span.iEnd]))
+		}
+	}
+
+	return slcCaptures, true
+}
+
+// Matches an ASCII pattern against a tame string and formats the content
+// captured by its wildcards, as for rewriting log lines.  The captures of
+// the '*' and '?' wildcards are passed, in pattern order, as arguments to
+// fmt.Sprintf() with strFormat.  So with the pattern "*-*" and the format
+// "%s/%s", "a-b" becomes "a/b".  Each verb in strFormat, other than "%%",
+// takes one capture.
+//
+// Returns "", false if the strings don't match.  A format whose number of
+// verbs differs from the number of wildcards in the pattern is an error,
+// which is also reported as "", false, rather than as a string full of
+// fmt's "%!s(MISSING)" or "%!(EXTRA ...)" markers.  Bracket classes aren't
+// supported, so a '[' is always a literal.
+func Reformat(strWild, strTame, strFormat string) (string, bool) {
+	spans, bMatched := matchWildSpans(strWild, strTame)
+
+	if !bMatched {
+		return "", false
+	}
+
+	iVerbs := 0
+
+	for i := 0; i < len(strFormat); i++ {
+		if strFormat[i] != '%' {
+			continue
+		} else if i+1 < len(strFormat) && strFormat[i+1] == '%' {
+			i++
+		} else {
+			iVerbs++
+		}
+	}
+
+	if iVerbs != len(spans) {
+		return "", false
+	}
+
+	slcArgs := make([]interface{}, len(spans))
+
+	for i, span := range spans {
+		slcArgs[i] = strTame[span.iStart:span.iEnd]
+	}
+
+	return fmt.Sprintf(strFormat, slcArgs...), true
+}
+
+// Reports whether the content captured by each '*' in an ASCII pattern is
+// uniquely determined, for every tame string the pattern matches.  That's
+// true exactly when the pattern has at most one '*'.  With one '*', the
+// literals and '?'s on either side of it fix the lengths of the content
+// before and after its capture.  With two or more, some matching tame
+// string can always be split between them in more than one way: "a*b*c"
+// splits "abbc" as "" and "b" or as "b" and "", and even "**" can split
+// "x" two ways.  Capture-based callers can use this to warn that the
+// spans they extract reflect only one of the possible splits.  As for the
+// capture routines, bracket classes aren't supported, so a '*' in "[*]" is
+// counted like any other.
+func IsUnambiguous(strWild string) bool {
+	return strings.Count(strWild, "*") <= 1
+}
+
+// Returns a plain-English account of how an ASCII pattern matched a tame
+// string, or of why it didn't, for support tools that show users what their
+// patterns do.  For a match, each literal run, '*', and '?' is described
+// in turn, with the tame content it matched and the position where that
+// content starts, as in:
+//
+//	literal "a" matched at position 0; '*' consumed "bc" at positions 1
+//	through 2; literal "d" matched at position 3; match
+//
+// Where the captures could be split more than one way, the earlier '*'s
+// are described as consuming as little as they can.  For a non-match, the
+// account names the decisive position: the first where the tame content
+// departs from every way of matching the pattern, as found via
+// MatchablePrefixLen(), or else the end of the tame content, where the
+// pattern still called for more.
+//
+// Building the account is far slower than matching, so it's meant for
+// explaining a result, not for getting one.  Bracket classes aren't
+// supported, so a '[' is always a literal.
+func Explain(strWild, strTame string) string {
+	spans, bMatched := matchWildSpans(strWild, strTame)
+
+	if !bMatched {
+		iPosition := MatchablePrefixLen(strWild, strTame)
+
+		if iPosition < len(strTame) {
+			return fmt.Sprintf("no match: %q at position %d can't be "+
+				"matched by the pattern after %q", strTame[iPosition],
+				iPosition, strTame[:iPosition])
+		}
+
+		return fmt.Sprintf("no match: the content ends at position %d, "+
+			"but the pattern calls for more", len(strTame))
+	}
+
+	var slcSteps []string
+	iSpan := 0
+	iTame := 0
+
+	for iWild := 0; iWild < len(strWild); {
+		switch strWild[iWild] {
+		case '*':
+			span := spans[iSpan]
+
+			if span.iStart == span.iEnd {
+				slcSteps = append(slcSteps, fmt.Sprintf(
+					"'*' consumed nothing at position %d", span.iStart))
+			} else {
+				slcSteps = append(slcSteps, fmt.Sprintf(
+					"'*' consumed %q at positions %d through %d",
+					strTame[span.iStart:span.iEnd], span.iStart,
+					span.iEnd-1))
+			}
+
+			iSpan++
+			iTame = span.iEnd
+			iWild++
+		case '?':
+			slcSteps = append(slcSteps, fmt.Sprintf(
+				"'?' matched %q at position %d", strTame[iTame], iTame))
+			iSpan++
+			iTame++
+			iWild++
+		default:
+			iEnd := iWild
+
+			for iEnd < len(strWild) && strWild[iEnd] != '*' &&
+				strWild[iEnd] != '?' {
+				iEnd++
+			}
+
+			slcSteps = append(slcSteps, fmt.Sprintf(
+				"literal %q matched at position %d", strWild[iWild:iEnd],
+				iTame))
+			iTame += iEnd - iWild
+			iWild = iEnd
+		}
+	}
+
+	if len(slcSteps) == 0 {
+		return "empty pattern matched empty content; match"
+	}
+
+	return strings.Join(slcSteps, "; ") + "; match"
+}
+
+// Compares two rune slices as FastWildCompareRuneSlices() does, and also
+// counts how many '?' wildcards matched a non-ASCII rune, one at or above
+// 128.  That's diagnostic data for validating that each '?' matched the
+// kind of content expected, as where a '?' meant for an ASCII digit turns
+// out to have consumed an emoji, which would take several bytes in UTF-8.
+// The count is for the match in which the earlier '*'s match as little as
+// they can, and is 0 if the slices don't match.  Bracket classes aren't
+// supported, so a '[' is always a literal.
+func FastWildCompareRuneSlicesClassify(rslcWild, rslcTame []rune) (bool,
+	int) {
+	iWild := 0
+	iTame := 0
+	iWildStar := -1 // Index of the '*' we can fall back to, if any
+	iTameStar := 0  // Where content consumed by that '*' ends
+	iCount := 0     // Non-ASCII runes matched by '?'
+	iCountStar := 0 // The count as of that '*'
+
+	for iTame < len(rslcTame) {
+		if iWild < len(rslcWild) && rslcWild[iWild] == '*' {
+			iWildStar = iWild
+			iTameStar = iTame
+			iCountStar = iCount
+			iWild++
+		} else if iWild < len(rslcWild) && (rslcWild[iWild] == '?' ||
+			rslcWild[iWild] == rslcTame[iTame]) {
+			if rslcWild[iWild] == '?' && rslcTame[iTame] >= 128 {
+				iCount++
+			}
+
+			iWild++
+			iTame++
+		} else if iWildStar >= 0 {
+			// Let the last '*' consume one more rune, and retry.
+			iTameStar++
+			iCount = iCountStar
+			iWild = iWildStar + 1
+			iTame = iTameStar
+		} else {
+			return false, 0
+		}
+	}
+
+	for iWild < len(rslcWild) && rslcWild[iWild] == '*' {
+		iWild++
+	}
+
+	if iWild < len(rslcWild) {
+		return false, 0
+	}
+
+	return true, iCount
+}
+
+// The most capture sets that AllCaptures() returns for one match.
+const MaxCaptureSets = 1000
+
+// The tame content captured by one '*', as byte offsets, so that the
+// content is strTame[Start:End].
+type Span struct {
+	Start int
+	End   int
+}
+
+// Returns every distinct way that an ASCII pattern can match a tame string,
+// as the spans of tame content captured by each '*', in pattern order.  This
+// serves tooling that must consider each interpretation of a match that
+// IsUnambiguous() reports to be ambiguous.  For example, "*a*" matches
+// "aaa" three ways, with the first '*' capturing "", "a", or "aa".  The
+// sets are ordered so that earlier '*'s capture as little as they can in
+// the earliest sets.
+//
+// A pattern such as "*****" can match a long string in an astronomical
+// number of ways, so at most MaxCaptureSets sets are returned.  The result
+// is nil if the strings don't match, and a match involving no '*' yields
+// one empty set.  The search is guided by a table recording, for each pair
+// of positions, whether the rest of the pattern can match the rest of the
+// tame string, so that the table's size, the product of the lengths of the
+// strings, bounds the work spent on any set.  Bracket classes aren't
+// supported, so a '[' is always a literal.
+func AllCaptures(strWild, strTame string) [][]Span {
+	iWidth := len(strTame) + 1
+	slcCanMatch := make([]bool, (len(strWild)+1)*iWidth)
+	slcCanMatch[len(strWild)*iWidth+len(strTame)] = true
+
+	for iWild := len(strWild) - 1; iWild >= 0; iWild-- {
+		for iTame := len(strTame); iTame >= 0; iTame-- {
+			i := iWild*iWidth + iTame
+
+			if strWild[iWild] == '*' {
+				slcCanMatch[i] = slcCanMatch[i+iWidth] ||
+					iTame < len(strTame) && slcCanMatch[i+1]
+			} else if iTame < len(strTame) && (strWild[iWild] == '?' ||
+				strWild[iWild] == strTame[iTame]) {
+				slcCanMatch[i] = slcCanMatch[i+iWidth+1]
+			}
+		}
+	}
+
+	var slcSets [][]Span
+	var slcSpans []Span
+	var explore func(iWild, iTame int)
+
+	// Extends the current spans with each way that the rest of the pattern,
+	// from iWild, can match the rest of the tame string, from iTame.
+	explore = func(iWild, iTame int) {
+		if len(slcSets) >= MaxCaptureSets ||
+			!slcCanMatch[iWild*iWidth+iTame] {
+			return
+		} else if iWild == len(strWild) {
+			slcSets = append(slcSets, slices.Clone(slcSpans))
+		} else if strWild[iWild] != '*' {
+			explore(iWild+1, iTame+1)
+		} else {
+			for iEnd := iTame; iEnd <= len(strTame); iEnd++ {
+				slcSpans = append(slcSpans, Span{iTame, iEnd})
+				explore(iWild+1, iEnd)
+				slcSpans = slcSpans[:len(slcSpans)-1]
+			}
+		}
+	}
+
+	explore(0, 0)
+	return slcSets
+}
@@ -0,0 +1,685 @@
+// Go tests for the routines that report what each wildcard matched.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// Tests for FastWildCompareAsciiSpan(), which reports where the content
+// matched by a pattern, less any leading or trailing '*'s, starts and ends.
+func TestFastWildCompareAsciiSpan(t *testing.T) {
+	for _, spanCase := range []struct {
+		strWild   string
+		strTame   string
+		bExpected bool
+		iStart    int
+		iEnd      int
+	}{
+		// Leading '*'s are left out of the span.
+		{"*ccd", "abcccd", true, 3, 6},
+		{"**ccd", "abcccd", true, 3, 6},
+		{"*issip*ss*", "mississipissippi", true, 4, 12},
+		{"*ERROR*", "12:00 ERROR disk", true, 6, 11},
+		{"*a*", "banana", true, 1, 2},
+
+		// Patterns anchored at the start cover the tame string from 0.
+		{"?b*??", "abcd", true, 0, 4},
+		{"?b*??", "abcdefg", true, 0, 7},
+		{"?b*?", "xbyz", true, 0, 4},
+		{"ab*", "abcd", true, 0, 2},
+		{"ab**", "abcd", true, 0, 2},
+		{"abc", "abc", true, 0, 3},
+		{"a?c", "abc", true, 0, 3},
+
+		// Only '*'s, or nothing at all.
+		{"*", "abc", true, 0, 0},
+		{"***", "", true, 0, 0},
+		{"", "", true, 0, 0},
+
+		// Non-matches.
+		{"*ccd", "abccde", false, 0, 0},
+		{"?b*??", "abc", false, 0, 0},
+		{"", "a", false, 0, 0},
+	} {
+		bMatched, iStart, iEnd := FastWildCompareAsciiSpan(spanCase.strWild,
+			spanCase.strTame)
+
+		if bMatched != spanCase.bExpected || iStart != spanCase.iStart ||
+			iEnd != spanCase.iEnd {
+			t.Errorf("FastWildCompareAsciiSpan(%q, %q) = %t, %d, %d, "+
+				"want %t, %d, %d", spanCase.strWild, spanCase.strTame,
+				bMatched, iStart, iEnd, spanCase.bExpected, spanCase.iStart,
+				spanCase.iEnd)
+		}
+	}
+
+	// The result is that of FastWildCompareAscii(), and the pattern less
+	// its leading and trailing '*'s matches just the content in the span.
+	for _, strWild := range allStrings([]string{"*", "?", "a", "b"}, 5) {
+		strInner := strings.Trim(strWild, "*")
+
+		for _, strTame := range allStrings([]string{"a", "b"}, 6) {
+			bMatched, iStart, iEnd := FastWildCompareAsciiSpan(strWild,
+				strTame)
+
+			if bMatched != FastWildCompareAscii(strWild, strTame) {
+				t.Errorf("FastWildCompareAsciiSpan(%q, %q) = %t",
+					strWild, strTame, bMatched)
+			} else if bMatched && strInner != "" &&
+				!FastWildCompareAscii(strInner, strTame[iStart:iEnd]) {
+				t.Errorf("FastWildCompareAsciiSpan(%q, %q) spans %q",
+					strWild, strTame, strTame[iStart:iEnd])
+			}
+		}
+	}
+}
+
+// Tests for FastWildCompareAsciiCaptures(), which returns what each '*'
+// consumed.
+func TestFastWildCompareAsciiCaptures(t *testing.T) {
+	for _, captureCase := range []struct {
+		strWild     string
+		strTame     string
+		bExpected   bool
+		slcCaptures []string
+	}{
+		{"a*b*c", "aXXbYYc", true, []string{"XX", "YY"}},
+		{"*=*", "key=value", true, []string{"key", "value"}},
+		{"*.*", "a.b.c", true, []string{"a", "b.c"}},
+
+		// Stars that match nothing capture empty strings.
+		{"a*b*c", "abc", true, []string{"", ""}},
+		{"**", "xyz", true, []string{"", "xyz"}},
+		{"**", "", true, []string{"", ""}},
+		{"a**c", "abc", true, []string{"", "b"}},
+		{"*", "", true, []string{""}},
+
+		// A '?' captures nothing.
+		{"?*?", "abcd", true, []string{"bc"}},
+		{"a?c", "abc", true, []string{}},
+		{"abc", "abc", true, []string{}},
+		{"", "", true, []string{}},
+
+		// Non-matches.
+		{"a*b*c", "aXXbYYd", false, nil},
+		{"abc", "abd", false, nil},
+	} {
+		bMatched, slcCaptures := FastWildCompareAsciiCaptures(
+			captureCase.strWild, captureCase.strTame)
+
+		if bMatched != captureCase.bExpected ||
+			!reflect.DeepEqual(slcCaptures, captureCase.slcCaptures) {
+			t.Errorf("FastWildCompareAsciiCaptures(%q, %q) = %t, %q, "+
+				"want %t, %q", captureCase.strWild, captureCase.strTame,
+				bMatched, slcCaptures, captureCase.bExpected,
+				captureCase.slcCaptures)
+		}
+	}
+
+	// The result is that of FastWildCompareAscii(), with one capture per
+	// '*', and the captures put back in place of the '*'s restore the tame
+	// string when the pattern has no '?'.
+	for _, strWild := range allStrings([]string{"*", "?", "a", "b"}, 5) {
+		slcParts := strings.Split(strWild, "*")
+
+		for _, strTame := range allStrings([]string{"a", "b"}, 6) {
+			bMatched, slcCaptures := FastWildCompareAsciiCaptures(strWild,
+				strTame)
+
+			if bMatched != FastWildCompareAscii(strWild, strTame) {
+				t.Errorf("FastWildCompareAsciiCaptures(%q, %q) = %t",
+					strWild, strTame, bMatched)
+				continue
+			} else if !bMatched {
+				continue
+			} else if len(slcCaptures) != len(slcParts)-1 {
+				t.Errorf("FastWildCompareAsciiCaptures(%q, %q) captured %q",
+					strWild, strTame, slcCaptures)
+				continue
+			} else if strings.Contains(strWild, "?") {
+				continue
+			}
+
+			var sb strings.Builder
+
+			for i, strPart := range slcParts {
+				if i > 0 {
+					sb.WriteString(slcCaptures[i-1])
+				}
+
+				sb.WriteString(strPart)
+			}
+
+			if sb.String() != strTame {
+				t.Errorf("FastWildCompareAsciiCaptures(%q, %q) captured %q",
+					strWild, strTame, slcCaptures)
+			}
+		}
+	}
+}
+
+// Tests for MatchShapeHash(), which groups matches by how much each '*'
+// consumed.
+func TestMatchShapeHash(t *testing.T) {
+	for _, shapeCase := range []struct {
+		strWild  string
+		strTameA string
+		strTameB string
+		bSame    bool
+	}{
+		// Differently-lettered inputs matching the same way share a hash.
+		{"a*b*c", "axxbyc", "azzbwc", true},
+		{"*-*", "ab-cd", "xy-zw", true},
+
+		// The same pattern matched with different star lengths.
+		{"a*b*c", "axxbyc", "axbyyc", false},
+
+		// The hash is stable from one call to the next.
+		{"*issip*ss*", "mississipissippi", "mississipissippi", true},
+	} {
+		iHashA, bOkA := MatchShapeHash(shapeCase.strWild, shapeCase.strTameA)
+		iHashB, bOkB := MatchShapeHash(shapeCase.strWild, shapeCase.strTameB)
+
+		if !bOkA || !bOkB || (iHashA == iHashB) != shapeCase.bSame {
+			t.Errorf("MatchShapeHash(%q, %q/%q) = %#x/%t, %#x/%t",
+				shapeCase.strWild, shapeCase.strTameA, shapeCase.strTameB,
+				iHashA, bOkA, iHashB, bOkB)
+		}
+	}
+
+	// Patterns without stars all share the hash of an empty shape.
+	iHashA, bOkA := MatchShapeHash("a?c", "abc")
+	iHashB, bOkB := MatchShapeHash("xyz", "xyz")
+
+	if !bOkA || !bOkB || iHashA != iHashB {
+		t.Errorf("MatchShapeHash() = %#x/%t and %#x/%t for starless "+
+			"patterns", iHashA, bOkA, iHashB, bOkB)
+	}
+
+	// Non-matches report no hash.
+	if iHash, bOk := MatchShapeHash("a*b*c", "axxbyd"); bOk || iHash != 0 {
+		t.Errorf(`MatchShapeHash("a*b*c", "axxbyd") = %#x, %t`, iHash, bOk)
+	}
+}
+
+// Tests for MatchSingleCapture(), with valid and invalid pattern
+// structures.
+func TestMatchSingleCapture(t *testing.T) {
+	for _, captureCase := range []struct {
+		strWild    string
+		strTame    string
+		opt        Options
+		strCapture string
+		bMatched   bool
+	}{
+		// Valid patterns, anchored at one or both ends.
+		{"user=*;", "user=kirk;", Options{}, "kirk", true},
+		{"user=*;", "user=;", Options{}, "", true},
+		{"*.log", "error.log", Options{}, "error", true},
+		{"id:*", "id:42", Options{}, "42", true},
+		{"*", "anything", Options{}, "anything", true},
+		{"*", "", Options{}, "", true},
+		{"♥*★", "♥貔貅★", Options{}, "貔貅", true},
+
+		// The anchors are literal, and can't overlap.
+		{"user=*;", "user=kirk", Options{}, "", false},
+		{"a?*", "abc", Options{}, "", false},
+		{"a?*", "a?c", Options{}, "c", true},
+		{"ab*ba", "aba", Options{}, "", false},
+		{"ab*ba", "abba", Options{}, "", true},
+
+		// Invalid patterns, with no placeholder or with several.
+		{"user=kirk", "user=kirk", Options{}, "", false},
+		{"*=*", "user=kirk", Options{}, "", false},
+		{"**", "user=kirk", Options{}, "", false},
+
+		// A different placeholder, leaving '*' as a literal.
+		{"*%*", "*bold*", Options{SingleCaptureByte: '%'}, "bold", true},
+		{"*%*", "bold", Options{SingleCaptureByte: '%'}, "", false},
+		{"%d%%", "5d%", Options{SingleCaptureByte: '%'}, "", false},
+	} {
+		strCapture, bMatched := captureCase.opt.MatchSingleCapture(
+			captureCase.strWild, captureCase.strTame)
+
+		if strCapture != captureCase.strCapture ||
+			bMatched != captureCase.bMatched {
+			t.Errorf("MatchSingleCapture(%q, %q) = %q, %t; want %q, %t",
+				captureCase.strWild, captureCase.strTame, strCapture,
+				bMatched, captureCase.strCapture, captureCase.bMatched)
+		}
+
+		// The package-level routine uses the default placeholder.
+		if captureCase.opt == (Options{}) {
+			strCapture, bMatched = MatchSingleCapture(captureCase.strWild,
+				captureCase.strTame)
+
+			if strCapture != captureCase.strCapture ||
+				bMatched != captureCase.bMatched {
+				t.Errorf("MatchSingleCapture(%q, %q) = %q, %t; want %q, %t",
+					captureCase.strWild, captureCase.strTame, strCapture,
+					bMatched, captureCase.strCapture, captureCase.bMatched)
+			}
+		}
+	}
+}
+
+// Tests for IsUnambiguous().
+func TestIsUnambiguous(t *testing.T) {
+	for _, testCase := range []struct {
+		strWild   string
+		bExpected bool
+	}{
+		// At most one '*' pins every capture.
+		{"", true},
+		{"abc", true},
+		{"a?c", true},
+		{"a*c", true},
+		{"*", true},
+		{"??*??", true},
+
+		// Two or more never do.
+		{"*a*", false},
+		{"a*b*c", false},
+		{"**", false},
+		{"*?*", false},
+	} {
+		if IsUnambiguous(testCase.strWild) != testCase.bExpected {
+			t.Errorf("IsUnambiguous(%q) = %t, want %t", testCase.strWild,
+				!testCase.bExpected, testCase.bExpected)
+		}
+	}
+
+	// Witnesses: a tame string that matches with the first '*' capturing
+	// nothing also matches with it capturing more.
+	for _, witnessCase := range []struct {
+		strTame string
+		slcWild []string
+	}{
+		{"abbc", []string{"a*b*c", "ab*c", "abb*c"}},
+		{"aa", []string{"*a*", "a*", "aa*"}},
+	} {
+		for _, strWild := range witnessCase.slcWild {
+			if !FastWildCompareAscii(strWild, witnessCase.strTame) {
+				t.Errorf("FastWildCompareAscii(%q, %q) = false", strWild,
+					witnessCase.strTame)
+			}
+		}
+	}
+}
+
+// Tests for CaptureTrimmed().
+func TestCaptureTrimmed(t *testing.T) {
+	for _, captureCase := range []struct {
+		strWild     string
+		strTame     string
+		slcExpected []string
+		bMatched    bool
+	}{
+		{"*=*", "  name = Mabel  ", []string{"name", "Mabel"}, true},
+		{"*:*;*", "a :\t b\t; \n", []string{"a", "b", ""}, true},
+		{"key=*", "key=  two words ", []string{"two words"}, true},
+
+		// Literals around the stars aren't trimmed, so spacing still
+		// matters to whether the strings match.
+		{"key=*", " key=value", nil, false},
+		{"*=*", "no equals sign", nil, false},
+
+		// A '?' is matched but not captured.
+		{"?*?", "[ x ]", []string{"x"}, true},
+		{"abc", "abc", nil, true},
+	} {
+		slcCaptures, bMatched := CaptureTrimmed(captureCase.strWild,
+			captureCase.strTame)
+
+		if bMatched != captureCase.bMatched || (bMatched &&
+			!slices.Equal(slcCaptures, captureCase.slcExpected)) {
+			t.Errorf("CaptureTrimmed(%q, %q) = %q, %t; want %q, %t",
+				captureCase.strWild, captureCase.strTame, slcCaptures,
+				bMatched, captureCase.slcExpected, captureCase.bMatched)
+		}
+	}
+
+	for _, strWild := range allStrings([]string{"*", "?", "a", " "}, 4) {
+		for _, strTame := range allStrings([]string{"a", "b", " "}, 4) {
+			bExpected := FastWildCompareAscii(strWild, strTame)
+
+			if _, bMatched := CaptureTrimmed(strWild,
+				strTame); bMatched != bExpected {
+				t.Errorf("CaptureTrimmed(%q, %q) matched: %t, want %t",
+					strWild, strTame, bMatched, bExpected)
+			}
+		}
+	}
+}
+
+// Tests for Reformat().
+func TestReformat(t *testing.T) {
+	for _, reformatCase := range []struct {
+		strWild     string
+		strTame     string
+		strFormat   string
+		strExpected string
+		bOk         bool
+	}{
+		// Multiple captures, from both '*' and '?'.
+		{"*-*", "a-b", "%s/%s", "a/b", true},
+		{"*-*-*", "2025-10-15", "%s/%s/%s", "2025/10/15", true},
+		{"*: *", "ERROR: disk full", "[%s] %s", "[ERROR] disk full", true},
+		{"user=* id=??", "user=bob id=42", "%s:%s%s", "bob:42", true},
+		{"?*?", "abcd", "%s.%s.%s", "a.bc.d", true},
+		{"*/*", "a/b/c", "%s|%s", "a|b/c", true},
+		{"a*", "a", "<%s>", "<>", true},
+		{"*", "50", "%s%%", "50%", true},
+		{"abc", "abc", "fixed", "fixed", true},
+
+		// Mismatched capture and verb counts.
+		{"*-*", "a-b", "%s", "", false},
+		{"*-*", "a-b", "%s %s %s", "", false},
+		{"abc", "abc", "%s", "", false},
+		{"*", "50", "%%", "", false},
+
+		// Strings that don't match.
+		{"*-*", "ab", "%s/%s", "", false},
+		{"??", "abc", "%s%s", "", false},
+	} {
+		strResult, bOk := Reformat(reformatCase.strWild,
+			reformatCase.strTame, reformatCase.strFormat)
+
+		if strResult != reformatCase.strExpected ||
+			bOk != reformatCase.bOk {
+			t.Errorf("Reformat(%q, %q, %q) = %q, %t; want %q, %t",
+				reformatCase.strWild, reformatCase.strTame,
+				reformatCase.strFormat, strResult, bOk,
+				reformatCase.strExpected, reformatCase.bOk)
+		}
+	}
+}
+
+// Tests for AllCaptures().
+func TestAllCaptures(t *testing.T) {
+	// A span of tame content, for brevity.
+	span := func(iStart, iEnd int) Span {
+		return Span{Start: iStart, End: iEnd}
+	}
+
+	for _, captureCase := range []struct {
+		strWild     string
+		strTame     string
+		slcExpected [][]Span
+	}{
+		// Every way the first '*' can leave an 'a' for the pattern's
+		// literal.
+		{"*a*", "aaa", [][]Span{{span(0, 0), span(1, 3)},
+			{span(0, 1), span(2, 3)}, {span(0, 2), span(3, 3)}}},
+		{"**", "ab", [][]Span{{span(0, 0), span(0, 2)},
+			{span(0, 1), span(1, 2)}, {span(0, 2), span(2, 2)}}},
+		{"*=*", "a=b=c", [][]Span{{span(0, 1), span(2, 5)},
+			{span(0, 3), span(4, 5)}}},
+
+		// Unambiguous matches have just one interpretation.
+		{"a*b", "axxb", [][]Span{{span(1, 3)}}},
+		{"?b?", "abc", [][]Span{nil}},
+
+		// No match, no interpretations.
+		{"*a*", "bbb", nil},
+		{"a", "", nil},
+	} {
+		if slcSets := AllCaptures(captureCase.strWild,
+			captureCase.strTame); !reflect.DeepEqual(slcSets,
+			captureCase.slcExpected) {
+			t.Errorf("AllCaptures(%q, %q) = %v, want %v",
+				captureCase.strWild, captureCase.strTame, slcSets,
+				captureCase.slcExpected)
+		}
+	}
+
+	// The enumeration is bounded.
+	if slcSets := AllCaptures("*****",
+		strings.Repeat("x", 40)); len(slcSets) != MaxCaptureSets {
+		t.Errorf("AllCaptures() found %d interpretations, want %d",
+			len(slcSets), MaxCaptureSets)
+	}
+
+	// There's at least one interpretation exactly when the strings match,
+	// and in each, the parts of the pattern between its '*'s match the tame
+	// content between the spans.
+	for _, strWild := range allStrings([]string{"*", "?", "a", "b"}, 4) {
+		slcParts := strings.Split(strWild, "*")
+
+		for _, strTame := range allStrings([]string{"a", "b"}, 4) {
+			slcSets := AllCaptures(strWild, strTame)
+
+			if (len(slcSets) > 0) != FastWildCompareAscii(strWild, strTame) {
+				t.Errorf("AllCaptures(%q, %q) = %v", strWild, strTame,
+					slcSets)
+			}
+
+			for _, slcSpans := range slcSets {
+				iTame := 0
+
+				for i, span := range slcSpans {
+					if !FastWildCompareAscii(slcParts[i],
+						strTame[iTame:span.Start]) {
+						t.Errorf("AllCaptures(%q, %q) found %v", strWild,
+							strTame, slcSpans)
+					}
+
+					iTame = span.End
+				}
+
+				if !FastWildCompareAscii(slcParts[len(slcSpans)],
+					strTame[iTame:]) {
+					t.Errorf("AllCaptures(%q, %q) found %v", strWild,
+						strTame, slcSpans)
+				}
+			}
+		}
+	}
+}
+
+// Tests for FastWildCompareRuneSlicesClassify().
+func TestClassifyRunes(t *testing.T) {
+	for _, classifyCase := range []struct {
+		strWild   string
+		strTame   string
+		iCount    int
+		bExpected bool
+	}{
+		// A '?' matching ASCII content isn't counted, but one matching an emoji
+		// or other non-ASCII rune is.
+		{"id-???", "id-123", 0, true},
+		{"id-???", "id-1🐉3", 1, true},
+		{"id-???", "id-🐂🚀🐉", 3, true},
+		{"?", "é", 1, true},
+		{"?", "\x7f", 0, true},
+		{"?", "\u0080", 1, true},
+
+		// Runes matched by '*' or by literals aren't counted.
+		{"*", "🐂🚀🐉", 0, true},
+		{"🐂?🐉", "🐂x🐉", 0, true},
+		{"*?☂🐉", "🐂🚀♥☀☂🐉", 1, true},
+		{"*☂?", "☂☂x", 0, true},
+		{"*?a?", "éaéaxa!", 0, true},
+		{"*?a?", "xaxaéaé", 2, true},
+
+		// Counts from abandoned attempts at matching are discarded.
+		{"*??b", "ééxxb", 0, true},
+		{"a*??c", "aééééc", 2, true},
+
+		// Failed matches count nothing.
+		{"id-???", "id-12", 0, false},
+		{"?é", "éx", 0, false},
+	} {
+		bMatch, iCount := FastWildCompareRuneSlicesClassify(
+			[]rune(classifyCase.strWild), []rune(classifyCase.strTame))
+
+		if bMatch != classifyCase.bExpected ||
+			iCount != classifyCase.iCount {
+			t.Errorf("FastWildCompareRuneSlicesClassify(%q, %q) = %t, %d; "+
+				"want %t, %d", classifyCase.strWild, classifyCase.strTame,
+				bMatch, iCount, classifyCase.bExpected, classifyCase.iCount)
+		}
+	}
+
+	// The match results are the same as FastWildCompareRuneSlices().
+	for _, strWild := range allStrings([]string{"*", "?", "a", "é"}, 4) {
+		for _, strTame := range allStrings([]string{"a", "é", "🐉"}, 4) {
+			rslcWild := []rune(strWild)
+			rslcTame := []rune(strTame)
+			bExpected := FastWildCompareRuneSlices(rslcWild, rslcTame)
+
+			if bMatch, _ := FastWildCompareRuneSlicesClassify(rslcWild,
+				rslcTame); bMatch != bExpected {
+				t.Errorf("FastWildCompareRuneSlicesClassify(%q, %q) = %t, "+
+					"want %t", strWild, strTame, bMatch, bExpected)
+			}
+		}
+	}
+}
+
+// Tests for Explain().
+func TestExplain(t *testing.T) {
+	for _, testCase := range []struct {
+		strWild     string
+		strTame     string
+		strExpected string
+	}{
+		{"a*d", "abcd", "literal \"a\" matched at position 0; '*' " +
+			"consumed \"bc\" at positions 1 through 2; literal \"d\" " +
+			"matched at position 3; match"},
+		{"*x?", "xy", "'*' consumed nothing at position 0; literal \"x\" " +
+			"matched at position 0; '?' matched 'y' at position 1; match"},
+		{"", "", "empty pattern matched empty content; match"},
+
+		// Non-matches name the decisive position.
+		{"ab?d", "abcx", "no match: 'x' at position 3 can't be matched " +
+			"by the pattern after \"abc\""},
+		{"a*d", "abc", "no match: the content ends at position 3, but " +
+			"the pattern calls for more"},
+	} {
+		if strExplained := Explain(testCase.strWild,
+			testCase.strTame); strExplained != testCase.strExpected {
+			t.Errorf("Explain(%q, %q) = %q, want %q", testCase.strWild,
+				testCase.strTame, strExplained, testCase.strExpected)
+		}
+	}
+
+	for _, testCase := range []struct {
+		strWild     string
+		strTame     string
+		strPosition string
+	}{
+		{"report-????.csv", "report-2025.tsv", "'t' at position 12"},
+		{"x*", "abc", "position 0"},
+	} {
+		strExplained := Explain(testCase.strWild, testCase.strTame)
+
+		if !strings.Contains(strExplained, testCase.strPosition) {
+			t.Errorf("Explain(%q, %q) = %q, which doesn't name %q",
+				testCase.strWild, testCase.strTame, strExplained,
+				testCase.strPosition)
+		}
+	}
+
+	// Every explanation agrees with FastWildCompareAscii() on whether the
+	// strings match.
+	for _, strWild := range allStrings([]string{"*", "?", "a", "b"}, 4) {
+		for _, strTame := range allStrings([]string{"a", "b", "c"}, 5) {
+			bExpected := FastWildCompareAscii(strWild, strTame)
+			strExplained := Explain(strWild, strTame)
+
+			if strings.HasSuffix(strExplained, "; match") != bExpected {
+				t.Errorf("Explain(%q, %q) = %q, want a %t result",
+					strWild, strTame, strExplained, bExpected)
+			}
+		}
+	}
+}
+
+// Tests for CaptureBytes().
+func TestCaptureBytes(t *testing.T) {
+	slcTame := []byte("GET /index.html HTTP/1.1")
+	slcCaptures, bMatch := CaptureBytes("* * HTTP/*", slcTame)
+
+	if !bMatch || !reflect.DeepEqual(slcCaptures, [][]byte{[]byte("GET"),
+		[]byte("/index.html"), []byte("1.1")}) {
+		t.Fatalf("CaptureBytes(%q, %q) = %q, %t", "* * HTTP/*", slcTame,
+			slcCaptures, bMatch)
+	}
+
+	// The captures point into the original buffer, rather than copies.
+	if &slcCaptures[0][0] != &slcTame[0] || &slcCaptures[1][0] !=
+		&slcTame[4] || &slcCaptures[2][0] != &slcTame[21] {
+		t.Errorf("CaptureBytes() captures don't point into the buffer")
+	}
+
+	// So they change along with the buffer, when it's reused.
+	copy(slcTame, "PUT")
+
+	if string(slcCaptures[0]) != "PUT" {
+		t.Errorf("capture = %q after reusing the buffer, want %q",
+			slcCaptures[0], "PUT")
+	}
+
+	// But appending to a capture leaves the rest of the buffer alone.
+	_ = append(slcCaptures[0], 'X')
+
+	if string(slcTame) != "PUT /index.html HTTP/1.1" {
+		t.Errorf("appending to a capture changed the buffer to %q", slcTame)
+	}
+
+	// A '*' that matches nothing captures an empty slice, and '?' captures
+	// nothing.
+	slcCaptures, bMatch = CaptureBytes("a*?c*", []byte("abc"))
+
+	if !bMatch || len(slcCaptures) != 2 || len(slcCaptures[0]) != 0 ||
+		len(slcCaptures[1]) != 0 {
+		t.Errorf("CaptureBytes(%q, %q) = %q, %t", "a*?c*", "abc",
+			slcCaptures, bMatch)
+	}
+
+	if slcCaptures, bMatch = CaptureBytes("a*c",
+		[]byte("abd")); bMatch || slcCaptures != nil {
+		t.Errorf("CaptureBytes(%q, %q) = %q, %t; want nil, false", "a*c",
+			"abd", slcCaptures, bMatch)
+	}
+
+	// The captures agree with those of the string-based routines.
+	for _, strWild := range allStrings([]string{"*", "?", "a", " "}, 4) {
+		for _, strTame := range allStrings([]string{"a", "b", " "}, 5) {
+			slcCaptures, bMatch = CaptureBytes(strWild, []byte(strTame))
+			slcTrimmed, bTrimmedMatch := CaptureTrimmed(strWild, strTame)
+			var slcBytesTrimmed []string
+
+			for _, slcCapture := range slcCaptures {
+				slcBytesTrimmed = append(slcBytesTrimmed,
+					strings.TrimSpace(string(slcCapture)))
+			}
+
+			if bMatch != bTrimmedMatch ||
+				!slices.Equal(slcBytesTrimmed, slcTrimmed) {
+				t.Errorf("CaptureBytes(%q, %q) = %q, %t; CaptureTrimmed() "+
+					"= %q, %t", strWild, strTame, slcCaptures, bMatch,
+					slcTrimmed, bTrimmedMatch)
+			}
+		}
+	}
+}
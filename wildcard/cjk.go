@@ -0,0 +1,85 @@
+// Go routines for matching with width, kana, and case folding for CJK text.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import (
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+	"golang.org/x/text/width"
+)
+
+// Returns the width-folded form of a rune, as by width.Fold: the ASCII
+// counterpart of a full-width ASCII variant, or the full-width form of a
+// half-width katakana or symbol.  The full-width '＊' and '？' are returned
+// as they are, so that they're never taken for wildcards.
+func foldRuneWidth(r rune) rune {
+	if r == '＊' || r == '？' {
+		return r
+	}
+
+	if rFolded := width.LookupRune(r).Folded(); rFolded != 0 {
+		return rFolded
+	}
+
+	return r
+}
+
+// Returns the hiragana form of a katakana rune, or the lowercase form of an
+// ASCII letter, or any other rune as is.
+func foldRuneKanaCase(r rune) rune {
+	switch {
+	case r >= 'A' && r <= 'Z':
+		return r + 'a' - 'A'
+	case r >= 'ァ' && r <= 'ヶ', r == 'ヽ', r == 'ヾ':
+		return r - ('ァ' - 'ぁ')
+	}
+
+	return r
+}
+
+// Compares two UTF-8 strings, accepting '*' and '?' as usual, with the
+// foldings that make mixed Japanese and ASCII log content match as a
+// reader would expect.  These foldings are applied, in this order, to both
+// strings:
+//
+//   - Width folding, as by width.Fold from golang.org/x/text: each
+//     full-width ASCII variant, such as '１' or 'Ａ', and the ideographic
+//     space, folds to its ASCII counterpart, and each half-width katakana
+//     or symbol, such as 'ｶ', folds to its full-width form.  The
+//     full-width '＊' and '？' are left as they are, so they're literals
+//     that match only themselves.
+//   - Canonical composition, as by norm.NFC, so that a half-width kana
+//     followed by a half-width voiced or semi-voiced sound mark, such as
+//     "ｶﾞ", becomes the single kana "ガ".
+//   - Kana folding, where each katakana from 'ァ' through 'ヶ', along with
+//     the iteration marks 'ヽ' and 'ヾ', folds to the hiragana at the same
+//     position in the hiragana block, so "カタカナ" matches "かたかな".
+//   - ASCII case folding, so "ERROR" matches "error" and "ｅｒｒｏｒ".
+//
+// So "*エラー*" matches "ｴﾗｰ発生" and "えらー", and "ｺｰﾄﾞ ???" matches
+// "コード ４０４".  Other runes, including kanji and non-ASCII letters, are
+// compared as they are.  Bracket classes aren't supported, so a '[' is
+// always a literal.
+func FastWildCompareCJKFold(strWild, strTame string) bool {
+	fnFoldWidth := func(str string) []rune {
+		return []rune(norm.NFC.String(strings.Map(foldRuneWidth, str)))
+	}
+
+	return fastWildCompareRuneSlicesFolded(fnFoldWidth(strWild),
+		fnFoldWidth(strTame), foldRuneKanaCase)
+}
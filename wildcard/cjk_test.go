@@ -0,0 +1,72 @@
+// Go tests for the routines for matching with width, kana, and case folding
+// for CJK text.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import "testing"
+
+// Tests for FastWildCompareCJKFold().
+func TestCJKFold(t *testing.T) {
+	for _, testCase := range []struct {
+		strTame   string
+		strWild   string
+		bExpected bool
+	}{
+		// Katakana matches hiragana, and the other way around.
+		{"かたかな", "カタカナ", true},
+		{"カタカナ", "かたかな", true},
+		{"えらー発生", "*エラー*", true},
+		{"ゞ", "ヾ", true},
+		{"ひらがな", "カタカナ", false},
+
+		// Half-width katakana, with or without sound marks, match too.
+		{"ｴﾗｰ発生", "*エラー*", true},
+		{"ｺｰﾄﾞ", "コード", true},
+		{"ｺｰﾄﾞ", "こーど", true},
+		{"ﾊﾟｽ", "ぱす", true},
+		{"ﾊﾟｽ", "ばす", false},
+		{"ﾄﾞ", "?", true},
+
+		// Full-width digits, letters, and spaces match half-width ones.
+		{"コード ４０４", "ｺｰﾄﾞ ???", true},
+		{"４０４", "404", true},
+		{"404", "４０４", true},
+		{"４０５", "404", false},
+		{"ＥＲＲＯＲ\u3000ｄｉｓｋ", "error disk", true},
+		{"ＥＲＲＯＲ", "Err*", true},
+
+		// ASCII case folds, while other scripts are compared as is.
+		{"ERROR: ディスク", "error: でぃすく", true},
+		{"ΣΟΦΟΣ", "σοφος", false},
+		{"漢字", "漢?", true},
+		{"漢字", "漢", false},
+
+		// The full-width '＊' and '？' are literals.
+		{"a*", "a＊", false},
+		{"a＊", "a＊", true},
+		{"ab", "a？", false},
+		{"", "*", true},
+		{"", "?", false},
+	} {
+		if FastWildCompareCJKFold(testCase.strWild,
+			testCase.strTame) != testCase.bExpected {
+			t.Errorf("FastWildCompareCJKFold(%q, %q) = %t, want %t",
+				testCase.strWild, testCase.strTame, !testCase.bExpected,
+				testCase.bExpected)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+// Go routines for recognizing simple patterns and matching them quickly.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import "strings"
+
+// The shape of a pattern, as reported by Classify().
+type PatternKind int
+
+const (
+	PatternLiteral  PatternKind = iota // No wildcards, as in "abc"
+	PatternPrefix                      // One trailing '*', as in "abc*"
+	PatternSuffix                      // One leading '*', as in "*abc"
+	PatternContains                    // Just a leading and trailing '*'
+	PatternGeneral                     // Anything else
+)
+
+// Reports the shape of an ASCII pattern, so that callers can route the
+// simplest shapes to a dedicated fast function instead of the general
+// algorithm.  A PatternPrefix pattern, for example, can be matched by
+// passing everything but its trailing '*' to HasWildPrefix().  The
+// pattern "*" is classified as PatternPrefix, with an empty prefix.  Any
+// pattern containing a '[', which may open a bracket class, is classified
+// as PatternGeneral.
+func Classify(strWild string) PatternKind {
+	if strings.IndexByte(strWild, '[') >= 0 {
+		return PatternGeneral
+	}
+
+	iWildcard := strings.IndexAny(strWild, "*?")
+
+	if iWildcard < 0 {
+		return PatternLiteral
+	} else if iWildcard == len(strWild)-1 && strWild[iWildcard] == '*' {
+		return PatternPrefix
+	} else if iWildcard == 0 && strWild[0] == '*' {
+		iNext := strings.IndexAny(strWild[1:], "*?") + 1
+
+		if iNext == 0 {
+			return PatternSuffix
+		} else if iNext == len(strWild)-1 && strWild[iNext] == '*' &&
+			iNext > 1 {
+			return PatternContains
+		}
+	}
+
+	return PatternGeneral
+}
+
+// Reports whether an ASCII pattern, as a string or a byte slice, contains
+// any '*' or '?' wildcards, or any '[' that may open a bracket class.  The
+// comparison routines check this on entry, so that a pattern made only of
+// literals is compared directly against the tame content, in one pass,
+// without setting up for wildcard matching.
+func hasWildcards[T string | []byte](wild T) bool {
+	for i := 0; i < len(wild); i++ {
+		if wild[i] == '*' || wild[i] == '?' || wild[i] == '[' {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Reports whether a rune slice pattern contains any '*' or '?' wildcards,
+// or any '[', as hasWildcards() does for ASCII patterns.
+func hasWildcardRunes(rslcWild []rune) bool {
+	for _, r := range rslcWild {
+		if r == '*' || r == '?' || r == '[' {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Returns a function that compares tame strings against an ASCII pattern,
+// with the same results as FastWildCompareAscii(), choosing the fastest
+// implementation for the pattern's shape as reported by Classify().  The
+// literal parts of the simpler shapes are compared via the strings package,
+// so that patterns used many times don't pay for the general algorithm.
+func BestMatcher(strWild string) func(string) bool {
+	switch Classify(strWild) {
+	case PatternLiteral:
+		return func(strTame string) bool {
+			return strTame == strWild
+		}
+	case PatternPrefix:
+		strPrefix := strWild[:len(strWild)-1]
+
+		return func(strTame string) bool {
+			return HasWildPrefix(strPrefix, strTame)
+		}
+	case PatternSuffix:
+		strSuffix := strWild[1:]
+
+		return func(strTame string) bool {
+			return strings.HasSuffix(strTame, strSuffix)
+		}
+	case PatternContains:
+		strInner := strWild[1 : len(strWild)-1]
+
+		return func(strTame string) bool {
+			return strings.Contains(strTame, strInner)
+		}
+	}
+
+	// Bracket classes are compiled once, rather than on every comparison.
+	if strings.IndexByte(strWild, '[') >= 0 {
+		slcTokens, _ := compileClassTokens(strWild, false)
+
+		return func(strTame string) bool {
+			return matchClassTokens(slcTokens, strTame)
+		}
+	}
+
+	return func(strTame string) bool {
+		return FastWildCompareAscii(strWild, strTame)
+	}
+}
+
+// Compares a tame string against the literal part of a PatternPrefix
+// pattern, such as "abc" for "abc*".  Returns the same result as
+// FastWildCompareAscii(strPrefix + "*", strTame), without ever looking
+// beyond the first len(strPrefix) bytes of strTame.  Every byte of
+// strPrefix is a literal, including any '?' or '[', since Classify() never
+// reports a pattern with a wildcard or a bracket class before its '*' as
+// PatternPrefix.
+func HasWildPrefix(strPrefix, strTame string) bool {
+	return strings.HasPrefix(strTame, strPrefix)
+}
+
+// Returns the set of bytes that the first byte of a tame string could be,
+// for the tame string to possibly match an ASCII pattern.  The set is a
+// bitmap where byte c is present if bit c%64 of element c/64 is set.  A
+// pattern that starts with a literal allows only that byte, while one that
+// starts with '*' or '?' allows every byte.  One that starts with a bracket
+// class allows the class members, which for a negated class are all the
+// bytes it doesn't list, but a '[' without a closing ']' is a literal, as
+// for FastWildCompareAscii().  The empty pattern only matches the empty
+// string, so it allows no first byte at all.
+//
+// A router holding many patterns can index them by the bytes in these
+// sets, and skip the patterns that can't match a given tame string.
+func FirstByteSet(strWild string) [4]uint64 {
+	if len(strWild) == 0 {
+		return [4]uint64{}
+	} else if strWild[0] == '*' || strWild[0] == '?' {
+		return byteSetAll
+	} else if strWild[0] == '[' {
+		if set, iNext, _ := parseClass(strWild, 0, false); iNext >= 0 {
+			return set
+		}
+	}
+
+	return byteSetOf(strWild[0])
+}
+
+// Returns the length of the shortest tame string that can match an ASCII
+// pattern: the number of its literals, '?'s, and bracket classes, since a
+// '*' can match nothing.  A router can reject any shorter tame string
+// without comparing it.
+func MinMatchLen(strWild string) int {
+	slcTokens, _ := compileClassTokens(strWild, false)
+	iLen := 0
+
+	for _, token := range slcTokens {
+		iLen += token.iMin
+	}
+
+	return iLen
+}
+
+// Returns the length of the longest tame string that can match an ASCII
+// pattern, along with true, for a pattern without any '*'.  Such a pattern
+// only matches tame strings of that one length, which is also what
+// MinMatchLen() returns.  A pattern with a '*' can match tame strings of
+// any length beyond that, so the result is 0, false for it.
+func MaxMatchLen(strWild string) (int, bool) {
+	slcTokens, _ := compileClassTokens(strWild, false)
+	iLen := 0
+
+	for _, token := range slcTokens {
+		if token.iMax < 0 {
+			return 0, false
+		}
+
+		iLen += token.iMax
+	}
+
+	return iLen, true
+}
@@ -0,0 +1,266 @@
+// Go tests for the wildcard checks behind Classify().
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHasWildcards(t *testing.T) {
+	for _, testCase := range []struct {
+		strWild    string
+		bWildcards bool
+	}{
+		{"abc", false},
+		{"", false},
+		{"ab*", true},
+		{"?bc", true},
+		{"a[bc]", true},
+		{"αβγ", false},
+		{"αβ?", true},
+	} {
+		if hasWildcards(testCase.strWild) != testCase.bWildcards {
+			t.Errorf("hasWildcards(%q) = %v, want %v", testCase.strWild,
+				!testCase.bWildcards, testCase.bWildcards)
+		}
+
+		if hasWildcardRunes([]rune(testCase.strWild)) != testCase.bWildcards {
+			t.Errorf("hasWildcardRunes(%q) = %v, want %v", testCase.strWild,
+				!testCase.bWildcards, testCase.bWildcards)
+		}
+	}
+}
+
+// FirstByteSet() allows the first byte of a leading literal, or every
+// byte after a leading '*' or '?', or the members of a leading bracket
+// class, or every byte but those a negated class lists.
+func TestFirstByteSet(t *testing.T) {
+	for _, testCase := range []struct {
+		strWild    string
+		strBytes   string // The bytes allowed, or else disallowed
+		bDisallows bool
+	}{
+		{"abc", "a", false},
+		{"abc*", "a", false},
+		{"Hi", "H", false},
+		{"\xff", "\xff", false},
+		{"", "", false},
+		{"*c", "", true},
+		{"*", "", true},
+		{"?", "", true},
+		{"?bc", "", true},
+
+		// For UTF-8 content, that's the leading byte of the first code
+		// point.
+		{"☂🐉", "\xe2", false},
+		{"[ab]x", "ab", false},
+		{"[a-c]*", "abc", false},
+		{"[]a]", "]a", false},
+		{"[z-ab]", "b", false},
+		{"[!ab]", "ab", true},
+		{"[^]]?", "]", true},
+		{"[ab", "[", false},
+		{"[", "[", false},
+	} {
+		set := byteSet(FirstByteSet(testCase.strWild))
+
+		for c := 0; c < 256; c++ {
+			bListed := strings.IndexByte(testCase.strBytes, byte(c)) >= 0
+
+			if set.has(byte(c)) != (bListed != testCase.bDisallows) {
+				t.Errorf("FirstByteSet(%q): got %v for %q",
+					testCase.strWild, set.has(byte(c)), byte(c))
+			}
+		}
+	}
+
+	// Every tame string that matches its pattern has its first byte in the
+	// pattern's set.
+	for _, strWild := range allStrings([]string{"*", "?", "a", "b",
+		"[ab]", "[!a]"}, 3) {
+		set := byteSet(FirstByteSet(strWild))
+
+		for _, strTame := range allStrings([]string{"a", "b", "c"}, 3) {
+			if len(strTame) > 0 && FastWildCompareAscii(strWild, strTame) &&
+				!set.has(strTame[0]) {
+				t.Errorf("FirstByteSet(%q) lacks %q, which starts %q",
+					strWild, strTame[0], strTame)
+			}
+		}
+	}
+}
+
+// Tests for Classify() and HasWildPrefix(), which together provide a fast
+// lane for "prefix*" patterns.
+func TestHasWildPrefix(t *testing.T) {
+	// Shapes recognized by Classify().
+	for _, classifyCase := range []struct {
+		strWild string
+		kind    PatternKind
+	}{
+		{"abc", PatternLiteral},
+		{"", PatternLiteral},
+		{"abc*", PatternPrefix},
+		{"*", PatternPrefix},
+		{"abc**", PatternGeneral},
+		{"a?c*", PatternGeneral},
+		{"*abc", PatternSuffix},
+		{"a*c", PatternGeneral},
+	} {
+		if kind := Classify(classifyCase.strWild); kind != classifyCase.kind {
+			t.Errorf("Classify(%q) = %v, want %v", classifyCase.strWild,
+				kind, classifyCase.kind)
+		}
+	}
+
+	// The fast lane agrees with the general algorithm.
+	for _, prefixCase := range []struct {
+		strTame   string
+		strPrefix string
+	}{
+		{"Hi", "Hi"},
+		{"Hi", "H"},
+		{"Hi", ""},
+		{"H", "Hi"},
+		{"abc", "abd"},
+		{"mississippi", "mississ"},
+		{"mississippi", "mississippi!"},
+		{"abcabcdabcdeabcdefabcdefgabcdefghabcdefghiabcdefghijabcdefghijk",
+			"abcabcdabcdeabcdefabcdefgabcdefghabcdefghi"},
+		{"abcabcdabcdeabcdefabcdefgabcdefghabcdefghiabcdefghijabcdefghijk",
+			"abcabcdabcdeabcdefabcdefgabcdefghabcdefghj"},
+		{"🐂🚀♥🍀貔貅🦁★□√", "🐂🚀♥"},
+	} {
+		strWild := prefixCase.strPrefix + "*"
+
+		if Classify(strWild) != PatternPrefix {
+			t.Errorf("Classify(%q) = %v, want PatternPrefix", strWild,
+				Classify(strWild))
+		}
+
+		bExpected := FastWildCompareAscii(strWild, prefixCase.strTame)
+
+		if HasWildPrefix(prefixCase.strPrefix,
+			prefixCase.strTame) != bExpected {
+			t.Errorf("HasWildPrefix(%q, %q) = %t, want %t",
+				prefixCase.strPrefix, prefixCase.strTame, !bExpected,
+				bExpected)
+		}
+	}
+}
+
+// Tests for BestMatcher(), which should agree with FastWildCompareAscii()
+// whichever implementation it picks.
+func TestBestMatcher(t *testing.T) {
+	// Shapes recognized by Classify() for the suffix and contains lanes.
+	for _, classifyCase := range []struct {
+		strWild string
+		kind    PatternKind
+	}{
+		{"*abc", PatternSuffix},
+		{"*abc*", PatternContains},
+		{"*a*", PatternContains},
+		{"**", PatternGeneral},
+		{"*?", PatternGeneral},
+		{"*a?c", PatternGeneral},
+		{"*a*c*", PatternGeneral},
+		{"*abc**", PatternGeneral},
+	} {
+		if kind := Classify(classifyCase.strWild); kind != classifyCase.kind {
+			t.Errorf("Classify(%q) = %v, want %v", classifyCase.strWild,
+				kind, classifyCase.kind)
+		}
+	}
+
+	slcWild := []string{"", "abc", "abc*", "*", "*abc", "*abc*", "*a*",
+		"**", "*?", "a*c", "?bc", "*a*c*", "mississippi", "mi*ss*ppi"}
+	slcTame := []string{"", "a", "abc", "abcd", "xabc", "xabcx", "ac",
+		"mississippi", "missisippi", "abcabc"}
+
+	// Every short pattern and tame string gets the same result either way,
+	// too.
+	slcWild = append(slcWild, allStrings([]string{"*", "?", "a", "b",
+		"[ab]"}, 4)...)
+	slcTame = append(slcTame, allStrings([]string{"a", "b"}, 5)...)
+
+	for _, strWild := range slcWild {
+		fnMatch := BestMatcher(strWild)
+
+		for _, strTame := range slcTame {
+			bExpected := FastWildCompareAscii(strWild, strTame)
+
+			if fnMatch(strTame) != bExpected {
+				t.Errorf("BestMatcher(%q)(%q) = %t, want %t", strWild,
+					strTame, !bExpected, bExpected)
+			}
+		}
+	}
+}
+
+// Tests for MinMatchLen() and MaxMatchLen().
+func TestMatchLen(t *testing.T) {
+	for _, testCase := range []struct {
+		strWild  string
+		iMin     int
+		iMax     int
+		bBounded bool
+	}{
+		// Star-free patterns match just one length.
+		{"", 0, 0, true},
+		{"abc", 3, 3, true},
+		{"a?c", 3, 3, true},
+		{"???", 3, 3, true},
+		{"x[0-9]y", 3, 3, true},
+		{"[]]", 1, 1, true},
+		{"a?[bc]d", 4, 4, true},
+		{"a[b", 3, 3, true},
+
+		// A '*' leaves the length unbounded above.
+		{"*", 0, 0, false},
+		{"a*", 1, 0, false},
+		{"*a?c*", 3, 0, false},
+		{"[*]*", 1, 0, false},
+		{"a*b?*", 3, 0, false},
+		{"*[a-z]*[0-9]", 2, 0, false},
+	} {
+		iMin := MinMatchLen(testCase.strWild)
+		iMax, bBounded := MaxMatchLen(testCase.strWild)
+
+		if iMin != testCase.iMin || bBounded != testCase.bBounded ||
+			(bBounded && iMax != testCase.iMax) {
+			t.Errorf("MinMatchLen(%q), MaxMatchLen(%q) = %d, %d, %t; "+
+				"want %d, %d, %t", testCase.strWild, testCase.strWild, iMin,
+				iMax, bBounded, testCase.iMin, testCase.iMax,
+				testCase.bBounded)
+		}
+	}
+
+	// No tame string matches outside the bounds.
+	for _, strWild := range allStrings([]string{"*", "?", "a", "[ab]"}, 4) {
+		iMin := MinMatchLen(strWild)
+		iMax, bBounded := MaxMatchLen(strWild)
+
+		for _, strTame := range allStrings([]string{"a", "b"}, 6) {
+			if FastWildCompareAscii(strWild, strTame) &&
+				(len(strTame) < iMin || bBounded && len(strTame) > iMax) {
+				t.Errorf("%q matches %q, outside the bounds %d, %d, %t",
+					strWild, strTame, iMin, iMax, bBounded)
+			}
+		}
+	}
+}
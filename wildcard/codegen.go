@@ -0,0 +1,183 @@
+// Go routines for generating specialized matchers for fixed patterns.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import (
+	"fmt"
+	"go/format"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+// Emits Go source for a function named funcName, of type
+// func(strTame string) bool, that matches exactly the given ASCII pattern
+// with the same results as FastWildCompareAscii().  The output is a
+// gofmt-formatted function declaration, ready to be pasted or generated
+// into a file of any package.
+//
+// The generated function checks the minimum length, then the literals
+// before the first '*' and after the last '*' at fixed offsets.  Each run
+// of pattern content between two '*' wildcards is then sought left to
+// right, with its literals unrolled into a single comparison.  Since a '*'
+// can absorb whatever a leftmost match of the next run skips over, no
+// fallback is ever needed.
+//
+// Patterns with bracket classes aren't supported, and are reported as an
+// error.
+func GenerateMatcherCode(strWild, funcName string) (string, error) {
+	if !token.IsIdentifier(funcName) {
+		return "", fmt.Errorf("invalid function name %q", funcName)
+	} else if strings.IndexByte(strWild, '[') >= 0 {
+		return "", fmt.Errorf("bracket classes aren't supported: %q",
+			strWild)
+	}
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "// %s reports whether strTame matches the wildcard "+
+		"pattern %s.\n", funcName, strconv.Quote(strWild))
+	fmt.Fprintf(&sb, "func %s(strTame string) bool {\n", funcName)
+
+	slcSegments := strings.Split(strWild, "*")
+
+	if len(slcSegments) == 1 {
+		// No '*' at all: the tame string has a fixed length.
+		fmt.Fprintf(&sb, "if len(strTame) != %d {\nreturn false\n}\n",
+			len(strWild))
+
+		if strCond := segmentCondition(strWild, "", "=="); strCond != "" {
+			fmt.Fprintf(&sb, "return %s\n}\n", strCond)
+		} else {
+			sb.WriteString("return true\n}\n")
+		}
+
+		return formatMatcherCode(sb.String())
+	}
+
+	strPrefix := slcSegments[0]
+	strSuffix := slcSegments[len(slcSegments)-1]
+	iMinLen := len(strPrefix) + len(strSuffix)
+	var slcMiddles []string
+
+	for _, strSegment := range slcSegments[1 : len(slcSegments)-1] {
+		if strSegment != "" {
+			slcMiddles = append(slcMiddles, strSegment)
+			iMinLen += len(strSegment)
+		}
+	}
+
+	if iMinLen > 0 {
+		fmt.Fprintf(&sb, "if len(strTame) < %d {\nreturn false\n}\n",
+			iMinLen)
+	}
+
+	if strCond := segmentCondition(strPrefix, "", "!="); strCond != "" {
+		fmt.Fprintf(&sb, "if %s {\nreturn false\n}\n", strCond)
+	}
+
+	if strCond := segmentCondition(strSuffix, "iSuffix", "!="); strCond != "" {
+		fmt.Fprintf(&sb, "iSuffix := len(strTame) - %d\n", len(strSuffix))
+		fmt.Fprintf(&sb, "if %s {\nreturn false\n}\n", strCond)
+	}
+
+	if len(slcMiddles) > 0 {
+		fmt.Fprintf(&sb, "iTame := %d\n", len(strPrefix))
+
+		if len(strSuffix) > 0 {
+			fmt.Fprintf(&sb, "iEnd := len(strTame) - %d\n", len(strSuffix))
+		} else {
+			sb.WriteString("iEnd := len(strTame)\n")
+		}
+
+		for iMiddle, strSegment := range slcMiddles {
+			strCond := segmentCondition(strSegment, "iTame", "==")
+			fmt.Fprintf(&sb, "\n// Find %s.\n", strconv.Quote(strSegment))
+
+			if strCond == "" {
+				fmt.Fprintf(&sb, "if iEnd-iTame < %d {\nreturn false\n}\n",
+					len(strSegment))
+			} else {
+				fmt.Fprintf(&sb, "for ; ; iTame++ {\n"+
+					"if iEnd-iTame < %d {\nreturn false\n}\n"+
+					"if %s {\nbreak\n}\n}\n", len(strSegment), strCond)
+			}
+
+			if iMiddle < len(slcMiddles)-1 {
+				fmt.Fprintf(&sb, "iTame += %d\n", len(strSegment))
+			}
+		}
+	}
+
+	sb.WriteString("return true\n}\n")
+	return formatMatcherCode(sb.String())
+}
+
+// Builds a condition comparing each literal in a run of pattern content
+// against the tame string at offsets from strBase, joining the comparisons
+// with "&&" for the "==" operator or "||" for "!=".  Returns an empty
+// string if the run holds only '?' wildcards.
+func segmentCondition(strSegment, strBase, strOperator string) string {
+	strJoin := " && "
+
+	if strOperator == "!=" {
+		strJoin = " || "
+	}
+
+	var slcConds []string
+
+	for i := 0; i < len(strSegment); i++ {
+		if strSegment[i] == '?' {
+			continue
+		}
+
+		strIndex := strconv.Itoa(i)
+
+		if strBase != "" {
+			if i == 0 {
+				strIndex = strBase
+			} else {
+				strIndex = strBase + "+" + strIndex
+			}
+		}
+
+		slcConds = append(slcConds, fmt.Sprintf("strTame[%s] %s %s",
+			strIndex, strOperator, byteLiteral(strSegment[i])))
+	}
+
+	return strings.Join(slcConds, strJoin)
+}
+
+// Returns a Go byte literal for c.
+func byteLiteral(c byte) string {
+	if c < 0x80 {
+		return strconv.QuoteRuneToASCII(rune(c))
+	}
+
+	return fmt.Sprintf(`'\x%02x'`, c)
+}
+
+// Runs generated source through gofmt.
+func formatMatcherCode(strSource string) (string, error) {
+	bytesFormatted, err := format.Source([]byte(strSource))
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(bytesFormatted), nil
+}
@@ -0,0 +1,80 @@
+// Go tests for generating specialized matchers for fixed patterns.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// Source for the matchers checked by TestGenerateMatcherCode().
+//
+//go:embed generated_matchers_test.go
+var strGeneratedMatchers string
+
+// Tests for GenerateMatcherCode().  The matchers in
+// generated_matchers_test.go must be just what the generator produces, and
+// they must agree with FastWildCompareAscii() on every short tame string
+// made of characters that their patterns care about.
+func TestGenerateMatcherCode(t *testing.T) {
+	for i, generated := range []struct {
+		strWild  string
+		fnMatch  func(string) bool
+		strChars string
+		iMaxLen  int
+	}{
+		{"*ccd", generatedMatcher0, "cd-", 7},
+		{"mi*sip*", generatedMatcher1, "misp", 7},
+		{"?b*??", generatedMatcher2, "ab", 8},
+		{"*a?b", generatedMatcher3, "ab-", 7},
+		{"*aa?", generatedMatcher4, "ab", 8},
+		{"xy*z*xyz", generatedMatcher5, "xyz", 8},
+		{"*12*12*", generatedMatcher6, "12-", 8},
+		{"bL?h", generatedMatcher7, "bLh-", 6},
+		{"a*a*a*a*a*a*aa*aaa*a*a*b", generatedMatcher8, "ab", 15},
+		{"abc", generatedMatcher9, "abc-", 5},
+		{"", generatedMatcher10, "a-", 3},
+		{"*", generatedMatcher11, "a-", 3},
+	} {
+		strName := fmt.Sprintf("generatedMatcher%d", i)
+		strCode, err := GenerateMatcherCode(generated.strWild, strName)
+
+		if err != nil || !strings.Contains(strGeneratedMatchers, strCode) {
+			t.Errorf("%s for %q isn't what GenerateMatcherCode() "+
+				"produces, with an error of %v", strName, generated.strWild,
+				err)
+		}
+
+		for _, strTame := range allStrings(strings.Split(generated.strChars,
+			""), generated.iMaxLen) {
+			bExpected := FastWildCompareAscii(generated.strWild, strTame)
+
+			if generated.fnMatch(strTame) != bExpected {
+				t.Errorf("%s(%q) for %q = %t, want %t", strName, strTame,
+					generated.strWild, !bExpected, bExpected)
+			}
+		}
+	}
+
+	// Function names must be Go identifiers.
+	if _, err := GenerateMatcherCode("*", "not a name"); err == nil {
+		t.Errorf("GenerateMatcherCode() accepted %q as a name",
+			"not a name")
+	}
+}
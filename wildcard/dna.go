@@ -0,0 +1,197 @@
+// Go routines for matching wildcards against DNA sequences.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import "fmt"
+
+// The index of each DNA base, 'A', 'C', 'G', or 'T', in a DNAMatcher's
+// tables, or -1 for any other byte.
+var i8slcBase = func() [256]int8 {
+	var i8slc [256]int8
+
+	for i := range i8slc {
+		i8slc[i] = -1
+	}
+
+	i8slc['A'] = 0
+	i8slc['C'] = 1
+	i8slc['G'] = 2
+	i8slc['T'] = 3
+	return i8slc
+}()
+
+// A pattern compiled by CompileDNA() for matching DNA sequences.
+//
+// The matcher tracks, as one bit each, the positions in the pattern that
+// the sequence read so far could have brought it to, and advances them all
+// at once for each base via a table lookup and a few shifts and masks, with
+// no branching on the pattern's content.  Bit i of the state stands for
+// having matched the first i characters of the pattern, which has had each
+// run of wildcards put in canonical form by Simplify(), so that no two '*'s
+// are adjacent.
+type DNAMatcher struct {
+	slcAdvance [4][]uint64 // The states that each base can advance into
+	slcLoops   []uint64    // The states just past a '*', kept by any base
+	slcStars   []uint64    // The states just before a '*'
+	iFinal     int         // The state for having matched the whole pattern
+}
+
+// Compiles a pattern for matching DNA sequences made of the bases 'A', 'C',
+// 'G', and 'T'.  Besides those bases, the pattern may contain '*' and '?',
+// which match any run of bases and any one base, as usual.  Returns an
+// error naming the first byte of any other kind, including a lowercase
+// base.
+func CompileDNA(strWild string) (*DNAMatcher, error) {
+	for i := 0; i < len(strWild); i++ {
+		if i8slcBase[strWild[i]] < 0 && strWild[i] != '*' &&
+			strWild[i] != '?' {
+			return nil, fmt.Errorf("byte %q at position %d of DNA pattern "+
+				"isn't a base or a wildcard", strWild[i], i)
+		}
+	}
+
+	strWild = Simplify(strWild)
+	iWords := len(strWild)/64 + 1
+	matcher := &DNAMatcher{
+		slcLoops: make([]uint64, iWords),
+		slcStars: make([]uint64, iWords),
+		iFinal:   len(strWild),
+	}
+
+	for iBase := range matcher.slcAdvance {
+		matcher.slcAdvance[iBase] = make([]uint64, iWords)
+	}
+
+	for i := 0; i < len(strWild); i++ {
+		iNext := i + 1
+
+		switch strWild[i] {
+		case '*':
+			matcher.slcStars[i/64] |= 1 << (i % 64)
+			matcher.slcLoops[iNext/64] |= 1 << (iNext % 64)
+		case '?':
+			for iBase := range matcher.slcAdvance {
+				matcher.slcAdvance[iBase][iNext/64] |= 1 << (iNext % 64)
+			}
+		default:
+			matcher.slcAdvance[i8slcBase[strWild[i]]][iNext/64] |=
+				1 << (iNext % 64)
+		}
+	}
+
+	return matcher, nil
+}
+
+// Reports whether a DNA sequence matches the compiled pattern.  A sequence
+// containing any byte other than 'A', 'C', 'G', or 'T' doesn't match.
+func (matcher *DNAMatcher) Match(slcSequence []byte) bool {
+	if len(matcher.slcLoops) == 1 {
+		return matcher.matchOneWord(slcSequence)
+	}
+
+	slcState := make([]uint64, len(matcher.slcLoops))
+	slcState[0] = 1
+	matcher.passStars(slcState)
+	iFinalWord := matcher.iFinal / 64
+	uFinal := uint64(1) << (matcher.iFinal % 64)
+
+	for _, c := range slcSequence {
+		iBase := i8slcBase[c]
+
+		if iBase < 0 {
+			return false
+		}
+
+		slcAdvance := matcher.slcAdvance[iBase]
+		var uCarry uint64 // The bit shifted out of the previous word
+		var uLive uint64  // Any bits still set
+
+		for iWord, uState := range slcState {
+			uNext := (uState<<1|uCarry)&slcAdvance[iWord] |
+				uState&matcher.slcLoops[iWord]
+			uCarry = uState >> 63
+			slcState[iWord] = uNext
+			uLive |= uNext
+		}
+
+		if uLive == 0 {
+			return false // No way of matching the pattern remains.
+		}
+
+		matcher.passStars(slcState)
+
+		if slcState[iFinalWord]&matcher.slcLoops[iFinalWord]&uFinal != 0 {
+			return matchesOnlyBases(slcSequence) // A trailing '*' remains.
+		}
+	}
+
+	return slcState[iFinalWord]&uFinal != 0
+}
+
+// Implements Match() for patterns of fewer than 64 characters, whose states
+// fit in one word.
+func (matcher *DNAMatcher) matchOneWord(slcSequence []byte) bool {
+	u64slcAdvance := [4]uint64{matcher.slcAdvance[0][0],
+		matcher.slcAdvance[1][0], matcher.slcAdvance[2][0],
+		matcher.slcAdvance[3][0]}
+	uLoops := matcher.slcLoops[0]
+	uStars := matcher.slcStars[0]
+	uFinal := uint64(1) << matcher.iFinal
+	uState := uint64(1) | (1&uStars)<<1
+
+	for _, c := range slcSequence {
+		iBase := i8slcBase[c]
+
+		if iBase < 0 {
+			return false
+		}
+
+		uState = uState<<1&u64slcAdvance[iBase] | uState&uLoops
+		uState |= (uState & uStars) << 1
+
+		if uState&uFinal&uLoops != 0 {
+			return matchesOnlyBases(slcSequence) // A trailing '*' remains.
+		} else if uState == 0 {
+			return false // No way of matching the pattern remains.
+		}
+	}
+
+	return uState&uFinal != 0
+}
+
+// Reports whether a sequence contains only the bases 'A', 'C', 'G', and
+// 'T'.
+func matchesOnlyBases(slcSequence []byte) bool {
+	for _, c := range slcSequence {
+		if i8slcBase[c] < 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Adds to a state the positions reached by letting each '*' match nothing.
+func (matcher *DNAMatcher) passStars(slcState []uint64) {
+	var uCarry uint64
+
+	for iWord, uState := range slcState {
+		uPassed := uState & matcher.slcStars[iWord]
+		slcState[iWord] = uState | uPassed<<1 | uCarry
+		uCarry = uPassed >> 63
+	}
+}
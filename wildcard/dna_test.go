@@ -0,0 +1,96 @@
+// Go tests for the routines for matching wildcards against DNA sequences.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import (
+	"strings"
+	"testing"
+)
+
+// Tests for CompileDNA() and DNAMatcher.
+func TestDNA(t *testing.T) {
+	strLong := strings.Repeat("ACGT", 40)
+
+	for _, testCase := range []struct {
+		strWild     string
+		strSequence string
+		bExpected   bool
+	}{
+		{"*GATTACA*", "CCGATTACAGG", true},
+		{"*GATTACA*", "CCGATTACGG", false},
+		{"ATG*TAA", "ATGGCCAAGTAA", true},
+		{"ATG*TAA", "ATGGCCAAGTAG", false},
+		{"AC?T", "ACGT", true},
+		{"AC?T", "ACT", false},
+		{"*A?G*T?C*", "TTAAGCCTGCA", true},
+		{"", "", true},
+		{"", "A", false},
+		{"*", "", true},
+		{"A**?*C", "AGC", true},
+		{"A**?*C", "AC", false},
+
+		// Patterns past one 64-bit word of states.
+		{strLong, strLong, true},
+		{strLong, strLong + "A", false},
+		{strLong[:100] + "*" + strLong[:60] + "*", strLong + strLong, true},
+		{"*" + strings.Repeat("?", 70) + "T", strLong, true},
+		{"*" + strings.Repeat("?", 70) + "G", strLong, false},
+
+		// Bytes outside the alphabet are rejected in sequences.
+		{"*", "ACGN", false},
+		{"AC*", "ACGTn", false},
+		{"AC??", "ACG-", false},
+	} {
+		matcher, err := CompileDNA(testCase.strWild)
+
+		if err != nil {
+			t.Errorf("CompileDNA(%q) failed: %v", testCase.strWild, err)
+		} else if matcher.Match([]byte(
+			testCase.strSequence)) != testCase.bExpected {
+			t.Errorf("CompileDNA(%q).Match(%q) = %t, want %t",
+				testCase.strWild, testCase.strSequence, !testCase.bExpected,
+				testCase.bExpected)
+		}
+	}
+
+	// Bytes outside the alphabet are rejected in patterns.
+	for _, strWild := range []string{"ACGN", "acgt"} {
+		if _, err := CompileDNA(strWild); err == nil {
+			t.Errorf("CompileDNA(%q) succeeded", strWild)
+		}
+	}
+
+	// Results match FastWildCompareAscii() for every pattern and sequence
+	// made of a few bases.
+	for _, strWild := range allStrings([]string{"A", "C", "*", "?"}, 5) {
+		matcher, err := CompileDNA(strWild)
+
+		if err != nil {
+			t.Errorf("CompileDNA(%q) failed: %v", strWild, err)
+			continue
+		}
+
+		for _, strSequence := range allStrings([]string{"A", "C"}, 5) {
+			bExpected := FastWildCompareAscii(strWild, strSequence)
+
+			if matcher.Match([]byte(strSequence)) != bExpected {
+				t.Errorf("CompileDNA(%q).Match(%q) = %t, want %t",
+					strWild, strSequence, !bExpected, bExpected)
+			}
+		}
+	}
+}
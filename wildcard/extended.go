@@ -0,0 +1,541 @@
+// Go routines for matching wildcards with extended pattern syntaxes.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import "strings"
+
+// The word characters, [A-Za-z0-9_], as matched by '~' in
+// FastWildCompareWordStar().
+var byteSetWord = func() byteSet {
+	var set byteSet
+	set.addRange('A', 'Z')
+	set.addRange('a', 'z')
+	set.addRange('0', '9')
+	set.add('_')
+	return set
+}()
+
+// Compares two ASCII strings, accepting '*' and '?' as usual along with
+// '~' as a word wildcard.  A '~' matches any run of word characters,
+// including an empty run, where the word characters are the ASCII letters
+// and digits and '_', as in the regular expression [A-Za-z0-9_]*.  So the
+// run a '~' matches never extends past punctuation, whitespace, or any
+// non-ASCII content.
+func FastWildCompareWordStar(strWild, strTame string) bool {
+	return matchWildTokens(compileWildTokens(strWild,
+		func(c byte) (wildToken, bool) {
+			if c == '~' {
+				return runToken(byteSetWord), true
+			}
+
+			return wildToken{}, false
+		}), strTame)
+}
+
+// The whitespace characters, as matched by '_' in
+// FastWildCompareWhitespace().  These are the characters that \s matches in
+// Go's regexp package: space, tab, newline, form feed, and carriage return.
+var byteSetSpace = func() byteSet {
+	var set byteSet
+	set.add(' ')
+	set.add('\t')
+	set.add('\n')
+	set.add('\f')
+	set.add('\r')
+	return set
+}()
+
+// Compares two ASCII strings, accepting '*' and '?' as usual along with
+// '_' as a whitespace wildcard, for matching text that may have been
+// reformatted.  A '_' matches any run of one or more whitespace characters,
+// as in the regular expression \s+, where the whitespace characters are
+// space, tab, newline, form feed, and carriage return.  So "a_b" matches
+// "a b" and "a \t b", but not "ab".  A literal '_' can't be expressed,
+// though a '?' will match one.
+func FastWildCompareWhitespace(strWild, strTame string) bool {
+	return matchWildTokens(compileWildTokens(strWild,
+		func(c byte) (wildToken, bool) {
+			if c == '_' {
+				return wildToken{byteSetSpace, 1, -1}, true
+			}
+
+			return wildToken{}, false
+		}), strTame)
+}
+
+// Compares two ASCII strings, accepting '*' and '?' as usual, where a '*'
+// followed by a bound in braces matches a limited number of characters.
+// The bounds are written as in regular expressions:
+//
+//	*{m,n}   matches at least m and at most n characters
+//	*{m,}    matches at least m characters
+//	*{,n}    matches at most n characters
+//	*{n}     matches exactly n characters
+//
+// So "a*{1,3}b" matches "axb" through "axxxb", but not "ab" or "axxxxb".
+// A brace that doesn't start a well-formed bound, such as "*{3,1}" or
+// "*{x}", is just a literal following an unbounded '*'.
+func FastWildCompareBoundedStar(strWild, strTame string) bool {
+	slcTokens := make([]wildToken, 0, len(strWild))
+
+	for i := 0; i < len(strWild); i++ {
+		switch strWild[i] {
+		case '*':
+			token := runToken(byteSetAll)
+
+			if iMin, iMax, iNext, bOk := parseStarBounds(strWild,
+				i+1); bOk {
+				token.iMin = iMin
+				token.iMax = iMax
+				i = iNext - 1
+			}
+
+			slcTokens = append(slcTokens, token)
+		case '?':
+			slcTokens = append(slcTokens, singleToken(byteSetAll))
+		default:
+			slcTokens = append(slcTokens, singleToken(byteSetOf(strWild[i])))
+		}
+	}
+
+	return matchWildTokens(slcTokens, strTame)
+}
+
+// Compares two ASCII strings, accepting '*' and '?' as usual along with
+// '+' after a literal character, which matches one or more of that
+// character, as in regular expressions.  So "go+gle" matches "gogle" and
+// "google", but not "ggle".  A '+' that doesn't follow a literal, as in
+// "*+" or "a++", is itself a literal, and "\+" is a literal '+' that a
+// following '+' can repeat.  Other backslashes are literal.
+func FastWildComparePlus(strWild, strTame string) bool {
+	slcTokens := make([]wildToken, 0, len(strWild))
+	bRepeatable := false // Whether the last token is a plain literal
+
+	for i := 0; i < len(strWild); i++ {
+		switch {
+		case strWild[i] == '*':
+			slcTokens = append(slcTokens, runToken(byteSetAll))
+			bRepeatable = false
+		case strWild[i] == '?':
+			slcTokens = append(slcTokens, singleToken(byteSetAll))
+			bRepeatable = false
+		case strWild[i] == '+' && bRepeatable:
+			slcTokens[len(slcTokens)-1].iMax = -1
+			bRepeatable = false
+		case strWild[i] == '\\' && i+1 < len(strWild) && strWild[i+1] == '+':
+			i++
+			slcTokens = append(slcTokens, singleToken(byteSetOf('+')))
+			bRepeatable = true
+		default:
+			slcTokens = append(slcTokens, singleToken(byteSetOf(strWild[i])))
+			bRepeatable = true
+		}
+	}
+
+	return matchWildTokens(slcTokens, strTame)
+}
+
+// Compares two ASCII strings, accepting '*' and '?' as usual, where the
+// pattern may contain C-style escapes, as for patterns written in config
+// files.  A "\t", "\n", or "\r" matches a tab, newline, or carriage return,
+// so "*\t*" matches tab-separated content.  Any other escaped character
+// matches itself, so "\*", "\?", and "\\" match a literal '*', '?', and
+// backslash, and an unknown escape such as "\x" simply matches 'x', as in
+// FastWildCompareGlobRunesEscaped().  A backslash at the end of the pattern
+// has nothing to escape, and matches a literal backslash.
+func FastWildCompareCEscapes(strWild, strTame string) bool {
+	slcTokens := make([]wildToken, 0, len(strWild))
+
+	for i := 0; i < len(strWild); i++ {
+		switch {
+		case strWild[i] == '\\' && i+1 < len(strWild):
+			i++
+			slcTokens = append(slcTokens, singleToken(byteSetOf(
+				byte(unescapeRune(rune(strWild[i]))))))
+		case strWild[i] == '*':
+			slcTokens = append(slcTokens, runToken(byteSetAll))
+		case strWild[i] == '?':
+			slcTokens = append(slcTokens, singleToken(byteSetAll))
+		default:
+			slcTokens = append(slcTokens, singleToken(byteSetOf(strWild[i])))
+		}
+	}
+
+	return matchWildTokens(slcTokens, strTame)
+}
+
+// Compares two ASCII strings as FastWildCompareAscii() does, except that a
+// backslash makes the character after it a literal, so that the pattern
+// can call for a literal '*' or '?'.  So "\*", "\?", and "\" match a
+// literal '*', '?', and backslash, and "*\?" matches any content ending
+// in a question mark.  A backslash before any other character is simply
+// dropped, and a backslash at the end of the pattern has nothing to
+// escape, and matches a literal backslash.  Likewise, "\[" matches a
+// literal '[', and inside a bracket class, a backslash makes the character
+// after it a member, so "[\]\-]" matches ']' or '-'.
+//
+// This is separate from FastWildCompareAscii() so that callers comparing
+// raw patterns, in which a backslash is just a literal, are unaffected.
+func FastWildCompareAsciiEscaped(strWild, strTame string) bool {
+	if strings.IndexByte(strWild, '\\') < 0 {
+		return FastWildCompareAscii(strWild, strTame)
+	}
+
+	slcTokens, _ := compileClassTokens(strWild, true)
+	return matchClassTokens(slcTokens, strTame)
+}
+
+// Compares an ASCII pattern in the syntax of SQL's LIKE operator against a
+// tame string, as for offering wildcards to database users.  A '%' matches
+// any run of characters, like '*', and a '_' matches any one character,
+// like '?'.  Every other character is a literal, including '*', '?', '[',
+// and backslash, so "a%d" matches "abcd", and "a_c" matches "abc" but not
+// "ac".  As in standard SQL, there's no escape character, so a literal '%'
+// or '_' calls for FastWildCompareLikeEscape().  The comparison is case
+// sensitive, as with a binary collation.
+func FastWildCompareLike(strWild, strTame string) bool {
+	return FastWildCompareLikeEscape(strWild, strTame, 0)
+}
+
+// Compares an ASCII pattern in the syntax of SQL's LIKE operator against a
+// tame string, as FastWildCompareLike() does, where cEscape is the escape
+// character of the ESCAPE clause.  It makes the character after it a
+// literal, so with an escape character of '!', "100!%" matches just
+// "100%", and "!_%" matches content that starts with '_'.  A doubled
+// escape character matches itself.  An escape character at the end of the
+// pattern has nothing to escape, and matches itself.  A cEscape of 0
+// selects no escape character.
+//
+// The pattern is mapped to the syntax of FastWildCompareAsciiEscaped(),
+// where a backslash makes a literal of any '*', '?', '[', or backslash,
+// and compared via that routine.
+func FastWildCompareLikeEscape(strWild, strTame string, cEscape byte) bool {
+	var sb strings.Builder
+
+	sb.Grow(len(strWild))
+
+	for i := 0; i < len(strWild); i++ {
+		c := strWild[i]
+
+		if c == cEscape && cEscape != 0 && i+1 < len(strWild) {
+			i++
+			c = strWild[i]
+		} else if c == '%' {
+			sb.WriteByte('*')
+			continue
+		} else if c == '_' {
+			sb.WriteByte('?')
+			continue
+		}
+
+		if c == '*' || c == '?' || c == '[' || c == '\\' {
+			sb.WriteByte('\\')
+		}
+
+		sb.WriteByte(c)
+	}
+
+	return FastWildCompareAsciiEscaped(sb.String(), strTame)
+}
+
+// Parses a bound such as "{2,5}" starting at strWild[i].  Returns the
+// minimum, the maximum (-1 if unbounded), and the index just past the
+// closing brace.  The boolean result is false if there's no well-formed
+// bound at strWild[i].
+func parseStarBounds(strWild string, i int) (int, int, int, bool) {
+	if i >= len(strWild) || strWild[i] != '{' {
+		return 0, 0, 0, false
+	}
+
+	// Reads a run of decimal digits, returning its value, or -1 if there are
+	// none, or -2 if the value is implausibly large.
+	parseNumber := func() int {
+		iValue := -1
+
+		for i < len(strWild) && strWild[i] >= '0' && strWild[i] <= '9' {
+			if iValue < 0 {
+				iValue = 0
+			}
+
+			iValue = iValue*10 + int(strWild[i]-'0')
+
+			if iValue > 1<<24 {
+				return -2
+			}
+
+			i++
+		}
+
+		return iValue
+	}
+
+	i++
+	iMin := parseNumber()
+	iMax := iMin
+
+	if iMin == -2 {
+		return 0, 0, 0, false
+	}
+
+	if i < len(strWild) && strWild[i] == ',' {
+		i++
+		iMax = parseNumber()
+
+		if iMax == -2 || (iMin < 0 && iMax < 0) {
+			return 0, 0, 0, false
+		}
+	} else if iMin < 0 {
+		return 0, 0, 0, false
+	}
+
+	if i >= len(strWild) || strWild[i] != '}' {
+		return 0, 0, 0, false
+	}
+
+	if iMin < 0 {
+		iMin = 0
+	}
+
+	if iMax >= 0 && iMax < iMin {
+		return 0, 0, 0, false
+	}
+
+	return iMin, iMax, i + 1, true
+}
+
+// One element of a pattern for FastWildCompareBackref() or
+// FastWildCompareStarBackref().
+type backrefElem struct {
+	cKind byte // '*', '?', '=' for a backreference, or 0 for a literal
+	c     byte // The literal byte
+	iStar int  // Which '*' a '*' or backreference element refers to
+}
+
+// Compares two ASCII strings, accepting '*' and '?' as usual along with
+// backreferences, where "*1" through "*9" must match the same content that
+// the first through ninth '*' matched.  So "*-*1" matches "ab-ab" but not
+// "ab-cd".  A '*' followed by a digit is a backreference only if at least
+// that many '*'s come before it; otherwise it's a '*' followed by a literal
+// digit.  So "*1-*1" is a '*', a literal '1', a '-', and a backreference
+// to that '*'.  Backreferences compare case-sensitively, like literals.
+//
+// Since a backreference depends on exactly where an earlier '*' ended,
+// there's no fallback position that can stand in for all of the others.
+// So this tries each split in turn, which can take exponential time for
+// patterns with many '*'s ahead of a backreference.
+func FastWildCompareBackref(strWild, strTame string) bool {
+	slcElems := make([]backrefElem, 0, len(strWild))
+	iStars := 0
+
+	for i := 0; i < len(strWild); i++ {
+		switch {
+		case strWild[i] == '*' && i+1 < len(strWild) &&
+			strWild[i+1] >= '1' && strWild[i+1] <= '9' &&
+			int(strWild[i+1]-'0') <= iStars:
+			slcElems = append(slcElems,
+				backrefElem{'=', 0, int(strWild[i+1] - '1')})
+			i++
+		case strWild[i] == '*':
+			slcElems = append(slcElems, backrefElem{'*', 0, iStars})
+			iStars++
+		case strWild[i] == '?':
+			slcElems = append(slcElems, backrefElem{'?', 0, 0})
+		default:
+			slcElems = append(slcElems, backrefElem{0, strWild[i], 0})
+		}
+	}
+
+	return matchBackrefElems(slcElems, iStars, strTame)
+}
+
+// Compares two ASCII strings, accepting '*' and '?' as usual along with
+// backreferences, where "\1" through "\9" must match the same content that
+// the first through ninth '*' matched.  This suits checks for repeated
+// values in log lines: "*=*=\2" matches "x=1=1" but not "x=1=2", and
+// "*: *=*=\3" matches "key: x=1=1".  A '\' followed by a digit is a
+// backreference only if at least that many '*'s come before it; any other
+// '\' is a literal.  The result only verifies the match, without reporting
+// what each '*' captured.
+//
+// As with FastWildCompareBackref(), each split is tried in turn, which can
+// take exponential time for patterns with many '*'s ahead of a
+// backreference.
+func FastWildCompareStarBackref(strWild, strTame string) bool {
+	slcElems := make([]backrefElem, 0, len(strWild))
+	iStars := 0
+
+	for i := 0; i < len(strWild); i++ {
+		switch {
+		case strWild[i] == '\\' && i+1 < len(strWild) &&
+			strWild[i+1] >= '1' && strWild[i+1] <= '9' &&
+			int(strWild[i+1]-'0') <= iStars:
+			slcElems = append(slcElems,
+				backrefElem{'=', 0, int(strWild[i+1] - '1')})
+			i++
+		case strWild[i] == '*':
+			slcElems = append(slcElems, backrefElem{'*', 0, iStars})
+			iStars++
+		case strWild[i] == '?':
+			slcElems = append(slcElems, backrefElem{'?', 0, 0})
+		default:
+			slcElems = append(slcElems, backrefElem{0, strWild[i], 0})
+		}
+	}
+
+	return matchBackrefElems(slcElems, iStars, strTame)
+}
+
+// Matches a parsed pattern, containing iStars '*'s, against a tame string.
+func matchBackrefElems(slcElems []backrefElem, iStars int,
+	strTame string) bool {
+	// Where the content matched by each '*' starts and ends.
+	slcStarts := make([]int, iStars)
+	slcEnds := make([]int, iStars)
+
+	var matchFrom func(iElem, iTame int) bool
+	matchFrom = func(iElem, iTame int) bool {
+		for ; iElem < len(slcElems); iElem++ {
+			elem := &slcElems[iElem]
+
+			switch elem.cKind {
+			case '*':
+				for iEnd := iTame; iEnd <= len(strTame); iEnd++ {
+					slcStarts[elem.iStar] = iTame
+					slcEnds[elem.iStar] = iEnd
+
+					if matchFrom(iElem+1, iEnd) {
+						return true
+					}
+				}
+
+				return false
+			case '=':
+				strCapture :=
+					strTame[slcStarts[elem.iStar]:slcEnds[elem.iStar]]
+
+				if !strings.HasPrefix(strTame[iTame:], strCapture) {
+					return false
+				}
+
+				iTame += len(strCapture)
+			case '?':
+				if iTame >= len(strTame) {
+					return false
+				}
+
+				iTame++
+			default:
+				if iTame >= len(strTame) || strTame[iTame] != elem.c {
+					return false
+				}
+
+				iTame++
+			}
+		}
+
+		return iTame == len(strTame)
+	}
+
+	return matchFrom(0, 0)
+}
+
+// Reports whether a byte is a thousands separator for MatchNumber().
+func isDigitSeparator(c byte) bool {
+	return c == ',' || c == '.'
+}
+
+// Compares two ASCII strings, accepting '*' and '?' as usual, where digits
+// are matched regardless of thousands separators in the tame string.  A
+// run of digits in the pattern matches the same digits in the tame string
+// with a ',' or '.' between any two of them, so "1000000" matches
+// "1000000", "1,000,000", and "1.000.000".  A '#' matches any run of one
+// or more digits, again with a ',' or '.' between any two, so "$#.00"
+// doesn't care how the dollars are grouped.  A separator is never skipped
+// before the first digit of a run or after the last, and two separators
+// in a row aren't skipped, so "1000" doesn't match "1,,000" or "1,000,".
+func MatchNumber(strWild, strTame string) bool {
+	iStride := len(strTame) + 1
+	slcDeadEnds := make([]uint64, ((len(strWild)+1)*iStride+63)/64)
+
+	// Reports whether strWild[iWild] is a digit or '#' following a digit or
+	// '#', and so may be preceded by a separator in the tame string.
+	bJoined := func(iWild int) bool {
+		return iWild > 0 && (strWild[iWild-1] == '#' ||
+			strWild[iWild-1] >= '0' && strWild[iWild-1] <= '9')
+	}
+
+	var matchFrom func(iWild, iTame int) bool
+	matchFrom = func(iWild, iTame int) bool {
+		if iWild == len(strWild) {
+			return iTame == len(strTame)
+		}
+
+		iDeadEnd := iWild*iStride + iTame
+
+		if slcDeadEnds[iDeadEnd/64]&(1<<(iDeadEnd%64)) != 0 {
+			return false
+		}
+
+		c := strWild[iWild]
+		bMatched := false
+
+		switch {
+		case c == '*':
+			for iEnd := iTame; !bMatched && iEnd <= len(strTame); iEnd++ {
+				bMatched = matchFrom(iWild+1, iEnd)
+			}
+		case c == '?':
+			bMatched = iTame < len(strTame) && matchFrom(iWild+1, iTame+1)
+		case c == '#' || c >= '0' && c <= '9':
+			// Try the digits with and without a separator ahead of them.
+			for iStart := iTame; !bMatched && iStart <= iTame+1; iStart++ {
+				if iStart > iTame && (!bJoined(iWild) ||
+					iTame >= len(strTame) || !isDigitSeparator(strTame[iTame])) {
+					break
+				}
+
+				for iEnd := iStart; iEnd < len(strTame) &&
+					strTame[iEnd] >= '0' && strTame[iEnd] <= '9' &&
+					(c == '#' || strTame[iEnd] == c); {
+					iEnd++
+
+					if bMatched = matchFrom(iWild+1, iEnd); bMatched ||
+						c != '#' {
+						break
+					}
+
+					// Let the '#' go on past a separator between digits.
+					if iEnd+1 < len(strTame) &&
+						isDigitSeparator(strTame[iEnd]) {
+						iEnd++
+					}
+				}
+			}
+		default:
+			bMatched = iTame < len(strTame) && strTame[iTame] == c &&
+				matchFrom(iWild+1, iTame+1)
+		}
+
+		if !bMatched {
+			slcDeadEnds[iDeadEnd/64] |= 1 << (iDeadEnd % 64)
+		}
+
+		return bMatched
+	}
+
+	return matchFrom(0, 0)
+}
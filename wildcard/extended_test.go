@@ -0,0 +1,600 @@
+// Go tests for the extended wildcard syntaxes.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import (
+	"strings"
+	"testing"
+)
+
+// Tests for FastWildCompareStarBackref(), where "\1" through "\9" refer
+// back to what a '*' matched.
+func TestStarBackref(t *testing.T) {
+	for _, testCase := range []struct {
+		strTame   string
+		strWild   string
+		bExpected bool
+	}{
+		// Repeated values.
+		{"x=1=1", `*=*=\2`, true},
+		{"x=1=2", `*=*=\2`, false},
+		{"x=10=10", `*=*=\2`, true},
+		{"x=10=1", `*=*=\2`, false},
+		{"key: x=1=1", `*: *=*=\3`, true},
+		{"key: x=1=2", `*: *=*=\3`, false},
+		{"a=b=a=b", `*=\1`, true},
+		{"ab-ab", `*-\1`, true},
+		{"ab-cd", `*-\1`, false},
+		{"ab-AB", `*-\1`, false},
+		{"-", `*-\1`, true},
+
+		// Several backreferences, combined with '?'.
+		{"x:yy:yy:x", `*:*:\2:\1`, true},
+		{"x:yy:yy:z", `*:*:\2:\1`, false},
+		{"abcb", `?*?\1`, true},
+		{"abab", `*\1`, true},
+		{"abba", `*\1`, false},
+		{"<b>bold</b>", `<*>*</\1>`, true},
+		{"<b>bold</i>", `<*>*</\1>`, false},
+
+		// A '\' is a literal unless it's a backreference to an earlier '*'.
+		{`\1x-x`, `\1*-\1`, true},
+		{`\1x-y`, `\1*-\1`, false},
+		{`ab\2`, `*\2`, true},
+		{`a\0`, `*\0`, true},
+		{`a\b`, `a\b`, true},
+		{`a\`, `a\`, true},
+		{`\1`, `\1`, true},
+	} {
+		if FastWildCompareStarBackref(testCase.strWild,
+			testCase.strTame) != testCase.bExpected {
+			t.Errorf("FastWildCompareStarBackref(%q, %q) = %t, want %t",
+				testCase.strWild, testCase.strTame, !testCase.bExpected,
+				testCase.bExpected)
+		}
+	}
+
+	// Without any backslashes, results are the same as
+	// FastWildCompareAscii().
+	for _, strWild := range allStrings([]string{"*", "?", "a", "b"}, 4) {
+		for _, strTame := range allStrings([]string{"a", "b"}, 5) {
+			bExpected := FastWildCompareAscii(strWild, strTame)
+
+			if FastWildCompareStarBackref(strWild, strTame) != bExpected {
+				t.Errorf("FastWildCompareStarBackref(%q, %q) = %t, want %t",
+					strWild, strTame, !bExpected, bExpected)
+			}
+		}
+	}
+}
+
+// Tests for FastWildCompareLike() and FastWildCompareLikeEscape(), with
+// cases typical of SQL LIKE predicates.
+func TestLike(t *testing.T) {
+	for _, testCase := range []struct {
+		strWild   string
+		strTame   string
+		bExpected bool
+	}{
+		// '%' and '_' as wildcards.
+		{"a%d", "abcd", true},
+		{"a%d", "ad", true},
+		{"a%d", "abcde", false},
+		{"a_c", "abc", true},
+		{"a_c", "ac", false},
+		{"a_c", "abbc", false},
+		{"%son", "Johnson", true},
+		{"J%n", "Johnson", true},
+		{"_ohn%", "John Smith", true},
+		{"%", "", true},
+		{"_", "", false},
+		{"%_%", "x", true},
+		{"Smith", "smith", false},
+
+		// The glob wildcards and a backslash are literals.
+		{"a*c", "abc", false},
+		{"a*c", "a*c", true},
+		{"a?%", "a?b", true},
+		{"a?%", "ab", false},
+		{"[ab]%", "a", false},
+		{"[ab]%", "[ab]c", true},
+		{"C:\\%", "C:\\temp", true},
+		{"100\\%", "100\\ percent", true},
+		{"100\\%", "100%", false},
+	} {
+		if FastWildCompareLike(testCase.strWild,
+			testCase.strTame) != testCase.bExpected {
+			t.Errorf("FastWildCompareLike(%q, %q) = %t, want %t",
+				testCase.strWild, testCase.strTame, !testCase.bExpected,
+				testCase.bExpected)
+		}
+	}
+
+	// An escape character, as via "ESCAPE '!'" or "ESCAPE '\\'".
+	for _, testCase := range []struct {
+		strWild   string
+		strTame   string
+		cEscape   byte
+		bExpected bool
+	}{
+		{"100!%", "100%", '!', true},
+		{"100!%", "1000", '!', false},
+		{"100!%%", "100% off", '!', true},
+		{"!_%", "_id", '!', true},
+		{"!_%", "id", '!', false},
+		{"a!!b", "a!b", '!', true},
+		{"a!b", "ab", '!', true},
+		{"wow!", "wow!", '!', true},
+		{"%!%%", "50% off", '!', true},
+		{"%!%%", "50 off", '!', false},
+		{"a\\%", "a%", '\\', true},
+		{"a\\%", "ab", '\\', false},
+	} {
+		if FastWildCompareLikeEscape(testCase.strWild, testCase.strTame,
+			testCase.cEscape) != testCase.bExpected {
+			t.Errorf("FastWildCompareLikeEscape(%q, %q, %q) = %t, want %t",
+				testCase.strWild, testCase.strTame, testCase.cEscape,
+				!testCase.bExpected, testCase.bExpected)
+		}
+	}
+
+	// With '%' for '*' and '_' for '?', a pattern matches just as it does
+	// via FastWildCompareAscii().
+	replacer := strings.NewReplacer("*", "%", "?", "_")
+
+	for _, strWild := range allStrings([]string{"*", "?", "a", "b"}, 4) {
+		strLike := replacer.Replace(strWild)
+
+		for _, strTame := range allStrings([]string{"a", "b"}, 5) {
+			bExpected := FastWildCompareAscii(strWild, strTame)
+
+			if FastWildCompareLike(strLike, strTame) != bExpected {
+				t.Errorf("FastWildCompareLike(%q, %q) = %t, want %t",
+					strLike, strTame, !bExpected, bExpected)
+			}
+		}
+	}
+}
+
+// Tests for FastWildCompareWordStar(), where '~' matches a run of word
+// characters.
+func TestWordStar(t *testing.T) {
+	for _, testCase := range []struct {
+		strWild   string
+		strTame   string
+		bExpected bool
+	}{
+		// A '~' matches identifiers and parts of identifiers.
+		{"~", "", true},
+		{"~", "snake_case_42", true},
+		{"get~", "getName", true},
+		{"~Name", "getName", true},
+		{"os.~", "os.Getenv", true},
+		{"~.~(~)", "strings.Index(s)", true},
+
+		// The run stops at punctuation and whitespace.
+		{"~", "os.Getenv", false},
+		{"get~", "get-name", false},
+		{"get~", "get name", false},
+		{"~(~)", "f(a, b)", false},
+		{"x~", "x★", false},
+		{"~(*)", "f(a, b)", true},
+		{"~.~", "fmt.Println", true},
+		{"~.~", "fmt.Println()", false},
+
+		// A '~' can give back characters for whatever follows it.
+		{"~_id", "user_id", true},
+		{"~?", "ab", true},
+		{"~?", "", false},
+
+		// The usual wildcards still apply.
+		{"*?", "a", true},
+		{"*issip*ss*", "mississipissippi", true},
+		{"*a*b", "ac", false},
+	} {
+		if FastWildCompareWordStar(testCase.strWild,
+			testCase.strTame) != testCase.bExpected {
+			t.Errorf("FastWildCompareWordStar(%q, %q) = %t, want %t",
+				testCase.strWild, testCase.strTame, !testCase.bExpected,
+				testCase.bExpected)
+		}
+	}
+}
+
+// Tests for FastWildCompareBoundedStar(), at each boundary of each kind
+// of bound.
+func TestBoundedStar(t *testing.T) {
+	for _, testCase := range []struct {
+		strWild   string
+		strTame   string
+		bExpected bool
+	}{
+		// Both bounds given.
+		{"a*{1,3}b", "ab", false},
+		{"a*{1,3}b", "axb", true},
+		{"a*{1,3}b", "axxb", true},
+		{"a*{1,3}b", "axxxb", true},
+		{"a*{1,3}b", "axxxxb", false},
+		{"a*{0,0}b", "ab", true},
+		{"a*{0,0}b", "axb", false},
+
+		// Open upper bound.
+		{"a*{2,}", "ax", false},
+		{"a*{2,}", "axx", true},
+		{"a*{2,}", "axxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx",
+			true},
+
+		// Open lower bound.
+		{"*{,2}c", "c", true},
+		{"*{,2}c", "abc", true},
+		{"*{,2}c", "abbc", false},
+
+		// Exact count.
+		{"x*{3}y", "x12y", false},
+		{"x*{3}y", "x123y", true},
+		{"x*{3}y", "x1234y", false},
+
+		// Bounded stars that must give back characters to what follows them.
+		{"*{1,3}b*{1,3}b", "abbbb", true},
+		{"*{1,2}b*{1,2}b", "aaabab", false},
+		{"*{2,}ss*{2,}", "mississippi", true},
+		{"*{6,}ss*{2,}", "mississippi", false},
+		{"*a?b*{0,1}", "caaab", true},
+
+		// Unbounded stars mixed in.
+		{"*{1,1}*", "abc", true},
+		{"*{1,1}*", "", false},
+		{"*issip*ss*", "mississipissippi", true},
+
+		// Braces that aren't well-formed bounds are literals.
+		{"a*{3,1}", "ab{3,1}", true},
+		{"a*{3,1}", "abcd", false},
+		{"a*{x}", "a{x}", true},
+		{"a*{,}", "aa{,}", true},
+		{"a*{2", "abc{2", true},
+		{"a{2}", "a{2}", true},
+	} {
+		if FastWildCompareBoundedStar(testCase.strWild,
+			testCase.strTame) != testCase.bExpected {
+			t.Errorf("FastWildCompareBoundedStar(%q, %q) = %t, want %t",
+				testCase.strWild, testCase.strTame, !testCase.bExpected,
+				testCase.bExpected)
+		}
+	}
+}
+
+// Tests for FastWildComparePlus().
+func TestPlus(t *testing.T) {
+	for _, testCase := range []struct {
+		strWild   string
+		strTame   string
+		bExpected bool
+	}{
+		{"go+gle", "google", true},
+		{"go+gle", "gogle", true},
+		{"go+gle", "gooooogle", true},
+		{"go+gle", "ggle", false},
+		{"go+gle", "goggle", false},
+
+		// Combined with '*' and '?'.
+		{"*s+i?p+*", "mississippi", true},
+		{"*s+i?p+*", "misisipi", false},
+		{"?o+*", "booking", true},
+		{"?o+*", "bking", false},
+
+		// A '+' that doesn't follow a literal is a literal.
+		{"+1", "+1", true},
+		{"*+", "c++", true},
+		{"c++", "c+", true},
+		{"c++", "cc+", true},
+		{"c++", "cc", false},
+		{"?+", "a+", true},
+
+		// An escaped '+' is a literal, and can be repeated.
+		{"c\\+\\+", "c++", true},
+		{"c\\+\\+", "cc", false},
+		{"1\\++2", "1+++2", true},
+		{"1\\++2", "12", false},
+		{"a\\b", "a\\b", true},
+	} {
+		if FastWildComparePlus(testCase.strWild,
+			testCase.strTame) != testCase.bExpected {
+			t.Errorf("FastWildComparePlus(%q, %q) = %t, want %t",
+				testCase.strWild, testCase.strTame, !testCase.bExpected,
+				testCase.bExpected)
+		}
+	}
+
+	// Without any '+', results are the same as FastWildCompareAscii().
+	for _, strWild := range allStrings([]string{"*", "?", "a", "b"}, 4) {
+		for _, strTame := range allStrings([]string{"a", "b", "+"}, 4) {
+			bExpected := FastWildCompareAscii(strWild, strTame)
+
+			if FastWildComparePlus(strWild, strTame) != bExpected {
+				t.Errorf("FastWildComparePlus(%q, %q) = %t, want %t",
+					strWild, strTame, !bExpected, bExpected)
+			}
+		}
+	}
+}
+
+// Tests for FastWildCompareBackref().
+func TestBackref(t *testing.T) {
+	for _, testCase := range []struct {
+		strWild   string
+		strTame   string
+		bExpected bool
+	}{
+		{"*-*1", "ab-ab", true},
+		{"*-*1", "ab-cd", false},
+		{"*-*1", "-", true},
+		{"*-*1", "ab-abc", false},
+		{"*-*1", "ab-AB", false},
+
+		// The first '*' has to give up content for the backreference to match.
+		{"*=*1", "a=b=a=b", true},
+		{"<*>*</*1>", "<b>bold</b>", true},
+		{"<*>*</*1>", "<b>bold</i>", false},
+
+		// Several stars and backreferences, combined with '?'.
+		{"*:*:*2:*1", "x:yy:yy:x", true},
+		{"*:*:*2:*1", "x:yy:yy:z", false},
+		{"?*?*1", "abcb", true},
+		{"**1", "abab", true},
+		{"**1", "abba", false},
+
+		// A '*' and digit ahead of that many '*'s are not a backreference.
+		{"*1-*1", "x1-x", true},
+		{"*1-*1", "x1-y", false},
+		{"*2", "abc2", true},
+		{"*0", "00", true},
+	} {
+		if FastWildCompareBackref(testCase.strWild,
+			testCase.strTame) != testCase.bExpected {
+			t.Errorf("FastWildCompareBackref(%q, %q) = %t, want %t",
+				testCase.strWild, testCase.strTame, !testCase.bExpected,
+				testCase.bExpected)
+		}
+	}
+
+	// Without any backreferences, results are the same as
+	// FastWildCompareAscii().
+	for _, strWild := range allStrings([]string{"*", "?", "a", "b"}, 4) {
+		for _, strTame := range allStrings([]string{"a", "b", "1"}, 4) {
+			bExpected := FastWildCompareAscii(strWild, strTame)
+
+			if FastWildCompareBackref(strWild, strTame) != bExpected {
+				t.Errorf("FastWildCompareBackref(%q, %q) = %t, want %t",
+					strWild, strTame, !bExpected, bExpected)
+			}
+		}
+	}
+}
+
+// Tests for MatchNumber().
+func TestMatchNumber(t *testing.T) {
+	for _, testCase := range []struct {
+		strWild   string
+		strTame   string
+		bExpected bool
+	}{
+		// Digit runs in the pattern skip separators in the tame string.
+		{"1000000", "1000000", true},
+		{"1000000", "1,000,000", true},
+		{"1000000", "1.000.000", true},
+		{"1000000", "10,00,000", true},
+		{"1000000", "1,000,00", false},
+		{"1000000", "1,,000,000", false},
+		{"1000000", ",1000000", false},
+		{"1000000", "1000000.", false},
+		{"Total: 1000 items", "Total: 1,000 items", true},
+
+		// A '#' matches any digits, however grouped.
+		{"$#", "$1,234,567", true},
+		{"$#", "$7", true},
+		{"$#", "$", false},
+		{"$#", "$1,234,", false},
+		{"# of #", "1,024 of 4.096", true},
+		{"#000", "12,000", true},
+		{"#000", "12,001", false},
+
+		// Combined with '*' and '?'.
+		{"*: #*", "Count: 65,536 (max)", true},
+		{"v?.#", "v2.1.0", true},
+		{"*1000*", "x1,00x", false},
+	} {
+		if MatchNumber(testCase.strWild,
+			testCase.strTame) != testCase.bExpected {
+			t.Errorf("MatchNumber(%q, %q) = %t, want %t",
+				testCase.strWild, testCase.strTame, !testCase.bExpected,
+				testCase.bExpected)
+		}
+	}
+
+	// Without digits or '#', results are the same as
+	// FastWildCompareAscii().
+	for _, strWild := range allStrings([]string{"*", "?", "a", ","}, 4) {
+		for _, strTame := range allStrings([]string{"a", "1", ","}, 4) {
+			bExpected := FastWildCompareAscii(strWild, strTame)
+
+			if MatchNumber(strWild, strTame) != bExpected {
+				t.Errorf("MatchNumber(%q, %q) = %t, want %t", strWild,
+					strTame, !bExpected, bExpected)
+			}
+		}
+	}
+}
+
+// Tests for FastWildCompareWhitespace().
+func TestWhitespace(t *testing.T) {
+	for _, testCase := range []struct {
+		strWild   string
+		strTame   string
+		bExpected bool
+	}{
+		{"a_b", "a \t b", true},
+		{"a_b", "a b", true},
+		{"a_b", "a\r\n\fb", true},
+		{"a_b", "ab", false},
+		{"a_b", "a_b", false},
+		{"a_b", "a\vb", false},
+		{"a_b", "a x b", false},
+		{"func_main()_{", "func  main()\n\t{", true},
+		{"*_return_nil", "\tif err {\n\t\treturn nil", true},
+		{"*_return_nil", "return nil", false},
+		{"_?_", " x\t", true},
+		{"_?_", " \t", false},
+		{"__", "  ", true},
+		{"__", " ", false},
+		{"a*_", "abc \n", true},
+	} {
+		if FastWildCompareWhitespace(testCase.strWild,
+			testCase.strTame) != testCase.bExpected {
+			t.Errorf("FastWildCompareWhitespace(%q, %q) = %t, want %t",
+				testCase.strWild, testCase.strTame, !testCase.bExpected,
+				testCase.bExpected)
+		}
+	}
+
+	// Without '_', results are the same as FastWildCompareAscii().
+	for _, strWild := range allStrings([]string{"*", "?", "a", " "}, 4) {
+		for _, strTame := range allStrings([]string{"a", " ", "\t"}, 4) {
+			bExpected := FastWildCompareAscii(strWild, strTame)
+
+			if FastWildCompareWhitespace(strWild, strTame) != bExpected {
+				t.Errorf("FastWildCompareWhitespace(%q, %q) = %t, want %t",
+					strWild, strTame, !bExpected, bExpected)
+			}
+		}
+	}
+}
+
+// Tests for FastWildCompareCEscapes().
+func TestCEscapes(t *testing.T) {
+	for _, testCase := range []struct {
+		strWild   string
+		strTame   string
+		bExpected bool
+	}{
+		{"*\\t*", "name\tvalue", true},
+		{"*\\t*", "name value", false},
+		{"*\\t*", "name\\tvalue", false},
+		{"id\\t*\\t?", "id\t42\tx", true},
+		{"*\\r\\n", "HTTP/1.1 200 OK\r\n", true},
+		{"line 1\\n*\\nline 3", "line 1\nx\nline 3", true},
+		{"a\\nb", "a\rb", false},
+
+		// Escaped wildcards and backslashes are literals.
+		{"a\\*", "a*", true},
+		{"a\\*", "ab", false},
+		{"\\?", "?", true},
+		{"\\?", "x", false},
+		{"C:\\\\*", "C:\\dir", true},
+		{"\\\\t", "\t", false},
+
+		// Unknown escapes match the escaped character, and a trailing backslash
+		// is a literal.
+		{"\\x\\y", "xy", true},
+		{"\\x", "\\x", false},
+		{"end\\", "end\\", true},
+		{"end\\", "end", false},
+	} {
+		if FastWildCompareCEscapes(testCase.strWild,
+			testCase.strTame) != testCase.bExpected {
+			t.Errorf("FastWildCompareCEscapes(%q, %q) = %t, want %t",
+				testCase.strWild, testCase.strTame, !testCase.bExpected,
+				testCase.bExpected)
+		}
+	}
+
+	// Without backslashes, results are the same as FastWildCompareAscii().
+	for _, strWild := range allStrings([]string{"*", "?", "a", "t"}, 4) {
+		for _, strTame := range allStrings([]string{"a", "t", "\t"}, 4) {
+			bExpected := FastWildCompareAscii(strWild, strTame)
+
+			if FastWildCompareCEscapes(strWild, strTame) != bExpected {
+				t.Errorf("FastWildCompareCEscapes(%q, %q) = %t, want %t",
+					strWild, strTame, !bExpected, bExpected)
+			}
+		}
+	}
+}
+
+// Tests for FastWildCompareAsciiEscaped().
+func TestAsciiEscaped(t *testing.T) {
+	for _, testCase := range []struct {
+		strWild   string
+		strTame   string
+		bExpected bool
+	}{
+		// Escaped wildcards are literals.
+		{"\\*", "*", true},
+		{"\\*", "a", false},
+		{"\\?", "?", true},
+		{"\\?", "a", false},
+		{"\\\\", "\\", true},
+		{"\\\\", "\\\\", false},
+
+		// Escaped wildcards next to real ones.
+		{"*\\?", "why?", true},
+		{"*\\?", "why", false},
+		{"\\**", "*args", true},
+		{"\\**", "args", false},
+		{"*\\**", "a*b", true},
+		{"*\\**", "ab", false},
+		{"?\\??", "a?b", true},
+		{"?\\??", "abb", false},
+		{"\\*?\\*", "*a*", true},
+		{"\\*?\\*", "*ab*", false},
+		{"*\\\\*", "C:\\Users", true},
+		{"*\\\\*", "C:/Users", false},
+
+		// A trailing lone backslash is a literal, and other escapes drop
+		// the backslash.
+		{"a\\", "a\\", true},
+		{"*\\", "dir\\", true},
+		{"*\\", "dir", false},
+		{"\\a\\b", "ab", true},
+
+		// Escapes apply to bracket classes too.
+		{"\\[a]", "[a]", true},
+		{"\\[a]", "a", false},
+		{"[\\]\\-]", "]", true},
+		{"[\\]\\-]", "-", true},
+		{"[\\]\\-]", "a", false},
+		{"*[\\*\\?]", "really?", true},
+	} {
+		if FastWildCompareAsciiEscaped(testCase.strWild,
+			testCase.strTame) != testCase.bExpected {
+			t.Errorf("FastWildCompareAsciiEscaped(%q, %q) = %t, want %t",
+				testCase.strWild, testCase.strTame, !testCase.bExpected,
+				testCase.bExpected)
+		}
+	}
+
+	// Patterns without backslashes match as usual.
+	for _, strWild := range allStrings([]string{"*", "?", "a", "[!a]"}, 4) {
+		for _, strTame := range allStrings([]string{"a", "b", "\\"}, 5) {
+			bExpected := FastWildCompareAscii(strWild, strTame)
+
+			if FastWildCompareAsciiEscaped(strWild, strTame) != bExpected {
+				t.Errorf("FastWildCompareAsciiEscaped(%q, %q) = %t, want %t",
+					strWild, strTame, !bExpected, bExpected)
+			}
+		}
+	}
+}
@@ -0,0 +1,745 @@
+// Go tests and benchmarks for the matching wildcards routines.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Each pattern made of a literal run and a '*' at either end, which
+// FastWildCompareAscii() compares via strings.HasPrefix() or
+// strings.HasSuffix(), gets the same result as from the general algorithm.
+// That's where the same pattern with a doubled '*' is compared.
+func TestPrefixSuffixParity(t *testing.T) {
+	slcRuns := allStrings([]string{"a", "b", "\xff"}, 4)
+	slcTames := allStrings([]string{"a", "b", "\xff"}, 5)
+
+	for _, strRun := range slcRuns {
+		for _, slcWilds := range [][2]string{
+			{strRun + "*", strRun + "**"},
+			{"*" + strRun, "**" + strRun},
+		} {
+			for _, strTame := range slcTames {
+				bExpected := FastWildCompareAscii(slcWilds[1], strTame)
+
+				if FastWildCompareAscii(slcWilds[0], strTame) != bExpected {
+					t.Fatalf("FastWildCompareAscii(%q, %q) = %t, want %t",
+						slcWilds[0], strTame, !bExpected, bExpected)
+				}
+			}
+		}
+	}
+}
+
+// Each pattern made of a '*' followed by a run of literals and '?'s, which
+// FastWildCompareAscii() compares from the end of the tame string via
+// matchTailReverse(), gets the same result as from the general algorithm,
+// for the same pattern with a doubled '*'.
+func TestTailReverseParity(t *testing.T) {
+	slcRuns := allStrings([]string{"a", "b", "?", "\xff"}, 4)
+	slcTames := allStrings([]string{"a", "b", "\xff"}, 5)
+
+	for _, strRun := range slcRuns {
+		for _, strTame := range slcTames {
+			bExpected := FastWildCompareAscii("**"+strRun, strTame)
+
+			if FastWildCompareAscii("*"+strRun, strTame) != bExpected {
+				t.Fatalf("FastWildCompareAscii(%q, %q) = %t, want %t",
+					"*"+strRun, strTame, !bExpected, bExpected)
+			}
+		}
+	}
+}
+
+// Representative tame/wild pairs, each run as a sub-benchmark.
+var slcBenchCases = []struct {
+	strName string
+	strTame string
+	strWild string
+}{
+	{"ManyStars",
+		"abababababababababababababababababababaacacacacacacacadaeafagahai" +
+			"ajakalaaaaaaaaaaaaaaaaaffafagaagggagaaaaaaaab",
+		"*a*b*ba*ca*a*aa*aaa*fa*ga*b*"},
+	{"LongTame",
+		"abc*abcd*abcde*abcdef*abcdefg*abcdefgh*abcdefghi*abcdefghij*" +
+			"abcdefghijk*abcdefghijkl*abcdefghijklm*abcdefghijklmn",
+		"abc*abc*abc*abc*abc*abc*abc*abc*abc*abc*abc*abc*"},
+	{"NoWildcards",
+		"abcabcdabcdeabcdefabcdefgabcdefghabcdefghiabcdefghijabcdefghijk",
+		"abcabcdabcdeabcdefabcdefgabcdefghabcdefghiabcdefghijabcdefghijk"},
+	{"Pathological",
+		"aaaaaaaaaaaaaaaa",
+		"*a*a*a*a*a*a*a*a*a*a*a*a*a*a*a*a*a*"},
+	{"PathologicalLong",
+		strings.Repeat("a", 1000),
+		strings.Repeat("*a", 32) + "*b"},
+}
+
+// Run via "go test -bench FastWildCompareAscii ./wildcard".
+func BenchmarkFastWildCompareAscii(b *testing.B) {
+	for _, benchCase := range slcBenchCases {
+		b.Run(benchCase.strName, func(b *testing.B) {
+			b.ReportAllocs()
+
+			for b.Loop() {
+				FastWildCompareAscii(benchCase.strWild, benchCase.strTame)
+			}
+		})
+	}
+}
+
+// Run via "go test -bench FastWildCompareRuneSlices ./wildcard".  Each
+// iteration converts both strings to rune slices, as a caller holding
+// strings would, so that the cost of those allocations is reported.
+func BenchmarkFastWildCompareRuneSlices(b *testing.B) {
+	for _, benchCase := range slcBenchCases {
+		b.Run(benchCase.strName, func(b *testing.B) {
+			b.ReportAllocs()
+
+			for b.Loop() {
+				FastWildCompareRuneSlices([]rune(benchCase.strWild),
+					[]rune(benchCase.strTame))
+			}
+		})
+	}
+}
+
+// Run via "go test -bench CompileFold ./wildcard".  Compares matching
+// against a pattern folded once, up front, with folding both sides in each
+// call.
+func BenchmarkCompileFold(b *testing.B) {
+	for _, benchCase := range slcBenchCases {
+		strWild := strings.ToUpper(benchCase.strWild)
+
+		b.Run(benchCase.strName+"/Compiled", func(b *testing.B) {
+			b.ReportAllocs()
+			pattern := CompileFold(strWild)
+
+			for b.Loop() {
+				pattern.MatchString(benchCase.strTame)
+			}
+		})
+
+		b.Run(benchCase.strName+"/FoldBoth", func(b *testing.B) {
+			b.ReportAllocs()
+
+			for b.Loop() {
+				FastWildCompareRuneSlicesFoldFast([]rune(strWild),
+					[]rune(benchCase.strTame))
+			}
+		})
+	}
+}
+
+// Run via "go test -bench FastWildCompareUtf8 ./wildcard".  Compares with
+// BenchmarkFastWildCompareRuneSlices(), which allocates rune slices.
+func BenchmarkFastWildCompareUtf8(b *testing.B) {
+	for _, benchCase := range slcBenchCases {
+		b.Run(benchCase.strName, func(b *testing.B) {
+			b.ReportAllocs()
+
+			for b.Loop() {
+				FastWildCompareUtf8(benchCase.strWild, benchCase.strTame)
+			}
+		})
+	}
+}
+
+// Run via "go test -bench Pattern ./wildcard".  Compares with
+// BenchmarkFastWildCompareAscii(), which finds the '*'s on each call.
+func BenchmarkPatternMatchString(b *testing.B) {
+	for _, benchCase := range slcBenchCases {
+		b.Run(benchCase.strName, func(b *testing.B) {
+			b.ReportAllocs()
+			pattern, _ := Compile(benchCase.strWild)
+
+			for b.Loop() {
+				pattern.MatchString(benchCase.strTame)
+			}
+		})
+	}
+}
+
+// Run via "go test -bench PrefixSuffix ./wildcard".  Compares the prefix
+// and suffix checks made for patterns such as "abc*" and "*abc" with the
+// general algorithm, as run for the same patterns with a doubled '*'.
+func BenchmarkPrefixSuffix(b *testing.B) {
+	strTame := strings.Repeat("abcdefghij", 10)
+
+	for _, benchCase := range []struct {
+		strName string
+		strWild string
+	}{
+		{"Prefix/FastPath", "abcdefghijabcdefghij*"},
+		{"Prefix/General", "abcdefghijabcdefghij**"},
+		{"Suffix/FastPath", "*abcdefghijabcdefghij"},
+		{"Suffix/General", "**abcdefghijabcdefghij"},
+	} {
+		b.Run(benchCase.strName, func(b *testing.B) {
+			b.ReportAllocs()
+
+			for b.Loop() {
+				FastWildCompareAscii(benchCase.strWild, strTame)
+			}
+		})
+	}
+}
+
+// Run via "go test -bench CountMatches ./wildcard".  The allocations per
+// operation are just those made by compiling the pattern, however many
+// candidates are counted.
+func BenchmarkCountMatches(b *testing.B) {
+	slcCorpus := allStrings([]string{"a", "b", "c", "."}, 7)
+
+	for _, strWild := range []string{"*a*b.c", "a?c*", "*[ab].[!c]*"} {
+		b.Run(strWild, func(b *testing.B) {
+			b.ReportAllocs()
+
+			for b.Loop() {
+				CountMatches(strWild, slcCorpus)
+			}
+		})
+	}
+}
+
+// Reports whether a tame string matches a pattern of literals, '*'s, and
+// '?'s, by recursing as referenceMatch() does, but remembering the result
+// for each pair of pattern and tame offsets, so that no pair is worked out
+// twice.  This is the textbook dynamic-programming approach, taking time
+// and memory proportional to the product of the lengths, for comparison
+// with the iterative algorithm, which needs no memory beyond a few indexes.
+func dpWildCompareAscii(strWild, strTame string) bool {
+	iStride := len(strTame) + 1
+	slcMemo := make([]int8, (len(strWild)+1)*iStride) // 0 unknown, 1, or -1
+
+	var matchFrom func(iWild, iTame int) bool
+	matchFrom = func(iWild, iTame int) bool {
+		iMemo := iWild*iStride + iTame
+
+		if slcMemo[iMemo] != 0 {
+			return slcMemo[iMemo] > 0
+		}
+
+		var bMatch bool
+
+		switch {
+		case iWild == len(strWild):
+			bMatch = iTame == len(strTame)
+		case strWild[iWild] == '*':
+			// The '*' matches nothing, or at least one more character.
+			bMatch = matchFrom(iWild+1, iTame) ||
+				iTame < len(strTame) && matchFrom(iWild, iTame+1)
+		default:
+			bMatch = iTame < len(strTame) &&
+				(strWild[iWild] == '?' || strWild[iWild] == strTame[iTame]) &&
+				matchFrom(iWild+1, iTame+1)
+		}
+
+		slcMemo[iMemo] = -1
+
+		if bMatch {
+			slcMemo[iMemo] = 1
+		}
+
+		return bMatch
+	}
+
+	return matchFrom(0, 0)
+}
+
+// Each short pattern over a small alphabet gets the same result from
+// dpWildCompareAscii() as from FastWildCompareAscii(), against each short
+// tame string, so that BenchmarkCompareApproaches() compares like with
+// like.
+func TestDpWildCompareAscii(t *testing.T) {
+	slcWilds := allStrings([]string{"a", "b", "*", "?"}, 5)
+	slcTames := allStrings([]string{"a", "b"}, 6)
+
+	for _, strWild := range slcWilds {
+		for _, strTame := range slcTames {
+			bExpected := FastWildCompareAscii(strWild, strTame)
+
+			if dpWildCompareAscii(strWild, strTame) != bExpected {
+				t.Fatalf("dpWildCompareAscii(%q, %q) = %t, want %t",
+					strWild, strTame, !bExpected, bExpected)
+			}
+		}
+	}
+}
+
+// Run via "go test -bench CompareApproaches ./wildcard".  Compares the
+// iterative algorithm of FastWildCompareAscii() with the recursive,
+// memoized approach of dpWildCompareAscii(), over the same pairs, for the
+// time and the allocations that each takes.
+func BenchmarkCompareApproaches(b *testing.B) {
+	for _, benchCase := range slcBenchCases {
+		b.Run(benchCase.strName+"/Iterative", func(b *testing.B) {
+			b.ReportAllocs()
+
+			for b.Loop() {
+				FastWildCompareAscii(benchCase.strWild, benchCase.strTame)
+			}
+		})
+
+		b.Run(benchCase.strName+"/RecursiveDP", func(b *testing.B) {
+			b.ReportAllocs()
+
+			for b.Loop() {
+				dpWildCompareAscii(benchCase.strWild, benchCase.strTame)
+			}
+		})
+	}
+}
+
+// Run via "go test -bench TailReverse ./wildcard".  Compares patterns made
+// of a '*' and a long run, which are compared from the end of the tame
+// string, with the general algorithm, as run for the same patterns with a
+// doubled '*'.  The general algorithm retries the run from each position
+// of the tame string.
+func BenchmarkTailReverse(b *testing.B) {
+	strRun := strings.Repeat("x", 1000)
+	strQuestionRun := strings.Repeat("x", 500) + "?" + strings.Repeat("x", 499)
+
+	for _, benchCase := range []struct {
+		strName string
+		strWild string
+	}{
+		{"Literal/Reverse", "*" + strRun},
+		{"Literal/General", "**" + strRun},
+		{"Question/Reverse", "*" + strQuestionRun},
+		{"Question/General", "**" + strQuestionRun},
+	} {
+		for _, strTame := range []string{strings.Repeat("x", 10000),
+			strings.Repeat("x", 10000) + "y"} {
+			strName := benchCase.strName + "/Match"
+
+			if strTame[len(strTame)-1] == 'y' {
+				strName = benchCase.strName + "/NoMatch"
+			}
+
+			b.Run(strName, func(b *testing.B) {
+				b.ReportAllocs()
+
+				for b.Loop() {
+					FastWildCompareAscii(benchCase.strWild, strTame)
+				}
+			})
+		}
+	}
+}
+
+// The single-fallback comparison of matchClassTokens() gets the same
+// results as the search of matchWildTokens(), for patterns with bracket
+// classes, both with and without a separator withheld from each token that
+// matches more than one byte, as MatchWithOptions() withholds it.
+func TestClassTokensParity(t *testing.T) {
+	for _, strWild := range allStrings([]string{"*", "?", "a", "/", "[!a]",
+		"[a/]", "[/]"}, 4) {
+		slcTokens, _ := compileClassTokens(strWild, false)
+		slcWithheld, _ := compileClassTokens(strWild, false)
+
+		for i := range slcWithheld {
+			if slcWithheld[i].set.count() > 1 {
+				slcWithheld[i].set.remove('/')
+			}
+		}
+
+		for _, strTame := range allStrings([]string{"a", "b", "/"}, 6) {
+			if matchClassTokens(slcTokens, strTame) !=
+				matchWildTokens(slcTokens, strTame) {
+				t.Errorf("%q against %q: results differ", strWild, strTame)
+			}
+
+			if matchClassTokens(slcWithheld, strTame) !=
+				matchWildTokens(slcWithheld, strTame) {
+				t.Errorf("%q against %q, withholding '/': results differ",
+					strWild, strTame)
+			}
+		}
+	}
+}
+
+// Patterns with bracket classes are compared in time linear in the tame
+// length, for patterns whose '*'s fall back at each tame position.  The
+// steps taken for such a pattern are bounded by a small multiple of the
+// tame length, and each comparison of a long tame string finishes in a
+// small fraction of the time a search that grows with its square would
+// take.
+func TestClassPatternLinear(t *testing.T) {
+	for _, strWild := range []string{"*[a]*a*b", "*[!b]*[a]*?b", "*a*[ab]*c"} {
+		for _, iLen := range []int{5000, 40000} {
+			strTame := strings.Repeat("a", iLen)
+			bMatch, bGaveUp := FastWildCompareAsciiLimit(strWild, strTame,
+				4*iLen)
+
+			if bMatch || bGaveUp {
+				t.Errorf("%q against %d 'a's: got %v, %v; want false, "+
+					"false within %d steps", strWild, iLen, bMatch, bGaveUp,
+					4*iLen)
+			}
+
+			timeStart := time.Now()
+			bAscii := FastWildCompareAscii(strWild, strTame)
+			bRunes := FastWildCompareRuneSlices([]rune(strWild),
+				[]rune(strTame))
+
+			if bAscii || bRunes {
+				t.Errorf("%q against %d 'a's: got %v, %v; want false",
+					strWild, iLen, bAscii, bRunes)
+			}
+
+			if durElapsed := time.Since(timeStart); durElapsed > time.Second {
+				t.Errorf("%q against %d 'a's took %v", strWild, iLen,
+					durElapsed)
+			}
+		}
+	}
+}
+
+// Run via "go test -bench ClassPattern ./wildcard".  The time per
+// comparison grows in proportion to the tame length, for a pattern with a
+// bracket class whose '*'s fall back at each tame position.
+func BenchmarkClassPattern(b *testing.B) {
+	for _, iLen := range []int{5000, 20000, 40000} {
+		strTame := strings.Repeat("a", iLen)
+		rslcTame := []rune(strTame)
+		strSize := strconv.Itoa(iLen)
+
+		b.Run("Ascii/"+strSize, func(b *testing.B) {
+			b.ReportAllocs()
+
+			for b.Loop() {
+				FastWildCompareAscii("*[a]*a*b", strTame)
+			}
+		})
+
+		b.Run("RuneSlices/"+strSize, func(b *testing.B) {
+			b.ReportAllocs()
+
+			for b.Loop() {
+				FastWildCompareRuneSlices([]rune("*[a]*a*b"), rslcTame)
+			}
+		})
+	}
+}
+
+// FastWildCompareAsciiBudget(), FastWildCompareAsciiLimit(), and
+// FastWildCompareAsciiCtx() get the same results as FastWildCompareAscii()
+// for patterns with bracket classes, and the steps they take never exceed
+// WorstCaseSteps().
+func TestBudgetClasses(t *testing.T) {
+	ctx := context.Background()
+
+	for _, strWild := range allStrings([]string{"*", "?", "a", "[!a]",
+		"[*b]", "[a"}, 4) {
+		for _, strTame := range allStrings([]string{"a", "b", "*", "["}, 5) {
+			bExpected := FastWildCompareAscii(strWild, strTame)
+			iWorst := WorstCaseSteps(strWild, len(strTame))
+			bMatch, iRemaining := FastWildCompareAsciiBudget(strWild,
+				strTame, int(iWorst))
+
+			if bMatch != bExpected || iRemaining < 0 {
+				t.Errorf("FastWildCompareAsciiBudget(%q, %q, %d) = %v, %d",
+					strWild, strTame, iWorst, bMatch, iRemaining)
+			}
+
+			if bMatch, bGaveUp := FastWildCompareAsciiLimit(strWild,
+				strTame, int(iWorst)); bMatch != bExpected || bGaveUp {
+				t.Errorf("FastWildCompareAsciiLimit(%q, %q, %d) = %v, %v",
+					strWild, strTame, iWorst, bMatch, bGaveUp)
+			}
+
+			if bMatch, err := FastWildCompareAsciiCtx(ctx, strWild,
+				strTame); bMatch != bExpected || err != nil {
+				t.Errorf("FastWildCompareAsciiCtx(%q, %q) = %v, %v",
+					strWild, strTame, bMatch, err)
+			}
+		}
+	}
+
+	ctxCancelled, cancel := context.WithCancel(ctx)
+	cancel()
+
+	if bMatch, err := FastWildCompareAsciiCtx(ctxCancelled, "*[a]*a*b",
+		strings.Repeat("a", 10000)); bMatch || err != context.Canceled {
+		t.Errorf("FastWildCompareAsciiCtx() with a cancelled context = "+
+			"%v, %v", bMatch, err)
+	}
+}
+
+// Tests for '?' immediately following '*', which skips the search for the
+// next prospective match and so sets up the fallback positions differently.
+// Each case is checked against both FastWildCompareAscii() and
+// FastWildCompareRuneSlices().
+func TestStarQuestion(t *testing.T) {
+	for _, testCase := range []struct {
+		strTame   string
+		strWild   string
+		bExpected bool
+	}{
+		// "*?" at the start of the pattern.
+		{"", "*?", false},
+		{"a", "*?", true},
+		{"ab", "*?", true},
+		{"abc", "*?", true},
+		{"a", "*?a", false},
+		{"ba", "*?a", true},
+		{"bba", "*?a", true},
+		{"ab", "*?a", false},
+		{"a", "*??", false},
+		{"ab", "*??", true},
+		{"abc", "*??", true},
+		{"b", "*?b", false},
+		{"ab", "*?b", true},
+		{"abb", "*?b", true},
+		{"abab", "*?b", true},
+		{"abba", "*?b", false},
+
+		// "*?" in the middle of the pattern.
+		{"ac", "a*?c", false},
+		{"abc", "a*?c", true},
+		{"abbc", "a*?c", true},
+		{"abcc", "a*?c", true},
+		{"acb", "a*?c", false},
+		{"ac", "a*?*c", false},
+		{"abc", "a*?*c", true},
+		{"abxcdyef", "ab*?cd*?ef", true},
+		{"abcdef", "ab*?cd*?ef", false},
+		{"abxcdef", "ab*?cd*?ef", false},
+		{"ababab", "*?a*?b", true},
+
+		// "*?" at the end of the pattern.
+		{"a", "a*?", false},
+		{"ab", "a*?", true},
+		{"abc", "a*?", true},
+		{"a", "*a*?", false},
+		{"ab", "*a*?", true},
+		{"ba", "*a*?", false},
+		{"aab", "*a*?", true},
+
+		// Repeating text matching '*' and then '?', after the DDJ reader cases
+		// among the wild cases.
+		{"caaab", "*a?b", true},
+		{"caaab", "*a?c", false},
+		{"ab", "*a?b", false},
+		{"aab", "*a?b", true},
+		{"aaab", "*a?b", true},
+		{"xaxb", "*a?b", true},
+		{"aaaaa", "*aa?", true},
+		{"aaaa", "*aa?", true},
+		{"aaa", "*aa?", true},
+		{"aa", "*aa?", false},
+		{"aab", "*aa?", true},
+		{"aba", "*aa?", false},
+		{"bbbbab", "*?a?", true},
+		{"bbbba", "*?a?", false},
+		{"xyxyxyz", "*?y?z", false},
+		{"xyxyxyz", "*?x?z", true},
+	} {
+		if FastWildCompareAscii(testCase.strWild,
+			testCase.strTame) != testCase.bExpected {
+			t.Errorf("FastWildCompareAscii(%q, %q) = %t, want %t",
+				testCase.strWild, testCase.strTame, !testCase.bExpected,
+				testCase.bExpected)
+		}
+
+		if FastWildCompareRuneSlices([]rune(testCase.strWild),
+			[]rune(testCase.strTame)) != testCase.bExpected {
+			t.Errorf("FastWildCompareRuneSlices(%q, %q) = %t, want %t",
+				testCase.strWild, testCase.strTame, !testCase.bExpected,
+				testCase.bExpected)
+		}
+	}
+}
+
+// Tests for passing the same rune slice, or overlapping parts of one, as
+// both the pattern and the tame content.  A '*' or '?' in the tame content
+// is just a rune that the pattern's wildcards can match.
+func TestSelfAlias(t *testing.T) {
+	for _, strSelf := range []string{"a*b", "a?b", "*", "?", "**?*", "",
+		"mi*ss?ss*pi", "🐂*🚀?♥"} {
+		rslcSelf := []rune(strSelf)
+
+		if !FastWildCompareRuneSlices(rslcSelf, rslcSelf) {
+			t.Errorf("FastWildCompareRuneSlices(%q, itself) = false",
+				strSelf)
+		}
+
+		if string(rslcSelf) != strSelf {
+			t.Errorf("FastWildCompareRuneSlices(%q, itself) changed it "+
+				"to %q", strSelf, string(rslcSelf))
+		}
+	}
+
+	// Overlapping windows of one slice.
+	rslcOverlap := []rune("*ab*ab")
+
+	for _, overlapCase := range []struct {
+		rslcWild  []rune
+		rslcTame  []rune
+		bExpected bool
+	}{
+		{rslcOverlap[:3], rslcOverlap[1:], true},
+		{rslcOverlap[1:3], rslcOverlap[1:], false},
+		{rslcOverlap[3:], rslcOverlap[1:], true},
+	} {
+		if FastWildCompareRuneSlices(overlapCase.rslcWild,
+			overlapCase.rslcTame) != overlapCase.bExpected {
+			t.Errorf("FastWildCompareRuneSlices(%q, %q) = %t, want %t",
+				string(overlapCase.rslcWild), string(overlapCase.rslcTame),
+				!overlapCase.bExpected, overlapCase.bExpected)
+		}
+	}
+
+	if string(rslcOverlap) != "*ab*ab" {
+		t.Errorf("FastWildCompareRuneSlices() changed \"*ab*ab\" to %q",
+			string(rslcOverlap))
+	}
+}
+
+// Tests for the direct comparison of patterns that have no wildcards.
+func TestLiteralFastPath(t *testing.T) {
+	// Every variant compares literal patterns as whole strings.
+	for _, slcCase := range [][2]string{
+		{"abc", "abc"}, {"abc", "abcd"}, {"abcd", "abc"}, {"abc", "abd"},
+		{"", ""}, {"", "a"}, {"a", ""}, {"ΣΟΦΟΣ", "ΣΟΦΟΣ"}, {"ΣΟΦ", "ΣΟΦΟΣ"},
+	} {
+		strWild, strTame := slcCase[0], slcCase[1]
+		bExpected := strWild == strTame
+
+		if FastWildCompareAscii(strWild, strTame) != bExpected {
+			t.Errorf("FastWildCompareAscii(%q, %q) = %t, want %t", strWild,
+				strTame, !bExpected, bExpected)
+		}
+
+		if FastWildCompareRuneSlices([]rune(strWild),
+			[]rune(strTame)) != bExpected {
+			t.Errorf("FastWildCompareRuneSlices(%q, %q) = %t, want %t",
+				strWild, strTame, !bExpected, bExpected)
+		}
+
+		if FastWildCompareRuneSlicesFoldFast([]rune(strWild),
+			[]rune(strTame)) != bExpected {
+			t.Errorf("FastWildCompareRuneSlicesFoldFast(%q, %q) = %t, "+
+				"want %t", strWild, strTame, !bExpected, bExpected)
+		}
+	}
+
+	if !FastWildCompareRuneSlicesFoldFast([]rune("σοφος"),
+		[]rune("ΣΟΦΟΣ")) {
+		t.Error(`FastWildCompareRuneSlicesFoldFast("σοφος", "ΣΟΦΟΣ") = false`)
+	}
+
+	if FastWildCompareRuneSlicesFoldFast([]rune("σοφ"), []rune("ΣΟΦΟΣ")) {
+		t.Error(`FastWildCompareRuneSlicesFoldFast("σοφ", "ΣΟΦΟΣ") = true`)
+	}
+
+	// Short literal patterns match just as they would via the general
+	// algorithm, which the same patterns still take once a '*' is appended.
+	for _, strWild := range allStrings([]string{"a", "b", "σ"}, 3) {
+		for _, strTame := range allStrings([]string{"a", "b", "σ"}, 4) {
+			bExpected := strWild == strTame
+
+			if FastWildCompareAscii(strWild, strTame) != bExpected {
+				t.Errorf("FastWildCompareAscii(%q, %q) = %t, want %t",
+					strWild, strTame, !bExpected, bExpected)
+			}
+
+			if FastWildCompareRuneSlices([]rune(strWild),
+				[]rune(strTame)) != bExpected {
+				t.Errorf("FastWildCompareRuneSlices(%q, %q) = %t, want %t",
+					strWild, strTame, !bExpected, bExpected)
+			}
+
+			bExpected = strings.HasPrefix(strTame, strWild)
+
+			if FastWildCompareAscii(strWild+"*", strTame) != bExpected {
+				t.Errorf("FastWildCompareAscii(%q, %q) = %t, want %t",
+					strWild+"*", strTame, !bExpected, bExpected)
+			}
+		}
+	}
+}
+
+// Tests for patterns with very long runs of '*' wildcards.  Each routine
+// steps past a run of '*'s once, on reaching it, and its fallback
+// positions are past the run, so the run is never stepped through again.
+func TestLongStarRuns(t *testing.T) {
+	strStars := strings.Repeat("*", 100000)
+	strTame := strings.Repeat("ab", 10000) + "abc"
+
+	for _, testCase := range []struct {
+		strWild   string
+		strTame   string
+		bExpected bool
+	}{
+		{strStars + "abc", strTame, true},
+		{strStars + "abd", strTame, false},
+		{strStars, strTame, true},
+		{strStars, "", true},
+		{"ab" + strStars, strTame, true},
+		{"?" + strStars + "?", strTame, true},
+		{"?" + strStars + "?", "a", false},
+		{strStars + "b" + strStars + "c", strTame, true},
+		{strStars + "c" + strStars + "a", strTame, false},
+		{strings.Repeat("a"+strStars, 10) + "c", strTame, true},
+		{strings.Repeat("a"+strStars, 10) + "x", strTame, false},
+	} {
+		if FastWildCompareAscii(testCase.strWild,
+			testCase.strTame) != testCase.bExpected {
+			t.Errorf("FastWildCompareAscii() = %t for a %d-byte pattern, "+
+				"want %t", !testCase.bExpected, len(testCase.strWild),
+				testCase.bExpected)
+		}
+
+		if FastWildCompareRuneSlices([]rune(testCase.strWild),
+			[]rune(testCase.strTame)) != testCase.bExpected {
+			t.Errorf("FastWildCompareRuneSlices() = %t for a %d-rune "+
+				"pattern, want %t", !testCase.bExpected,
+				len(testCase.strWild), testCase.bExpected)
+		}
+	}
+
+	// Lengthening each run of '*'s changes no results.
+	for _, strWild := range allStrings([]string{"*", "?", "a", "b"}, 4) {
+		if !strings.Contains(strWild, "*") {
+			continue
+		}
+
+		strLong := strings.ReplaceAll(strWild, "*", strings.Repeat("*", 1000))
+
+		for _, strTame := range allStrings([]string{"a", "b"}, 5) {
+			bExpected := FastWildCompareAscii(strWild, strTame)
+
+			if FastWildCompareAscii(strLong, strTame) != bExpected {
+				t.Errorf("FastWildCompareAscii(%q, %q) changed to %t with "+
+					"longer runs", strWild, strTame, !bExpected)
+			}
+
+			if FastWildCompareRuneSlices([]rune(strLong),
+				[]rune(strTame)) != bExpected {
+				t.Errorf("FastWildCompareRuneSlices(%q, %q) changed to %t "+
+					"with longer runs", strWild, strTame, !bExpected)
+			}
+		}
+	}
+}
@@ -0,0 +1,575 @@
+// Go routines for matching wildcards with Unicode case folding.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import (
+	"slices"
+	"sync"
+	"unicode"
+)
+
+// The folded form of every rune in the Basic Multilingual Plane, built on
+// first use by foldRuneTable().  At 128 KB, the table costs far less than
+// the unicode.SimpleFold() calls it saves a server doing heavy
+// case-insensitive matching.
+var (
+	onceFoldTable sync.Once
+	u16slcFold    []uint16
+)
+
+// Returns the folded form of a rune: the lowest-numbered rune among those
+// that unicode.SimpleFold() cycles through, starting from the rune.  Two
+// runes are equal under simple case folding exactly when their folded
+// forms are equal.  So 'K', 'k', and the Kelvin sign all fold to 'K'.
+func foldRuneSimple(r rune) rune {
+	rFolded := r
+
+	for rNext := unicode.SimpleFold(r); rNext != r; rNext =
+		unicode.SimpleFold(rNext) {
+		if rNext < rFolded {
+			rFolded = rNext
+		}
+	}
+
+	return rFolded
+}
+
+// Returns the same result as foldRuneSimple(), via a lookup table for runes
+// in the Basic Multilingual Plane.  A rune's folded form is never greater
+// than the rune itself, so it fits the table's 16-bit entries.
+func foldRuneTable(r rune) rune {
+	onceFoldTable.Do(func() {
+		u16slcFold = make([]uint16, 0x10000)
+
+		for i := range u16slcFold {
+			u16slcFold[i] = uint16(foldRuneSimple(rune(i)))
+		}
+	})
+
+	if r >= 0 && r < 0x10000 {
+		return rune(u16slcFold[r])
+	}
+
+	return foldRuneSimple(r)
+}
+
+// Compares two rune slices as FastWildCompareRuneSlices() does, except
+// that each literal rune matches any rune that's equal to it under simple
+// Unicode case folding.  So "σ*Σ" matches "ΣΟΦΟΣ", and "k?" matches the
+// Kelvin sign followed by any rune.  Folding is done on the fly via a
+// table covering the Basic Multilingual Plane, which is built the first
+// time it's needed, so neither input needs to be copied or lowercased.
+// Bracket classes are accepted as by FastWildCompareRuneSlices(), and a
+// class matches a rune if it lists the rune, or any rune equal to it under
+// simple case folding, so "[a-z]" matches 'Q' and "[!k]" doesn't match the
+// Kelvin sign.
+func FastWildCompareRuneSlicesFoldFast(rslcWild, rslcTame []rune) bool {
+	return fastWildCompareRuneSlicesFolded(rslcWild, rslcTame, foldRuneTable)
+}
+
+// Compares two rune slices as FastWildCompareRuneSlicesFoldFast() does,
+// but with each rune folded via unicode.SimpleFold() as it's compared,
+// rather than via a table.  That suits callers making only occasional
+// case-insensitive comparisons, for which building the table wouldn't pay
+// off.  Neither input is copied or lowercased, and '*' and '?' behave
+// just as in FastWildCompareRuneSlices(), as do bracket classes, which
+// are folded as for FastWildCompareRuneSlicesFoldFast().
+func FastWildCompareRuneSlicesFold(rslcWild, rslcTame []rune) bool {
+	return fastWildCompareRuneSlicesFolded(rslcWild, rslcTame, foldRuneSimple)
+}
+
+// Implements FastWildCompareRuneSlices() with each comparison of literal
+// runes made on the forms returned by fnFold.  The fnFold routine must
+// return '*' and '?' unchanged, and must never return either of them for
+// any other rune.
+func fastWildCompareRuneSlicesFolded(rslcWild, rslcTame []rune,
+	fnFold func(rune) rune) bool {
+	var iWild int = 0     // Index for pattern content
+	var iTame int = 0     // Index for tame content
+	var iWildSequence int // Index for prospective match after '*'
+	var iTameSequence int // Index for match in tame content
+
+	// A pattern without wildcards can only match content of the same length.
+	if !hasWildcardRunes(rslcWild) {
+		if len(rslcWild) != len(rslcTame) {
+			return false
+		}
+
+		for i, r := range rslcWild {
+			if fnFold(r) != fnFold(rslcTame[i]) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	// Bracket classes take more than one pattern rune apiece, so they are
+	// left to the rune glob matcher.
+	if slices.Contains(rslcWild, '[') {
+		return matchRuneGlobTokensFold(compileRuneGlobTokens(rslcWild,
+			false), rslcTame, fnFold)
+	}
+
+	// Find a first wildcard, if one exists, and the beginning of any
+	// prospectively matching sequence after it.  Until a '*' turns up,
+	// iWild and iTame advance together, so they're equal throughout this
+	// loop, but each is used only to index its own string.
+	for {
+		// Check for the end from the start.  Get out fast, if possible.
+		if len(rslcTame) <= iTame {
+			if len(rslcWild) > iWild {
+				for rslcWild[iWild] == '*' {
+					iWild++
+
+					if len(rslcWild) <= iWild {
+						return true // "ab" matches "ab*".
+					}
+				}
+
+				return false // "abcd" doesn't match "abc".
+			} else {
+				return true // "abc" matches "abc".
+			}
+		} else if len(rslcWild) <= iWild {
+			return false // "abc" doesn't match "abcd".
+		} else if rslcWild[iWild] == '*' {
+			// Got wild: set up for the second loop and skip on down there.
+			for {
+				iWild++
+
+				if len(rslcWild) <= iWild {
+					return true // "abc*" matches "abcd".
+				}
+
+				if rslcWild[iWild] != '*' {
+					break
+				}
+			}
+
+			// Search for the next prospective match.
+			if rslcWild[iWild] != '?' {
+				rWild := fnFold(rslcWild[iWild])
+
+				for rWild != fnFold(rslcTame[iTame]) {
+					iTame++
+
+					if len(rslcTame) <= iTame {
+						return false // "a*bc" doesn't match "ab".
+					}
+				}
+			}
+
+			// Keep fallback positions for retry in case of incomplete match.
+			iWildSequence = iWild
+			iTameSequence = iTame
+			break
+		} else if rslcWild[iWild] != '?' &&
+			fnFold(rslcWild[iWild]) != fnFold(rslcTame[iTame]) {
+			return false // "abc" doesn't match "abd".
+		}
+
+		iWild++ // Everything's a match, so far.
+		iTame++
+	}
+
+	// Find any further wildcards and any further matching sequences.
+	for {
+		if len(rslcWild) > iWild && rslcWild[iWild] == '*' {
+			// Got wild again.
+			for {
+				iWild++
+
+				if len(rslcWild) <= iWild {
+					return true // "ab*c*" matches "abcd".
+				}
+
+				if rslcWild[iWild] != '*' {
+					break
+				}
+			}
+
+			if len(rslcTame) <= iTame {
+				return false // "*bcd*" doesn't match "abc".
+			}
+
+			// Search for the next prospective match.
+			if rslcWild[iWild] != '?' {
+				rWild := fnFold(rslcWild[iWild])
+
+				for len(rslcTame) > iTame && rWild != fnFold(rslcTame[iTame]) {
+					iTame++
+
+					if len(rslcTame) <= iTame {
+						return false // "a*b*c" doesn't match "ab".
+					}
+				}
+			}
+
+			// Keep the new fallback positions.
+			iWildSequence = iWild
+			iTameSequence = iTame
+		} else {
+			// The equivalent portion of the upper loop is really simple.
+			if len(rslcTame) <= iTame {
+				if len(rslcWild) <= iWild {
+					return true // "*b*c" matches "abc".
+				}
+
+				return false // "*bcd" doesn't match "abc".
+			}
+
+			if len(rslcWild) <= iWild ||
+				rslcWild[iWild] != '?' &&
+					fnFold(rslcWild[iWild]) != fnFold(rslcTame[iTame]) {
+				// A fine time for questions.
+				for len(rslcWild) > iWildSequence &&
+					rslcWild[iWildSequence] == '?' {
+					iWildSequence++
+					iTameSequence++
+				}
+
+				iWild = iWildSequence
+
+				// Fall back, but never so far again.
+				for {
+					iTameSequence++
+
+					if len(rslcTame) <= iTameSequence {
+						if len(rslcWild) <= iWild {
+							return true // "*a*b" matches "ab".
+						} else {
+							return false // "*a*b" doesn't match "ac".
+						}
+					}
+
+					if len(rslcWild) > iWild &&
+						fnFold(rslcWild[iWild]) ==
+							fnFold(rslcTame[iTameSequence]) {
+						break
+					}
+				}
+
+				iTame = iTameSequence
+			}
+		}
+
+		// Another check for the end, at the end.
+		if len(rslcTame) <= iTame {
+			if len(rslcWild) <= iWild {
+				return true // "*bc" matches "abc".
+			}
+
+			return false // "*bc" doesn't match "abcd".
+		}
+
+		iWild++ // Everything's still a match.
+		iTame++
+	}
+}
+
+// A pattern whose literal runes have been folded once, up front, for
+// case-insensitive matching against many tame strings.
+type FoldPattern struct {
+	rslcFolded []rune          // The pattern, with each literal rune folded
+	bLiteral   bool            // Whether the pattern has no wildcards
+	slcTokens  []runeGlobToken // The tokens of a pattern with a '['
+}
+
+// Folds the literal runes of a pattern, as they would be folded in each
+// comparison by FastWildCompareRuneSlicesFoldFast(), and returns the result
+// for repeated matching via MatchString().  A pattern with bracket classes
+// is compiled to tokens instead, with each literal token folded.
+func CompileFold(strWild string) *FoldPattern {
+	rslcFolded := []rune(strWild)
+
+	if slices.Contains(rslcFolded, '[') {
+		slcTokens := compileRuneGlobTokens(rslcFolded, false)
+
+		for i := range slcTokens {
+			slcTokens[i].r = foldRuneTable(slcTokens[i].r)
+		}
+
+		return &FoldPattern{slcTokens: slcTokens}
+	}
+
+	for i, r := range rslcFolded {
+		rslcFolded[i] = foldRuneTable(r)
+	}
+
+	return &FoldPattern{rslcFolded, !hasWildcardRunes(rslcFolded), nil}
+}
+
+// Reports whether a tame string matches the pattern under simple Unicode
+// case folding, with the same result as FastWildCompareRuneSlicesFoldFast()
+// for the uncompiled pattern.  Only the tame string's runes are folded,
+// each as it's compared.
+func (pattern *FoldPattern) MatchString(strTame string) bool {
+	rslcTame := []rune(strTame)
+
+	if pattern.slcTokens != nil {
+		return matchRuneGlobTokensFold(pattern.slcTokens, rslcTame,
+			foldRuneTable)
+	}
+
+	// A pattern without wildcards can only match content of the same length.
+	if pattern.bLiteral {
+		if len(pattern.rslcFolded) != len(rslcTame) {
+			return false
+		}
+
+		for i, r := range pattern.rslcFolded {
+			if r != foldRuneTable(rslcTame[i]) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return fastWildCompareRuneSlicesPrefolded(pattern.rslcFolded, rslcTame)
+}
+
+// Implements fastWildCompareRuneSlicesFolded(), via foldRuneTable(), for a
+// pattern with wildcards whose literal runes are already folded.
+func fastWildCompareRuneSlicesPrefolded(rslcFolded, rslcTame []rune) bool {
+	var iWild int = 0     // Index for pattern content
+	var iTame int = 0     // Index for tame content
+	var iWildSequence int // Index for prospective match after '*'
+	var iTameSequence int // Index for match in tame content
+
+	// Find a first wildcard, if one exists, and the beginning of any
+	// prospectively matching sequence after it.  Until a '*' turns up,
+	// iWild and iTame advance together, so they're equal throughout this
+	// loop, but each is used only to index its own string.
+	for {
+		// Check for the end from the start.  Get out fast, if possible.
+		if len(rslcTame) <= iTame {
+			if len(rslcFolded) > iWild {
+				for rslcFolded[iWild] == '*' {
+					iWild++
+
+					if len(rslcFolded) <= iWild {
+						return true // "ab" matches "ab*".
+					}
+				}
+
+				return false // "abcd" doesn't match "abc".
+			} else {
+				return true // "abc" matches "abc".
+			}
+		} else if len(rslcFolded) <= iWild {
+			return false // "abc" doesn't match "abcd".
+		} else if rslcFolded[iWild] == '*' {
+			// Got wild: set up for the second loop and skip on down there.
+			for {
+				iWild++
+
+				if len(rslcFolded) <= iWild {
+					return true // "abc*" matches "abcd".
+				}
+
+				if rslcFolded[iWild] != '*' {
+					break
+				}
+			}
+
+			// Search for the next prospective match.
+			if rslcFolded[iWild] != '?' {
+				rWild := rslcFolded[iWild]
+
+				for rWild != foldRuneTable(rslcTame[iTame]) {
+					iTame++
+
+					if len(rslcTame) <= iTame {
+						return false // "a*bc" doesn't match "ab".
+					}
+				}
+			}
+
+			// Keep fallback positions for retry in case of incomplete match.
+			iWildSequence = iWild
+			iTameSequence = iTame
+			break
+		} else if rslcFolded[iWild] != '?' &&
+			rslcFolded[iWild] != foldRuneTable(rslcTame[iTame]) {
+			return false // "abc" doesn't match "abd".
+		}
+
+		iWild++ // Everything's a match, so far.
+		iTame++
+	}
+
+	// Find any further wildcards and any further matching sequences.
+	for {
+		if len(rslcFolded) > iWild && rslcFolded[iWild] == '*' {
+			// Got wild again.
+			for {
+				iWild++
+
+				if len(rslcFolded) <= iWild {
+					return true // "ab*c*" matches "abcd".
+				}
+
+				if rslcFolded[iWild] != '*' {
+					break
+				}
+			}
+
+			if len(rslcTame) <= iTame {
+				return false // "*bcd*" doesn't match "abc".
+			}
+
+			// Search for the next prospective match.
+			if rslcFolded[iWild] != '?' {
+				rWild := rslcFolded[iWild]
+
+				for len(rslcTame) > iTame &&
+					rWild != foldRuneTable(rslcTame[iTame]) {
+					iTame++
+
+					if len(rslcTame) <= iTame {
+						return false // "a*b*c" doesn't match "ab".
+					}
+				}
+			}
+
+			// Keep the new fallback positions.
+			iWildSequence = iWild
+			iTameSequence = iTame
+		} else {
+			// The equivalent portion of the upper loop is really simple.
+			if len(rslcTame) <= iTame {
+				if len(rslcFolded) <= iWild {
+					return true // "*b*c" matches "abc".
+				}
+
+				return false // "*bcd" doesn't match "abc".
+			}
+
+			if len(rslcFolded) <= iWild ||
+				rslcFolded[iWild] != '?' &&
+					rslcFolded[iWild] != foldRuneTable(rslcTame[iTame]) {
+				// A fine time for questions.
+				for len(rslcFolded) > iWildSequence &&
+					rslcFolded[iWildSequence] == '?' {
+					iWildSequence++
+					iTameSequence++
+				}
+
+				iWild = iWildSequence
+
+				// Fall back, but never so far again.
+				for {
+					iTameSequence++
+
+					if len(rslcTame) <= iTameSequence {
+						if len(rslcFolded) <= iWild {
+							return true // "*a*b" matches "ab".
+						} else {
+							return false // "*a*b" doesn't match "ac".
+						}
+					}
+
+					if len(rslcFolded) > iWild &&
+						rslcFolded[iWild] ==
+							foldRuneTable(rslcTame[iTameSequence]) {
+						break
+					}
+				}
+
+				iTame = iTameSequence
+			}
+		}
+
+		// Another check for the end, at the end.
+		if len(rslcTame) <= iTame {
+			if len(rslcFolded) <= iWild {
+				return true // "*bc" matches "abc".
+			}
+
+			return false // "*bc" doesn't match "abcd".
+		}
+
+		iWild++ // Everything's still a match.
+		iTame++
+	}
+}
+
+// Reports whether a token other than a '*' matches a rune under case
+// folding, with literal runes compared on the forms returned by fnFold.  A
+// bracket expression matches if it would match the rune, or any rune that
+// unicode.SimpleFold() cycles through from it, were it not negated, and
+// then the negation applies.
+func (token *runeGlobToken) matchesFold(r rune, fnFold func(rune) rune) bool {
+	switch token.cKind {
+	case '?':
+		return true
+	case '[':
+		rFolded := r
+
+		for {
+			for _, rng := range token.slcRanges {
+				if rFolded >= rng.rFirst && rFolded <= rng.rLast {
+					return !token.bNegated
+				}
+			}
+
+			if rFolded = unicode.SimpleFold(rFolded); rFolded == r {
+				return token.bNegated
+			}
+		}
+	}
+
+	return fnFold(r) == fnFold(token.r)
+}
+
+// Reports whether a rune slice, in its entirety, matches the tokens of a
+// compiled rune glob pattern, as matchRuneGlobTokens() does, but with each
+// token matched under case folding via matchesFold().
+func matchRuneGlobTokensFold(slcTokens []runeGlobToken, rslcTame []rune,
+	fnFold func(rune) rune) bool {
+	iToken := 0
+	iTame := 0
+	iTokenStar := -1 // Index of the '*' we can fall back to, if any
+	iTameStar := 0   // Where content consumed by that '*' ends
+
+	for iTame < len(rslcTame) {
+		if iToken < len(slcTokens) && slcTokens[iToken].cKind == '*' {
+			iTokenStar = iToken
+			iTameStar = iTame
+			iToken++
+		} else if iToken < len(slcTokens) &&
+			slcTokens[iToken].matchesFold(rslcTame[iTame], fnFold) {
+			iToken++
+			iTame++
+		} else if iTokenStar >= 0 {
+			// Let the last '*' consume one more rune, and retry.
+			iTameStar++
+			iToken = iTokenStar + 1
+			iTame = iTameStar
+		} else {
+			return false
+		}
+	}
+
+	for iToken < len(slcTokens) && slcTokens[iToken].cKind == '*' {
+		iToken++
+	}
+
+	return iToken == len(slcTokens)
+}
@@ -0,0 +1,289 @@
+// Go tests for matching with case folding.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+)
+
+// The table agrees with SimpleFold() across the whole BMP, and beyond it.
+func TestFoldRuneTable(t *testing.T) {
+	for r := rune(0); r < 0x10000; r++ {
+		if foldRuneTable(r) != foldRuneSimple(r) {
+			t.Errorf("foldRuneTable(%U) = %U, want %U", r, foldRuneTable(r),
+				foldRuneSimple(r))
+		}
+	}
+
+	for _, r := range []rune{-1, 0x10400, unicode.MaxRune + 1} {
+		if foldRuneTable(r) != foldRuneSimple(r) {
+			t.Errorf("foldRuneTable(%U) = %U, want %U", r, foldRuneTable(r),
+				foldRuneSimple(r))
+		}
+	}
+}
+
+// The folded comparison takes the fast path for a literal pattern, folding
+// each rune once, or not at all when the lengths differ.
+func TestFoldedLiteralFastPath(t *testing.T) {
+	iFolds := 0
+	fnCountingFold := func(r rune) rune {
+		iFolds++
+		return foldRuneSimple(r)
+	}
+
+	if !fastWildCompareRuneSlicesFolded([]rune("Kelvin"), []rune("kELVIN"),
+		fnCountingFold) || iFolds != 12 {
+		t.Errorf("matching \"kELVIN\" took %d folds, want 12", iFolds)
+	}
+
+	iFolds = 0
+
+	if fastWildCompareRuneSlicesFolded([]rune("Kelvin"), []rune("kELVINS"),
+		fnCountingFold) || iFolds != 0 {
+		t.Errorf("rejecting \"kELVINS\" took %d folds, want 0", iFolds)
+	}
+}
+
+// The precompiled pattern gets the same results as folding both sides in
+// each comparison.
+func TestCompileFoldParity(t *testing.T) {
+	slcWilds := []string{"", "*", "?", "abc", "ABC", "a*c", "*Σ", "σ*Σ",
+		"k?", "*issip*PI", "?*?*?", "*a*a*a*b", "ǅ*", "[ab]"}
+	slcTames := []string{"", "a", "abc", "AbC", "ΣΟΦΟΣ", "σοφος", "\u212a!",
+		"MISSISSIPPI", "mississippi", "aaab", "ǆx", "ǲ", "[ab]", "a"}
+
+	for _, strWild := range slcWilds {
+		pattern := CompileFold(strWild)
+
+		for _, strTame := range slcTames {
+			bExpected := FastWildCompareRuneSlicesFoldFast([]rune(strWild),
+				[]rune(strTame))
+
+			if pattern.MatchString(strTame) != bExpected {
+				t.Errorf("CompileFold(%q).MatchString(%q) = %t, want %t",
+					strWild, strTame, !bExpected, bExpected)
+			}
+		}
+	}
+}
+
+// Bracket classes are accepted by the folded matchers, as they are by
+// FastWildCompareRuneSlices(), with each class matching a rune if it lists
+// the rune or any rune equal to it under simple case folding.
+func TestFoldClasses(t *testing.T) {
+	for _, testCase := range []struct {
+		strWild   string
+		strTame   string
+		bExpected bool
+	}{
+		{"[a-z]*", "Quiz", true},
+		{"[!a-z]*", "Quiz", false},
+		{"*[Σ]", "σοφος", true},
+		{"*[σ]", "ΣΟΦΟΣ", true},
+		{"*[ς]", "σοφοσ", true},
+		{"[k]?", "\u212a!", true},
+		{"[!k]", "\u212a", false},
+		{"[α-ω]*", "ΣΟΦΟΣ", true},
+		{"x[", "X[", true},
+		{"[ab]", "[ab]", false},
+	} {
+		rslcWild := []rune(testCase.strWild)
+		rslcTame := []rune(testCase.strTame)
+
+		if FastWildCompareRuneSlicesFold(rslcWild,
+			rslcTame) != testCase.bExpected {
+			t.Errorf("FastWildCompareRuneSlicesFold(%q, %q) = %t, want %t",
+				testCase.strWild, testCase.strTame, !testCase.bExpected,
+				testCase.bExpected)
+		}
+
+		if FastWildCompareRuneSlicesFoldFast(rslcWild,
+			rslcTame) != testCase.bExpected {
+			t.Errorf("FastWildCompareRuneSlicesFoldFast(%q, %q) = %t, "+
+				"want %t", testCase.strWild, testCase.strTame,
+				!testCase.bExpected, testCase.bExpected)
+		}
+
+		if CompileFold(testCase.strWild).MatchString(
+			testCase.strTame) != testCase.bExpected {
+			t.Errorf("CompileFold(%q).MatchString(%q) = %t, want %t",
+				testCase.strWild, testCase.strTame, !testCase.bExpected,
+				testCase.bExpected)
+		}
+	}
+
+	// For ASCII content, folding is the same as lowercasing both strings
+	// up front.
+	for _, strWild := range allStrings([]string{"*", "?", "a", "B",
+		"[aB]", "[!a]", "["}, 3) {
+		pattern := CompileFold(strWild)
+
+		for _, strTame := range allStrings([]string{"a", "A", "b", "B",
+			"["}, 4) {
+			bExpected := FastWildCompareRuneSlices(
+				[]rune(strings.ToLower(strWild)),
+				[]rune(strings.ToLower(strTame)))
+
+			if FastWildCompareRuneSlicesFold([]rune(strWild),
+				[]rune(strTame)) != bExpected ||
+				pattern.MatchString(strTame) != bExpected {
+				t.Errorf("%q against %q, folded, doesn't match as it does "+
+					"lowercased (%t)", strWild, strTame, bExpected)
+			}
+		}
+	}
+}
+
+// Tests for FastWildCompareRuneSlicesFoldFast(), which should get the same
+// results as folding via unicode.SimpleFold() on every call.
+func TestFoldFast(t *testing.T) {
+	for _, testCase := range []struct {
+		strTame   string
+		strWild   string
+		bExpected bool
+	}{
+		{"ΣΟΦΟΣ", "σ*Σ", true},
+		{"σοφος", "ΣΟΦΟΣ", true},
+		{"σοφος", "ΣΟΦ?Σ", true},
+		{"σοφια", "ΣΟΦ*Σ", false},
+		{"ПУШКИН", "пушк??", true},
+		{"Пушкин", "*ШКИН", true},
+		{"Пушкин", "*ШКИНА", false},
+		{"\u212a", "k", true},
+		{"\u212aelvin", "K?LVIN", true},
+		{"straße", "STRASSE", false},
+		{"Straße", "STRA?E", true},
+		{"ǅemal", "ǆ*", true},
+		{"𐐨𐐯", "𐐀*", true},
+		{"🐂🚀♥🍀貔貅", "*♥🍀???", false},
+		{"🐂🚀♥🍀貔貅", "*♥🍀??", true},
+		{"AbC★", "abc?", true},
+		{"⚛⚖☁o", "⚛⚖☁O", true},
+		{"⚛⚖☁O", "⚛⚖☁0", false},
+	} {
+		rslcWild := []rune(testCase.strWild)
+		rslcTame := []rune(testCase.strTame)
+
+		if FastWildCompareRuneSlicesFoldFast(rslcWild,
+			rslcTame) != testCase.bExpected {
+			t.Errorf("FastWildCompareRuneSlicesFoldFast(%q, %q) = %t, "+
+				"want %t", testCase.strWild, testCase.strTame,
+				!testCase.bExpected, testCase.bExpected)
+		}
+
+		if FastWildCompareRuneSlicesFold(rslcWild,
+			rslcTame) != testCase.bExpected {
+			t.Errorf("FastWildCompareRuneSlicesFold(%q, %q) = %t, want %t",
+				testCase.strWild, testCase.strTame, !testCase.bExpected,
+				testCase.bExpected)
+		}
+	}
+
+	// Every short pattern and tame string gets the same result from the
+	// table as from SimpleFold(), and the same result as the case-sensitive
+	// algorithm when both strings are folded in advance.
+	foldRunes := func(str string) []rune {
+		rslcFolded := []rune(str)
+
+		for i, r := range rslcFolded {
+			rslcFolded[i] = foldRuneSimple(r)
+		}
+
+		return rslcFolded
+	}
+
+	for _, strWild := range allStrings([]string{"*", "?", "k", "\u212a",
+		"σ"}, 4) {
+		for _, strTame := range allStrings([]string{"K", "ς", "Σ"}, 4) {
+			rslcWild := []rune(strWild)
+			rslcTame := []rune(strTame)
+			bMatch := FastWildCompareRuneSlicesFoldFast(rslcWild, rslcTame)
+
+			if bMatch != FastWildCompareRuneSlicesFold(rslcWild,
+				rslcTame) || bMatch != FastWildCompareRuneSlices(
+				foldRunes(strWild), foldRunes(strTame)) {
+				t.Errorf("FastWildCompareRuneSlicesFoldFast(%q, %q) = %t, "+
+					"unlike the other folded comparisons", strWild,
+					strTame, bMatch)
+			}
+		}
+	}
+}
+
+// Tests for FastWildCompareRuneSlicesFold(), which should get the same
+// results as lowercasing both strings in advance, for Latin, Greek, and
+// Cyrillic content.
+func TestRuneSlicesFold(t *testing.T) {
+	for _, testCase := range []struct {
+		strTame   string
+		strWild   string
+		bExpected bool
+	}{
+		{"Mississippi", "*ISSIP*pi", true},
+		{"Mississippi", "*ISSIP*PA", false},
+		{"Ångström", "åNG*M", true},
+		{"Ångström", "ang*m", false},
+		{"Crème Brûlée", "CRÈME?BRÛL*", true},
+		{"Crème Brûlée", "CREME*", false},
+		{"Ελληνικά", "ελλην*Ά", true},
+		{"Ελληνικά", "ελλην*α?", false},
+		{"ΑΘΗΝΑ", "αθ?να", true},
+		{"ΑΘΗΝΑ", "αθ?ν", false},
+		{"Москва", "МОСК??", true},
+		{"Москва", "*СКВА", true},
+		{"Москва", "*СКВО", false},
+		{"Достоевский", "д*ЕВ*ИЙ", true},
+		{"Достоевский", "д*ЕВ*ИЯ", false},
+		{"", "*", true},
+		{"", "?", false},
+	} {
+		if FastWildCompareRuneSlicesFold([]rune(testCase.strWild),
+			[]rune(testCase.strTame)) != testCase.bExpected {
+			t.Errorf("FastWildCompareRuneSlicesFold(%q, %q) = %t, want %t",
+				testCase.strWild, testCase.strTame, !testCase.bExpected,
+				testCase.bExpected)
+		}
+
+		if FastWildCompareRuneSlices(
+			[]rune(strings.ToLower(testCase.strWild)),
+			[]rune(strings.ToLower(testCase.strTame))) !=
+			testCase.bExpected {
+			t.Errorf("FastWildCompareRuneSlices() on lowercased %q, %q "+
+				"= %t, want %t", testCase.strWild, testCase.strTame,
+				!testCase.bExpected, testCase.bExpected)
+		}
+	}
+
+	// '*' and '?' behave just as in the case-sensitive algorithm, for
+	// content without any letters.
+	for _, strWild := range allStrings([]string{"*", "?", "1", "-"}, 4) {
+		for _, strTame := range allStrings([]string{"1", "2", "-"}, 5) {
+			rslcWild := []rune(strWild)
+			rslcTame := []rune(strTame)
+			bExpected := FastWildCompareRuneSlices(rslcWild, rslcTame)
+
+			if FastWildCompareRuneSlicesFold(rslcWild,
+				rslcTame) != bExpected {
+				t.Errorf("FastWildCompareRuneSlicesFold(%q, %q) = %t, "+
+					"want %t", strWild, strTame, !bExpected, bExpected)
+			}
+		}
+	}
+}
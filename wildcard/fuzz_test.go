@@ -0,0 +1,88 @@
+// Go fuzz test checking the ASCII matchers against a reference matcher.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import "testing"
+
+// Reports whether a tame string matches a pattern of literals, '*'s, '?'s,
+// and bracket classes, by trying each way that each '*' could match.  This
+// is as plain as a matcher can be, and it takes exponential time, so it's
+// only for checking short strings.
+func referenceMatch(strWild, strTame string) bool {
+	if strWild == "" {
+		return strTame == ""
+	}
+
+	switch strWild[0] {
+	case '*':
+		// The '*' matches nothing, or at least one more character.
+		return referenceMatch(strWild[1:], strTame) ||
+			strTame != "" && referenceMatch(strWild, strTame[1:])
+	case '?':
+		return strTame != "" && referenceMatch(strWild[1:], strTame[1:])
+	case '[':
+		if set, iNext, _ := parseClass(strWild, 0, false); iNext >= 0 {
+			return strTame != "" && set.has(strTame[0]) &&
+				referenceMatch(strWild[iNext:], strTame[1:])
+		}
+	}
+
+	return strTame != "" && strTame[0] == strWild[0] &&
+		referenceMatch(strWild[1:], strTame[1:])
+}
+
+// Maps fuzzed bytes to a string of up to iMaxLen characters drawn from
+// strAlphabet, so that the fuzzer's pairs are short, and small alphabets
+// make matches likely.
+func fuzzString(slcFuzzed []byte, strAlphabet string, iMaxLen int) string {
+	slcMapped := make([]byte, 0, iMaxLen)
+
+	for i := 0; i < len(slcFuzzed) && i < iMaxLen; i++ {
+		slcMapped = append(slcMapped,
+			strAlphabet[int(slcFuzzed[i])%len(strAlphabet)])
+	}
+
+	return string(slcMapped)
+}
+
+// Run via "go test -fuzz FuzzFastWildCompare ./wildcard".  Patterns are
+// made of 'a', 'b', '*', '?', and the bracket class syntax of '[', '!',
+// and ']', and tame strings of 'a', 'b', and ']'.  Both
+// FastWildCompareAscii() and SlowWildCompareAscii() are checked.
+func FuzzFastWildCompare(f *testing.F) {
+	f.Add([]byte("\x00\x02\x01"), []byte("\x00\x01\x01"))
+	f.Add([]byte("\x02\x00\x02\x00\x03\x01"), []byte("\x01\x00\x00\x01"))
+	f.Add([]byte("\x03\x03\x02"), []byte("\x00"))
+	f.Add([]byte(""), []byte(""))
+	f.Add([]byte("\x02\x04\x05\x00\x06\x01"), []byte("\x01\x02\x01"))
+
+	f.Fuzz(func(t *testing.T, slcWild, slcTame []byte) {
+		strWild := fuzzString(slcWild, "ab*?[!]", 12)
+		strTame := fuzzString(slcTame, "ab]", 16)
+		bExpected := referenceMatch(strWild, strTame)
+
+		if FastWildCompareAscii(strWild, strTame) != bExpected {
+			t.Errorf("FastWildCompareAscii(%q, %q) = %t, want %t",
+				strWild, strTame, !bExpected, bExpected)
+		}
+
+		if SlowWildCompareAscii(strWild, strTame) != bExpected {
+			t.Errorf("SlowWildCompareAscii(%q, %q) = %t, want %t",
+				strWild, strTame, !bExpected, bExpected)
+		}
+	})
+}
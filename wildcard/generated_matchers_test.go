@@ -0,0 +1,278 @@
+// Code generated by GenerateMatcherCode; DO NOT EDIT.
+
+// Specialized matchers for a few patterns, generated for
+// TestGenerateMatcherCode() in codegen_test.go.
+
+package wildcard
+
+// generatedMatcher0 reports whether strTame matches the wildcard pattern "*ccd".
+func generatedMatcher0(strTame string) bool {
+	if len(strTame) < 3 {
+		return false
+	}
+	iSuffix := len(strTame) - 3
+	if strTame[iSuffix] != 'c' || strTame[iSuffix+1] != 'c' || strTame[iSuffix+2] != 'd' {
+		return false
+	}
+	return true
+}
+
+// generatedMatcher1 reports whether strTame matches the wildcard pattern "mi*sip*".
+func generatedMatcher1(strTame string) bool {
+	if len(strTame) < 5 {
+		return false
+	}
+	if strTame[0] != 'm' || strTame[1] != 'i' {
+		return false
+	}
+	iTame := 2
+	iEnd := len(strTame)
+
+	// Find "sip".
+	for ; ; iTame++ {
+		if iEnd-iTame < 3 {
+			return false
+		}
+		if strTame[iTame] == 's' && strTame[iTame+1] == 'i' && strTame[iTame+2] == 'p' {
+			break
+		}
+	}
+	return true
+}
+
+// generatedMatcher2 reports whether strTame matches the wildcard pattern "?b*??".
+func generatedMatcher2(strTame string) bool {
+	if len(strTame) < 4 {
+		return false
+	}
+	if strTame[1] != 'b' {
+		return false
+	}
+	return true
+}
+
+// generatedMatcher3 reports whether strTame matches the wildcard pattern "*a?b".
+func generatedMatcher3(strTame string) bool {
+	if len(strTame) < 3 {
+		return false
+	}
+	iSuffix := len(strTame) - 3
+	if strTame[iSuffix] != 'a' || strTame[iSuffix+2] != 'b' {
+		return false
+	}
+	return true
+}
+
+// generatedMatcher4 reports whether strTame matches the wildcard pattern "*aa?".
+func generatedMatcher4(strTame string) bool {
+	if len(strTame) < 3 {
+		return false
+	}
+	iSuffix := len(strTame) - 3
+	if strTame[iSuffix] != 'a' || strTame[iSuffix+1] != 'a' {
+		return false
+	}
+	return true
+}
+
+// generatedMatcher5 reports whether strTame matches the wildcard pattern "xy*z*xyz".
+func generatedMatcher5(strTame string) bool {
+	if len(strTame) < 6 {
+		return false
+	}
+	if strTame[0] != 'x' || strTame[1] != 'y' {
+		return false
+	}
+	iSuffix := len(strTame) - 3
+	if strTame[iSuffix] != 'x' || strTame[iSuffix+1] != 'y' || strTame[iSuffix+2] != 'z' {
+		return false
+	}
+	iTame := 2
+	iEnd := len(strTame) - 3
+
+	// Find "z".
+	for ; ; iTame++ {
+		if iEnd-iTame < 1 {
+			return false
+		}
+		if strTame[iTame] == 'z' {
+			break
+		}
+	}
+	return true
+}
+
+// generatedMatcher6 reports whether strTame matches the wildcard pattern "*12*12*".
+func generatedMatcher6(strTame string) bool {
+	if len(strTame) < 4 {
+		return false
+	}
+	iTame := 0
+	iEnd := len(strTame)
+
+	// Find "12".
+	for ; ; iTame++ {
+		if iEnd-iTame < 2 {
+			return false
+		}
+		if strTame[iTame] == '1' && strTame[iTame+1] == '2' {
+			break
+		}
+	}
+	iTame += 2
+
+	// Find "12".
+	for ; ; iTame++ {
+		if iEnd-iTame < 2 {
+			return false
+		}
+		if strTame[iTame] == '1' && strTame[iTame+1] == '2' {
+			break
+		}
+	}
+	return true
+}
+
+// generatedMatcher7 reports whether strTame matches the wildcard pattern "bL?h".
+func generatedMatcher7(strTame string) bool {
+	if len(strTame) != 4 {
+		return false
+	}
+	return strTame[0] == 'b' && strTame[1] == 'L' && strTame[3] == 'h'
+}
+
+// generatedMatcher8 reports whether strTame matches the wildcard pattern "a*a*a*a*a*a*aa*aaa*a*a*b".
+func generatedMatcher8(strTame string) bool {
+	if len(strTame) < 14 {
+		return false
+	}
+	if strTame[0] != 'a' {
+		return false
+	}
+	iSuffix := len(strTame) - 1
+	if strTame[iSuffix] != 'b' {
+		return false
+	}
+	iTame := 1
+	iEnd := len(strTame) - 1
+
+	// Find "a".
+	for ; ; iTame++ {
+		if iEnd-iTame < 1 {
+			return false
+		}
+		if strTame[iTame] == 'a' {
+			break
+		}
+	}
+	iTame += 1
+
+	// Find "a".
+	for ; ; iTame++ {
+		if iEnd-iTame < 1 {
+			return false
+		}
+		if strTame[iTame] == 'a' {
+			break
+		}
+	}
+	iTame += 1
+
+	// Find "a".
+	for ; ; iTame++ {
+		if iEnd-iTame < 1 {
+			return false
+		}
+		if strTame[iTame] == 'a' {
+			break
+		}
+	}
+	iTame += 1
+
+	// Find "a".
+	for ; ; iTame++ {
+		if iEnd-iTame < 1 {
+			return false
+		}
+		if strTame[iTame] == 'a' {
+			break
+		}
+	}
+	iTame += 1
+
+	// Find "a".
+	for ; ; iTame++ {
+		if iEnd-iTame < 1 {
+			return false
+		}
+		if strTame[iTame] == 'a' {
+			break
+		}
+	}
+	iTame += 1
+
+	// Find "aa".
+	for ; ; iTame++ {
+		if iEnd-iTame < 2 {
+			return false
+		}
+		if strTame[iTame] == 'a' && strTame[iTame+1] == 'a' {
+			break
+		}
+	}
+	iTame += 2
+
+	// Find "aaa".
+	for ; ; iTame++ {
+		if iEnd-iTame < 3 {
+			return false
+		}
+		if strTame[iTame] == 'a' && strTame[iTame+1] == 'a' && strTame[iTame+2] == 'a' {
+			break
+		}
+	}
+	iTame += 3
+
+	// Find "a".
+	for ; ; iTame++ {
+		if iEnd-iTame < 1 {
+			return false
+		}
+		if strTame[iTame] == 'a' {
+			break
+		}
+	}
+	iTame += 1
+
+	// Find "a".
+	for ; ; iTame++ {
+		if iEnd-iTame < 1 {
+			return false
+		}
+		if strTame[iTame] == 'a' {
+			break
+		}
+	}
+	return true
+}
+
+// generatedMatcher9 reports whether strTame matches the wildcard pattern "abc".
+func generatedMatcher9(strTame string) bool {
+	if len(strTame) != 3 {
+		return false
+	}
+	return strTame[0] == 'a' && strTame[1] == 'b' && strTame[2] == 'c'
+}
+
+// generatedMatcher10 reports whether strTame matches the wildcard pattern "".
+func generatedMatcher10(strTame string) bool {
+	if len(strTame) != 0 {
+		return false
+	}
+	return true
+}
+
+// generatedMatcher11 reports whether strTame matches the wildcard pattern "*".
+func generatedMatcher11(strTame string) bool {
+	return true
+}
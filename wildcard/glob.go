@@ -0,0 +1,724 @@
+// Go routines for matching POSIX-style glob patterns.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import (
+	"errors"
+	"path"
+	"strings"
+)
+
+// Flags for MatchFnmatch(), with the same values as the FNM_ flags of the
+// GNU C library's fnmatch().
+const (
+	FnmPathname = 1 << 0 // '*', '?', and brackets never match '/'
+	FnmNoEscape = 1 << 1 // A backslash is a literal, not an escape
+	FnmCasefold = 1 << 4 // Letters match regardless of ASCII case
+)
+
+// The POSIX character classes, for use as "[:name:]" in bracket
+// expressions, with their members in the POSIX locale.
+var mapCharClasses = func() map[string]byteSet {
+	mapClasses := make(map[string]byteSet)
+	add := func(strName string, fnMember func(c byte) bool) {
+		var set byteSet
+
+		for c := 0; c < 128; c++ {
+			if fnMember(byte(c)) {
+				set.add(byte(c))
+			}
+		}
+
+		mapClasses[strName] = set
+	}
+	bUpper := func(c byte) bool { return c >= 'A' && c <= 'Z' }
+	bLower := func(c byte) bool { return c >= 'a' && c <= 'z' }
+	bDigit := func(c byte) bool { return c >= '0' && c <= '9' }
+	bAlpha := func(c byte) bool { return bUpper(c) || bLower(c) }
+	bAlnum := func(c byte) bool { return bAlpha(c) || bDigit(c) }
+	bGraph := func(c byte) bool { return c > ' ' && c < 0x7f }
+
+	add("upper", bUpper)
+	add("lower", bLower)
+	add("digit", bDigit)
+	add("alpha", bAlpha)
+	add("alnum", bAlnum)
+	add("graph", bGraph)
+	add("print", func(c byte) bool { return c >= ' ' && c < 0x7f })
+	add("punct", func(c byte) bool { return bGraph(c) && !bAlnum(c) })
+	add("space", func(c byte) bool { return c == ' ' || c >= '\t' && c <= '\r' })
+	add("blank", func(c byte) bool { return c == ' ' || c == '\t' })
+	add("cntrl", func(c byte) bool { return c < ' ' || c == 0x7f })
+	add("xdigit", func(c byte) bool {
+		return bDigit(c) || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+	})
+
+	return mapClasses
+}()
+
+// One member of a bracket expression, as parsed by parseBracketMember().
+type bracketMember struct {
+	set        byteSet // The bytes the member matches
+	c          byte    // The byte itself, for a member that's one byte
+	bRangeable bool    // Whether the member is one byte that can start a range
+	bFoldable  bool    // Whether FnmCasefold applies to the member
+}
+
+var (
+	errBadClass    = errors.New("unknown character class in bracket expression")
+	errBadCollator = errors.New("unsupported collating element in bracket expression")
+	errBadEscape   = errors.New("pattern ends with an escaping backslash")
+)
+
+// Parses a member of a bracket expression starting at strWild[i]: a byte,
+// which a backslash can escape unless bEscape is false, or a bracketed
+// class, collating element, or equivalence class, such as "[:digit:]",
+// "[.-.]", or "[=a=]".  Returns the member and the index just past it, or
+// -1 if the bracket expression ends before the member does.  At the end of
+// a range, bRangeEnd is true, and a character class isn't recognized, so
+// the '[' of "[a-[:digit:]]" is just a byte, as in the GNU C library.
+func parseBracketMember(strWild string, i int,
+	bEscape, bRangeEnd bool) (bracketMember, int, error) {
+	var member bracketMember
+
+	if strWild[i] == '[' && i+1 < len(strWild) &&
+		(strWild[i+1] == '.' || strWild[i+1] == '=' ||
+			strWild[i+1] == ':' && !bRangeEnd) {
+		cDelimiter := strWild[i+1]
+		iEnd := i + 2
+
+		for iEnd+1 < len(strWild) &&
+			(strWild[iEnd] != cDelimiter || strWild[iEnd+1] != ']') {
+			iEnd++
+		}
+
+		if iEnd+1 >= len(strWild) {
+			return member, -1, nil
+		}
+
+		strName := strWild[i+2 : iEnd]
+
+		if cDelimiter == ':' {
+			setClass, bKnown := mapCharClasses[strName]
+
+			if !bKnown {
+				return member, 0, errBadClass
+			}
+
+			member.set = setClass
+			return member, iEnd + 2, nil
+		} else if len(strName) != 1 {
+			return member, 0, errBadCollator
+		}
+
+		// In the POSIX locale, a one-byte collating element or equivalence
+		// class is just that byte, though only a collating element can
+		// start a range.
+		member.c = strName[0]
+		member.set = byteSetOf(member.c)
+		member.bRangeable = cDelimiter == '.'
+		return member, iEnd + 2, nil
+	}
+
+	if strWild[i] == '\\' && bEscape {
+		i++
+
+		if i >= len(strWild) {
+			return member, -1, nil
+		}
+	}
+
+	member.c = strWild[i]
+	member.set = byteSetOf(member.c)
+	member.bRangeable = true
+	member.bFoldable = true
+	return member, i + 1, nil
+}
+
+// Parses a bracket expression, such as "[a-z_]" or "[![:digit:]]",
+// starting at the '[' at strWild[i].  Returns the set of bytes it matches
+// and the index just past its closing ']'.  A leading '!' or '^' negates
+// the set, and a ']' right after the opening '[' or the negation is a
+// member rather than the end.  Unless bEscape is false, a backslash makes
+// the following byte a member, whatever it is.  A range whose end is lower
+// than its start matches nothing, as in the GNU C library.
+//
+// If bFold is true, the bytes and ranges match either ASCII case, though
+// character classes, collating elements, and equivalence classes don't,
+// again as in the GNU C library.  So "[a-c]" matches 'B', but
+// "[[:lower:]]" doesn't.
+//
+// If there's no closing ']', the returned index is -1, meaning that the
+// '[' is just a literal.  An error is returned for a character class that
+// POSIX doesn't define, or for a collating element or equivalence class
+// other than a single byte, such as the "[.ch.]" in "[[.ch.]]".
+func parseBracket(strWild string, i int, bEscape, bFold bool) (byteSet,
+	int, error) {
+	var set byteSet
+	bNegated := false
+	i++
+
+	if i < len(strWild) && (strWild[i] == '!' || strWild[i] == '^') {
+		bNegated = true
+		i++
+	}
+
+	for iFirst := i; ; {
+		if i >= len(strWild) {
+			return set, -1, nil
+		} else if strWild[i] == ']' && i > iFirst {
+			break
+		}
+
+		member, iNext, err := parseBracketMember(strWild, i, bEscape, false)
+
+		if err != nil || iNext < 0 {
+			return set, iNext, err
+		}
+
+		i = iNext
+
+		// A '-' after a one-byte member makes a range, unless it's last.
+		if member.bRangeable && i+1 < len(strWild) && strWild[i] == '-' &&
+			strWild[i+1] != ']' {
+			memberLast, iNext, err := parseBracketMember(strWild, i+1,
+				bEscape, true)
+
+			if err != nil || iNext < 0 {
+				return set, iNext, err
+			}
+
+			i = iNext
+			member.set = byteSet{}
+
+			if bFold {
+				// The GNU C library lowercases the ends of the range, and
+				// each byte compared against it.
+				cFirst := lowerAscii(member.c)
+				cLast := lowerAscii(memberLast.c)
+
+				for c := 0; c < 256; c++ {
+					if lowerAscii(byte(c)) >= cFirst &&
+						lowerAscii(byte(c)) <= cLast {
+						member.set.add(byte(c))
+					}
+				}
+			} else if memberLast.c >= member.c {
+				member.set.addRange(member.c, memberLast.c)
+			}
+		} else if member.bFoldable && bFold {
+			member.set.foldCase()
+		}
+
+		for j := range set {
+			set[j] |= member.set[j]
+		}
+	}
+
+	if bNegated {
+		for j := range set {
+			set[j] = ^set[j]
+		}
+	}
+
+	return set, i + 1, nil
+}
+
+// Returns the lowercase form of an ASCII letter, or any other byte as is.
+func lowerAscii(c byte) byte {
+	if c >= 'A' && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+
+	return c
+}
+
+// Adds the other ASCII case of each letter in a set.
+func (set *byteSet) foldCase() {
+	for c := byte('A'); c <= 'Z'; c++ {
+		if set.has(c) || set.has(c+'a'-'A') {
+			set.add(c)
+			set.add(c + 'a' - 'A')
+		}
+	}
+}
+
+// Compiles a POSIX glob pattern to tokens, per the FNM_ flags in iFlags.
+func compileGlobTokens(strWild string, iFlags int) ([]wildToken, error) {
+	slcTokens := make([]wildToken, 0, len(strWild))
+	bEscape := iFlags&FnmNoEscape == 0
+
+	for i := 0; i < len(strWild); i++ {
+		var token wildToken
+		bSlash := false  // Whether the token is a literal '/'
+		bFolded := false // Whether FnmCasefold has been applied
+
+		switch {
+		case strWild[i] == '*':
+			token = runToken(byteSetAll)
+		case strWild[i] == '?':
+			token = singleToken(byteSetAll)
+		case strWild[i] == '[':
+			set, iNext, err := parseBracket(strWild, i, bEscape,
+				iFlags&FnmCasefold != 0)
+
+			if err != nil {
+				return nil, err
+			} else if iNext < 0 {
+				token = singleToken(byteSetOf('['))
+			} else {
+				// The bracket expression has been folded already, as needed.
+				token = singleToken(set)
+				i = iNext - 1
+				bFolded = true
+			}
+		case strWild[i] == '\\' && bEscape:
+			if i+1 >= len(strWild) {
+				return nil, errBadEscape
+			}
+
+			i++
+			token = singleToken(byteSetOf(strWild[i]))
+			bSlash = strWild[i] == '/'
+		default:
+			token = singleToken(byteSetOf(strWild[i]))
+			bSlash = strWild[i] == '/'
+		}
+
+		if iFlags&FnmCasefold != 0 && !bFolded {
+			token.set.foldCase()
+		}
+
+		// A literal '/' is the only thing that can match a '/'.
+		if iFlags&FnmPathname != 0 && !bSlash {
+			token.set[0] &^= 1 << '/'
+		}
+
+		slcTokens = append(slcTokens, token)
+	}
+
+	return slcTokens, nil
+}
+
+// Reports whether a pattern is a valid POSIX glob, as used by fnmatch() and
+// the shell, with backslash escapes.  The only invalid globs are those
+// with a bracket expression naming an unknown character class or a
+// multi-byte collating element, and those ending with an escaping
+// backslash.  An unclosed '[' is valid, and matches a literal '['.
+func IsPosixGlob(strWild string) bool {
+	_, err := compileGlobTokens(strWild, 0)
+	return err == nil
+}
+
+// Compares a name against a POSIX glob pattern, as fnmatch() does, with
+// the behaviors selected by the FNM_ flags in iFlags:
+//
+//	FnmPathname  '*', '?', and bracket expressions never match a '/', so
+//	             each '/' in the name must match a '/' in the pattern
+//	FnmNoEscape  a backslash is a literal instead of escaping the next
+//	             character
+//	FnmCasefold  letters match regardless of ASCII case
+//
+// Patterns may use '*', '?', bracket expressions such as "[a-z]",
+// "[!0-9]", and "[[:alpha:]]", and backslash escapes.  Names and patterns
+// are compared byte by byte.  An invalid pattern, as reported by
+// IsPosixGlob(), matches nothing, just as fnmatch() returns an error.
+func MatchFnmatch(strWild, strName string, iFlags int) bool {
+	slcTokens, err := compileGlobTokens(strWild, iFlags)
+
+	if err != nil {
+		return false
+	}
+
+	return matchWildTokens(slcTokens, strName)
+}
+
+// The error reported by MatchPath() for a malformed pattern.  It's the
+// same error that path.Match() and filepath.Match() report, so errors.Is()
+// recognizes it as either.
+var ErrBadPattern = path.ErrBadPattern
+
+// Compares a name against a path pattern, as path.Match() does, except
+// that the path separator is opt.PathSeparator.  A '*' matches any run of
+// bytes other than the separator, so "a/*" matches "a/b" but not "a/b/c".
+// Likewise, a '?' or a bracket class matches any one byte other than the
+// separator.  Each separator in the name must be matched by a separator in
+// the pattern, whether at the start, in the middle, or at the end, so
+// "a/*" matches "a/" but not "a/b/", which "a/*/" matches.
+//
+// The exception is a "**" that makes up a whole path element, bounded by
+// separators or by the ends of the pattern.  It's a globstar, matching any
+// number of whole elements of the name, including none, along with their
+// separators.  So "a/**/z" matches "a/z" and "a/b/c/z", "**/foo" matches
+// "foo" at any depth, and "foo/**" matches "foo" and everything under it.
+// A "**" within an element, as in "a**", is just two '*'s.
+//
+// Bracket classes are as described for parseClass(), and a backslash
+// escapes the byte after it, unless the separator is itself a backslash.
+// A pattern with an unclosed '[', a range that ends before it starts, or
+// a trailing escaping backslash matches nothing, and ErrBadPattern is
+// returned, whether or not the name would otherwise have matched.
+func (opt Options) MatchPath(strWild, strName string) (bool, error) {
+	cSeparator := opt.PathSeparator
+
+	if cSeparator == 0 {
+		cSeparator = '/'
+	}
+
+	var slcElements []string  // The pattern's elements, between separators
+	var slcTokens []wildToken // The tokens of the element being compiled
+	var iElement int          // Where that element starts
+	bEscape := cSeparator != '\\'
+	mapTokens := make(map[string][]wildToken) // Each element's tokens
+
+	for i := 0; i <= len(strWild); i++ {
+		var token wildToken
+		iToken := i // Where the token starts
+
+		switch {
+		case i == len(strWild):
+			// The end of the pattern ends its last element.
+		case strWild[i] == '*':
+			token = runToken(byteSetAll)
+		case strWild[i] == '?':
+			token = singleToken(byteSetAll)
+		case strWild[i] == '[':
+			set, iNext, err := parseClass(strWild, i, bEscape)
+
+			if iNext < 0 || err != nil {
+				return false, ErrBadPattern
+			}
+
+			token = singleToken(set)
+			i = iNext - 1
+		case strWild[i] == '\\' && bEscape:
+			if i+1 >= len(strWild) {
+				return false, ErrBadPattern
+			}
+
+			i++
+			fallthrough
+		default:
+			token = singleToken(byteSetOf(strWild[i]))
+		}
+
+		// A literal separator, or the end, ends an element.  The elements
+		// are compared against the name's elements, so no other token can
+		// match a separator.
+		if i == len(strWild) || strWild[i] == cSeparator &&
+			token.set == byteSetOf(cSeparator) {
+			strElement := strWild[iElement:iToken]
+			slcElements = append(slcElements, strElement)
+			mapTokens[strElement] = slcTokens
+			slcTokens = nil
+			iElement = i + 1
+			continue
+		}
+
+		token.set[cSeparator>>6] &^= 1 << (cSeparator & 63)
+		slcTokens = append(slcTokens, token)
+	}
+
+	return matchSegments(slcElements,
+		strings.Split(strName, string(cSeparator)),
+		func(strElement, strNameElement string) bool {
+			return matchClassTokens(mapTokens[strElement], strNameElement)
+		}), nil
+}
+
+// Calls Options.MatchPath() with '/' as the separator.
+func MatchPath(strWild, strName string) (bool, error) {
+	return Options{}.MatchPath(strWild, strName)
+}
+
+// One rule from an ignore file, as parsed by MatchIgnoreFile().
+type ignoreRule struct {
+	strGlob   string // The pattern, without any '!', or leading or trailing '/'
+	bNegated  bool   // Whether the rule re-includes what it matches
+	bDirOnly  bool   // Whether the rule only matches directories
+	bAnchored bool   // Whether the rule matches whole paths, not base names
+}
+
+// Reports whether a path is ignored by the rules of a .gitignore-style
+// ignore file, given as its lines.  The rules are evaluated in order, and
+// the last one that matches the path decides: a rule ignores the paths it
+// matches, unless it begins with '!', in which case it re-includes them.
+// A path that no rule matches isn't ignored.  As in git:
+//
+//   - Blank lines, and lines beginning with '#', are skipped.  A leading
+//     "\#" or "\!" stands for a literal '#' or '!', and trailing spaces are
+//     trimmed.
+//   - A rule ending with '/' only matches directories.  Paths are
+//     slash-separated and relative to the ignore file's directory, and a
+//     directory's path is written with a trailing '/', as in "build/".
+//   - A rule with a '/' at its start or in its middle matches whole paths,
+//     with a leading '/' dropped.  Any other rule matches the base name,
+//     the last element of a path, at any depth.
+//   - Paths inside an ignored directory are ignored, and no rule can
+//     re-include them, since git doesn't even look inside such directories.
+//
+// Each rule is a POSIX glob, matched via MatchFnmatch() with FnmPathname,
+// so that '*', '?', and bracket expressions never match a '/'.
+func MatchIgnoreFile(slcLines []string, strPath string) bool {
+	slcRules := make([]ignoreRule, 0, len(slcLines))
+
+	for _, strLine := range slcLines {
+		strLine = strings.TrimRight(strLine, " ")
+
+		if strLine == "" || strLine[0] == '#' {
+			continue
+		}
+
+		var rule ignoreRule
+
+		if strLine[0] == '!' {
+			rule.bNegated = true
+			strLine = strLine[1:]
+		} else if strings.HasPrefix(strLine, "\\!") ||
+			strings.HasPrefix(strLine, "\\#") {
+			strLine = strLine[1:]
+		}
+
+		if strings.HasSuffix(strLine, "/") {
+			rule.bDirOnly = true
+			strLine = strLine[:len(strLine)-1]
+		}
+
+		rule.bAnchored = strings.Contains(strLine, "/")
+		rule.strGlob = strings.TrimPrefix(strLine, "/")
+		slcRules = append(slcRules, rule)
+	}
+
+	// Check each directory along the path, and then the path itself.
+	bDir := strings.HasSuffix(strPath, "/")
+	slcElements := strings.Split(strings.TrimSuffix(strPath, "/"), "/")
+
+	for iElements := 1; iElements <= len(slcElements); iElements++ {
+		strSubpath := strings.Join(slcElements[:iElements], "/")
+		bSubpathDir := bDir || iElements < len(slcElements)
+		bIgnored := false
+
+		for _, rule := range slcRules {
+			if rule.bDirOnly && !bSubpathDir {
+				continue
+			}
+
+			strName := strSubpath
+
+			if !rule.bAnchored {
+				strName = slcElements[iElements-1]
+			}
+
+			if MatchFnmatch(rule.strGlob, strName, FnmPathname) {
+				bIgnored = !rule.bNegated
+			}
+		}
+
+		if bIgnored {
+			return true
+		}
+	}
+
+	return false
+}
+
+// A range of runes, from rFirst through rLast, in a bracket expression of
+// a rune glob pattern.
+type runeRange struct {
+	rFirst rune
+	rLast  rune
+}
+
+// One position in a compiled rune glob pattern: a '*', a '?', a bracket
+// expression, or a literal rune.
+type runeGlobToken struct {
+	cKind     byte // '*', '?', '[', or 0 for a literal
+	r         rune // The literal rune
+	slcRanges []runeRange
+	bNegated  bool // Whether the bracket expression matches runes not in it
+}
+
+// Reports whether a token other than a '*' matches a rune.
+func (token *runeGlobToken) matches(r rune) bool {
+	switch token.cKind {
+	case '?':
+		return true
+	case '[':
+		for _, rng := range token.slcRanges {
+			if r >= rng.rFirst && r <= rng.rLast {
+				return !token.bNegated
+			}
+		}
+
+		return token.bNegated
+	}
+
+	return r == token.r
+}
+
+// Returns the rune that a backslash followed by r stands for: a newline,
+// tab, or carriage return for 'n', 't', or 'r', or else r itself.
+func unescapeRune(r rune) rune {
+	switch r {
+	case 'n':
+		return '\n'
+	case 't':
+		return '\t'
+	case 'r':
+		return '\r'
+	}
+
+	return r
+}
+
+// Compiles a rune glob pattern to tokens.  With bEscape, backslash escapes
+// are accepted, as described for FastWildCompareGlobRunesEscaped(), and
+// otherwise a backslash is a literal.
+func compileRuneGlobTokens(rslcWild []rune, bEscape bool) []runeGlobToken {
+	slcTokens := make([]runeGlobToken, 0, len(rslcWild))
+
+	for i := 0; i < len(rslcWild); i++ {
+		switch rslcWild[i] {
+		case '*', '?':
+			slcTokens = append(slcTokens, runeGlobToken{cKind: byte(rslcWild[i])})
+			continue
+		case '\\':
+			if bEscape && i+1 < len(rslcWild) {
+				i++
+				slcTokens = append(slcTokens,
+					runeGlobToken{r: unescapeRune(rslcWild[i])})
+				continue
+			}
+		case '[':
+			if token, iNext := parseRuneBracket(rslcWild, i,
+				bEscape); iNext >= 0 {
+				slcTokens = append(slcTokens, token)
+				i = iNext - 1
+				continue
+			}
+		}
+
+		slcTokens = append(slcTokens, runeGlobToken{r: rslcWild[i]})
+	}
+
+	return slcTokens
+}
+
+// Parses a bracket expression of runes starting at the '[' at
+// rslcWild[i], returning it as a token along with the index just past its
+// closing ']', or -1 if there's no closing ']'.  A leading '^' or '!'
+// negates it.  A ']' right after the opening '[' or the
+// negation is a member, and a '-' between two members makes a range.  With
+// bEscape, a backslash makes the next rune a member, unescaped as by
+// unescapeRune(), and never a range's '-' or the closing ']'.
+func parseRuneBracket(rslcWild []rune, i int,
+	bEscape bool) (runeGlobToken, int) {
+	token := runeGlobToken{cKind: '['}
+	i++
+
+	if i < len(rslcWild) &&
+		(rslcWild[i] == '^' || rslcWild[i] == '!') {
+		token.bNegated = true
+		i++
+	}
+
+	// Reads the member at rslcWild[i], or returns false if there's none.
+	readMember := func() (rune, bool) {
+		if i >= len(rslcWild) {
+			return 0, false
+		} else if bEscape && rslcWild[i] == '\\' && i+1 < len(rslcWild) {
+			i += 2
+			return unescapeRune(rslcWild[i-1]), true
+		}
+
+		i++
+		return rslcWild[i-1], true
+	}
+
+	for iFirst := i; ; {
+		if i >= len(rslcWild) {
+			return token, -1
+		} else if rslcWild[i] == ']' && i > iFirst {
+			break
+		}
+
+		rFirst, _ := readMember()
+		rLast := rFirst
+
+		if i+1 < len(rslcWild) && rslcWild[i] == '-' && rslcWild[i+1] != ']' {
+			i++
+			rLast, _ = readMember()
+		}
+
+		if rLast >= rFirst {
+			token.slcRanges = append(token.slcRanges, runeRange{rFirst, rLast})
+		}
+	}
+
+	return token, i + 1
+}
+
+// Compares two rune slices, accepting '*' and '?' as usual along with
+// bracket expressions and backslash escapes, both inside and outside
+// bracket expressions.  A bracket expression such as "[a-zα-ω]" matches
+// one rune from among its members and ranges, or with a leading '^' or
+// '!', one rune not among them.  A ']' right after the opening '[' or the
+// negation is a member, as is a '-' at either end.  A '[' without a
+// closing ']' is a literal.
+//
+// A backslash makes the next rune a literal, or a member of a bracket
+// expression, whatever it is, except that "\n", "\t", and "\r" stand for
+// a newline, tab, and carriage return.  So "[\]\-]" matches ']' or '-',
+// "[^\n]" matches any rune but a newline, and "\*" matches only a '*'.  A
+// backslash at the end of the pattern is a literal.
+func FastWildCompareGlobRunesEscaped(rslcWild, rslcTame []rune) bool {
+	return matchRuneGlobTokens(compileRuneGlobTokens(rslcWild, true), rslcTame)
+}
+
+// Reports whether a rune slice, in its entirety, matches the tokens of a
+// compiled rune glob pattern.
+func matchRuneGlobTokens(slcTokens []runeGlobToken, rslcTame []rune) bool {
+	iToken := 0
+	iTame := 0
+	iTokenStar := -1 // Index of the '*' we can fall back to, if any
+	iTameStar := 0   // Where content consumed by that '*' ends
+
+	for iTame < len(rslcTame) {
+		if iToken < len(slcTokens) && slcTokens[iToken].cKind == '*' {
+			iTokenStar = iToken
+			iTameStar = iTame
+			iToken++
+		} else if iToken < len(slcTokens) &&
+			slcTokens[iToken].matches(rslcTame[iTame]) {
+			iToken++
+			iTame++
+		} else if iTokenStar >= 0 {
+			// Let the last '*' consume one more rune, and retry.
+			iTameStar++
+			iToken = iTokenStar + 1
+			iTame = iTameStar
+		} else {
+			return false
+		}
+	}
+
+	for iToken < len(slcTokens) && slcTokens[iToken].cKind == '*' {
+		iToken++
+	}
+
+	return iToken == len(slcTokens)
+}
@@ -0,0 +1,474 @@
+// Go tests for the glob and path matching routines.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import (
+	"errors"
+	"path"
+	"strings"
+	"testing"
+)
+
+// Tests for MatchPath() and Options.MatchPath(), where wildcards stop at
+// path separators.
+func TestMatchPath(t *testing.T) {
+	optSlash := Options{}
+	optBackslash := Options{PathSeparator: '\\'}
+
+	for _, testCase := range []struct {
+		opt       Options
+		strWild   string
+		strName   string
+		bExpected bool
+	}{
+		// Wildcards match within one path element, but never across two.
+		{optSlash, "a/*", "a/b", true},
+		{optSlash, "a/*", "a/b/c", false},
+		{optSlash, "a/*/c", "a/b/c", true},
+		{optSlash, "*", "a/b", false},
+		{optSlash, "a?b", "a/b", false},
+		{optSlash, "a[!x]b", "a/b", false},
+		{optSlash, "a[/]b", "a/b", false},
+		{optSlash, "*/*.go", "cmd/main.go", true},
+		{optSlash, "*.go", "cmd/main.go", false},
+
+		// Leading and trailing separators must be matched, too.
+		{optSlash, "/*", "/usr", true},
+		{optSlash, "*", "/usr", false},
+		{optSlash, "a/*", "a/", true},
+		{optSlash, "a/*", "a/b/", false},
+		{optSlash, "a/*/", "a/b/", true},
+		{optSlash, "a/*/", "a/b", false},
+		{optSlash, "a/\\*", "a/*", true},
+		{optSlash, "a/\\*", "a/b", false},
+
+		// Windows-style paths, where a backslash separates rather than
+		// escapes.
+		{optBackslash, "a\\*", "a\\b", true},
+		{optBackslash, "a\\*", "a\\b\\c", false},
+		{optBackslash, "a\\*\\", "a\\b\\", true},
+		{optBackslash, "a\\*", "a\\b/c", true},
+		{optBackslash, "*", "C:\\", false},
+		{optBackslash, "?:\\*", "C:\\x", true},
+	} {
+		bMatch, err := testCase.opt.MatchPath(testCase.strWild,
+			testCase.strName)
+
+		if err != nil || bMatch != testCase.bExpected {
+			t.Errorf("MatchPath(%q, %q) with separator %q = %t, %v, "+
+				"want %t, nil", testCase.strWild, testCase.strName,
+				testCase.opt.PathSeparator, bMatch, err, testCase.bExpected)
+		}
+	}
+
+	// Malformed patterns are reported, whether or not the name matches.
+	for _, testCase := range []struct {
+		opt     Options
+		strWild string
+		strName string
+	}{
+		{optSlash, "a/[bc", "a/b"},
+		{optSlash, "a/[z-a]", "a/b"},
+		{optSlash, "x*[", "a/b"},
+		{optSlash, "a/b\\", "a/b"},
+		{optBackslash, "a\\[b", "a\\b"},
+		{optSlash, "[", ""},
+	} {
+		bMatch, err := testCase.opt.MatchPath(testCase.strWild,
+			testCase.strName)
+
+		if bMatch || !errors.Is(err, ErrBadPattern) {
+			t.Errorf("MatchPath(%q, %q) = %t, %v, want false, %v",
+				testCase.strWild, testCase.strName, bMatch, err,
+				ErrBadPattern)
+		}
+	}
+
+	// For short patterns without escapes, negated classes, which
+	// path.Match() lets match the separator, or a "**", which may be a
+	// globstar, the results agree with path.Match().
+	for _, strWild := range allStrings([]string{"*", "?", "a", "/",
+		"[ab]"}, 4) {
+		if strings.Contains(strWild, "**") {
+			continue
+		}
+
+		for _, strName := range allStrings([]string{"a", "b", "/"}, 4) {
+			for _, strSuffix := range []string{"", "/x"} {
+				strWildFull := strWild
+				strNameFull := strName + strSuffix
+
+				if strSuffix != "" {
+					strWildFull += "/*"
+				}
+
+				bMatch, err := MatchPath(strWildFull, strNameFull)
+				bExpected, errExpected := path.Match(strWildFull,
+					strNameFull)
+
+				if err != nil || errExpected != nil || bMatch != bExpected {
+					t.Errorf("MatchPath(%q, %q) = %t, %v, want %t, %v",
+						strWildFull, strNameFull, bMatch, err, bExpected,
+						errExpected)
+				}
+			}
+		}
+	}
+}
+
+// Tests for "**" as a globstar in MatchPath(), matching any number of
+// whole path elements.
+func TestGlobstar(t *testing.T) {
+	optBackslash := Options{PathSeparator: '\\'}
+
+	for _, testCase := range []struct {
+		opt       Options
+		strWild   string
+		strName   string
+		bExpected bool
+	}{
+		// A leading globstar.
+		{Options{}, "**/foo", "foo", true},
+		{Options{}, "**/foo", "a/foo", true},
+		{Options{}, "**/foo", "a/b/c/foo", true},
+		{Options{}, "**/foo", "a/foo/b", false},
+		{Options{}, "**/foo", "a/xfoo", false},
+		{Options{}, "**/*.go", "cmd/wild/main.go", true},
+		{Options{}, "**/*.go", "main.go", true},
+
+		// A trailing globstar.
+		{Options{}, "foo/**", "foo", true},
+		{Options{}, "foo/**", "foo/", true},
+		{Options{}, "foo/**", "foo/a", true},
+		{Options{}, "foo/**", "foo/a/b/", true},
+		{Options{}, "foo/**", "foobar/a", false},
+		{Options{}, "foo/**", "a/foo/b", false},
+		{Options{}, "**", "", true},
+		{Options{}, "**", "a/b/c", true},
+
+		// Globstars in the middle, matching no elements or several.
+		{Options{}, "a/**/z", "a/z", true},
+		{Options{}, "a/**/z", "a/b/c/z", true},
+		{Options{}, "a/**/z", "a/b/c/zz", false},
+		{Options{}, "a/**/z", "az", false},
+		{Options{}, "a/**/b/**/c", "a/b/c", true},
+		{Options{}, "a/**/b/**/c", "a/x/b/y/z/c", true},
+		{Options{}, "a/**/b/**/c", "a/b/b/b/c", true},
+		{Options{}, "a/**/b/**/c", "a/x/y/c", false},
+		{Options{}, "a/**/b/**/c", "a/c/b", false},
+		{Options{}, "a/**/b?/*.go", "a/x/b1/m.go", true},
+
+		// A "**" within an element is just two '*'s, which stop at a '/'.
+		{Options{}, "a**", "abc", true},
+		{Options{}, "a**", "ab/c", false},
+		{Options{}, "a/**b/c", "a/x/b/c", false},
+		{Options{}, "a/**b/c", "a/xb/c", true},
+		{Options{}, "a/\\**/c", "a/x/c", false},
+		{Options{}, "a/\\**/c", "a/*x/c", true},
+
+		// Windows-style paths.
+		{optBackslash, "C:\\**\\*.exe", "C:\\Windows\\System32\\cmd.exe",
+			true},
+		{optBackslash, "C:\\**\\*.exe", "C:\\cmd.exe", true},
+	} {
+		bMatch, err := testCase.opt.MatchPath(testCase.strWild,
+			testCase.strName)
+
+		if err != nil || bMatch != testCase.bExpected {
+			t.Errorf("MatchPath(%q, %q) with separator %q = %t, %v, "+
+				"want %t, nil", testCase.strWild, testCase.strName,
+				testCase.opt.PathSeparator, bMatch, err, testCase.bExpected)
+		}
+	}
+
+	// A malformed pattern with a globstar.
+	if bMatch, err := MatchPath("**/[z-a]", "a/b"); bMatch ||
+		!errors.Is(err, ErrBadPattern) {
+		t.Errorf("MatchPath(%q, %q) = %t, %v, want false, %v", "**/[z-a]",
+			"a/b", bMatch, err, ErrBadPattern)
+	}
+}
+
+// Tests for IsPosixGlob() and MatchFnmatch(), with the results that the
+// GNU C library's fnmatch() returns for the same cases.
+func TestFnmatch(t *testing.T) {
+	for _, fnmatchCase := range []struct {
+		iFlags    int
+		strWild   string
+		strName   string
+		bExpected bool
+	}{
+		// Without FnmPathname, '*', '?', and brackets match '/'.
+		{0, "*.go", "main.go", true},
+		{0, "*.go", "cmd/main.go", true},
+		{0, "cmd?main.go", "cmd/main.go", true},
+		{0, "cmd[/]main.go", "cmd/main.go", true},
+
+		// With it, only a literal '/' does.
+		{FnmPathname, "*.go", "cmd/main.go", false},
+		{FnmPathname, "*/*.go", "cmd/main.go", true},
+		{FnmPathname, "cmd?main.go", "cmd/main.go", false},
+		{FnmPathname, "cmd[/]main.go", "cmd/main.go", false},
+		{FnmPathname, "cmd[!a]main.go", "cmd/main.go", false},
+		{FnmPathname, "a/b", "a/b", true},
+
+		// Without FnmNoEscape, a backslash escapes the next character.
+		{0, "\\*", "*", true},
+		{0, "\\*", "x", false},
+		{0, "\\[a]", "[a]", true},
+		{0, "a\\\\b", "a\\b", true},
+
+		// With it, a backslash is a literal.
+		{FnmNoEscape, "\\*", "\\x", true},
+		{FnmNoEscape, "\\*", "\\*", true},
+		{FnmNoEscape, "\\[a]", "\\a", true},
+		{FnmNoEscape, "a\\\\b", "a\\\\b", true},
+		{FnmNoEscape | FnmPathname, "*\\*", "x\\y", true},
+
+		// Without FnmCasefold, case matters.
+		{0, "ABC", "abc", false},
+
+		// With it, letters and ranges match either case, but classes don't.
+		{FnmCasefold, "ABC", "abc", true},
+		{FnmCasefold, "a[B-C]c", "abc", true},
+		{FnmCasefold, "[[:upper:]]", "a", false},
+		{FnmCasefold, "[![:lower:]]", "A", true},
+		{FnmCasefold, "[[:alpha:]]", "Q", true},
+
+		// All together.
+		{FnmPathname | FnmNoEscape | FnmCasefold,
+			"*/[[:alpha:]]*.GO", "cmd/main.go", true},
+		{FnmPathname | FnmNoEscape | FnmCasefold,
+			"*[.]GO", "cmd/main.go", false},
+
+		// Bracket expressions.
+		{0, "[a-c]x", "bx", true},
+		{0, "[!a-c]x", "bx", false},
+		{0, "[^a-c]x", "dx", true},
+		{0, "[]]", "]", true},
+		{0, "[!]]", "]", false},
+		{0, "[a-]", "-", true},
+		{0, "[z-a]", "m", false},
+		{0, "[[:digit:][:alpha:]]", "7", true},
+		{0, "[[:space:]]", " ", true},
+		{0, "[[.-.]]", "-", true},
+		{0, "[[=a=]]", "a", true},
+
+		// An unclosed '[' is a literal.
+		{0, "[", "[", true},
+		{0, "[ab", "[ab", true},
+		{0, "a[", "a[", true},
+
+		// Invalid patterns match nothing.
+		{0, "[[:foo:]]", "a", false},
+		{0, "[[.ab.]]", "a", false},
+		{0, "abc\\", "abc\\", false},
+	} {
+		if MatchFnmatch(fnmatchCase.strWild, fnmatchCase.strName,
+			fnmatchCase.iFlags) != fnmatchCase.bExpected {
+			t.Errorf("MatchFnmatch(%q, %q, %#x) = %t, want %t",
+				fnmatchCase.strWild, fnmatchCase.strName, fnmatchCase.iFlags,
+				!fnmatchCase.bExpected, fnmatchCase.bExpected)
+		}
+	}
+
+	for _, globCase := range []struct {
+		strWild   string
+		bExpected bool
+	}{
+		{"*.[ch]", true},
+		{"[[:alpha:]_]*[!~]", true},
+		{"[unclosed", true},
+		{"\\*\\?", true},
+		{"[[:foo:]]", false},
+		{"[[.ab.]]", false},
+		{"abc\\", false},
+	} {
+		if IsPosixGlob(globCase.strWild) != globCase.bExpected {
+			t.Errorf("IsPosixGlob(%q) = %t, want %t", globCase.strWild,
+				!globCase.bExpected, globCase.bExpected)
+		}
+	}
+
+	// For patterns without brackets or backslashes, the results are the
+	// same as FastWildCompareAscii().
+	for _, strWild := range allStrings([]string{"*", "?", "a", "/"}, 4) {
+		for _, strTame := range allStrings([]string{"a", "b", "/"}, 4) {
+			bExpected := FastWildCompareAscii(strWild, strTame)
+
+			if MatchFnmatch(strWild, strTame, 0) != bExpected {
+				t.Errorf("MatchFnmatch(%q, %q, 0) = %t, want %t", strWild,
+					strTame, !bExpected, bExpected)
+			}
+		}
+	}
+}
+
+// Tests for FastWildCompareGlobRunesEscaped().
+func TestGlobRunesEscaped(t *testing.T) {
+	for _, testCase := range []struct {
+		strWild   string
+		strTame   string
+		bExpected bool
+	}{
+		// Escaped bracket expression delimiters are members.
+		{"[\\]\\-]", "]", true},
+		{"[\\]\\-]", "-", true},
+		{"[\\]\\-]", "\\", false},
+		{"[\\]\\-]", "a", false},
+		{"[a\\-z]", "-", true},
+		{"[a\\-z]", "m", false},
+		{"[\\[\\]]", "[", true},
+		{"[\\\\]", "\\", true},
+
+		// Unescaped delimiters in their literal positions.
+		{"[]-]", "]", true},
+		{"[]-]", "-", true},
+		{"[a-]", "-", true},
+
+		// Negation combined with escapes.
+		{"[^\\n]", "x", true},
+		{"[^\\n]", "n", true},
+		{"[^\\n]", "\n", false},
+		{"*[!\\]]", "ab", true},
+		{"*[!\\]]", "a]", false},
+		{"[^\\^]", "x", true},
+		{"[^\\^]", "^", false},
+		{"[\\^]", "^", true},
+		{"[^\\t\\n ]*", "word\tspace", true},
+		{"[^\\t\\n ]*", " word", false},
+
+		// Ranges of non-ASCII runes.
+		{"[α-ω]*", "λόγος", true},
+		{"[α-ω]*", "Λόγος", false},
+		{"?[^α-ω]", "🐂🚀", true},
+		{"[🐂-🐕]", "🐉", true},
+
+		// Escapes outside bracket expressions.
+		{"\\*\\?", "*?", true},
+		{"\\*\\?", "ab", false},
+		{"\\[a]", "[a]", true},
+		{"a\\nb", "a\nb", true},
+		{"a\\", "a\\", true},
+
+		// An unclosed '[' is a literal.
+		{"[ab", "[ab", true},
+		{"*[", "x[", true},
+		{"[\\]", "[]", true},
+	} {
+		if FastWildCompareGlobRunesEscaped([]rune(testCase.strWild),
+			[]rune(testCase.strTame)) != testCase.bExpected {
+			t.Errorf("FastWildCompareGlobRunesEscaped(%q, %q) = %t, want %t",
+				testCase.strWild, testCase.strTame, !testCase.bExpected,
+				testCase.bExpected)
+		}
+	}
+
+	// Without brackets or backslashes, results are the same as
+	// FastWildCompareRuneSlices().
+	for _, strWild := range allStrings([]string{"*", "?", "a", "α"}, 4) {
+		for _, strTame := range allStrings([]string{"a", "α", "["}, 4) {
+			rslcWild := []rune(strWild)
+			rslcTame := []rune(strTame)
+			bExpected := FastWildCompareRuneSlices(rslcWild, rslcTame)
+
+			if FastWildCompareGlobRunesEscaped(rslcWild,
+				rslcTame) != bExpected {
+				t.Errorf("FastWildCompareGlobRunesEscaped(%q, %q) = %t, "+
+					"want %t", strWild, strTame, !bExpected, bExpected)
+			}
+		}
+	}
+}
+
+// Tests for MatchIgnoreFile().
+func TestMatchIgnoreFile(t *testing.T) {
+	slcLines := []string{
+		"# Build output and logs",
+		"*.log",
+		"!important.log",
+		"",
+		"build/",
+		"/TODO",
+		"docs/*.tmp",
+		"\\#notes",
+		"\\!bang  ",
+	}
+
+	for _, testCase := range []struct {
+		strPath   string
+		bExpected bool
+	}{
+		// A later '!' rule re-includes a path that an earlier rule ignored.
+		{"app.log", true},
+		{"src/debug.log", true},
+		{"important.log", false},
+		{"src/important.log", false},
+		{"app.log.txt", false},
+
+		// Directory rules, and paths inside ignored directories.
+		{"build/", true},
+		{"build/app.o", true},
+		{"src/build/", true},
+		{"build/important.log", true},
+		{"build", false},
+		{"builder/app.o", false},
+
+		// Anchored rules.
+		{"TODO", true},
+		{"src/TODO", false},
+		{"docs/draft.tmp", true},
+		{"docs/old/draft.tmp", false},
+		{"src/docs/draft.tmp", false},
+
+		// Comments, escapes, and trailing spaces.
+		{"#notes", true},
+		{"!bang", true},
+		{"# Build output and logs", false},
+		{"README.md", false},
+	} {
+		if MatchIgnoreFile(slcLines,
+			testCase.strPath) != testCase.bExpected {
+			t.Errorf("MatchIgnoreFile(%q) = %t, want %t", testCase.strPath,
+				!testCase.bExpected, testCase.bExpected)
+		}
+	}
+
+	for _, testCase := range []struct {
+		slcLines  []string
+		strPath   string
+		bExpected bool
+	}{
+		// A file in an ignored directory can't be re-included, but one
+		// matched by a wildcard within the directory can.
+		{[]string{"logs/", "!logs/keep.txt"}, "logs/keep.txt", true},
+		{[]string{"logs/*", "!logs/keep.txt"}, "logs/keep.txt", false},
+		{[]string{"logs/*", "!logs/keep.txt"}, "logs/old.txt", true},
+
+		// The last matching rule wins, either way.
+		{[]string{"!a.txt", "*.txt"}, "a.txt", true},
+		{[]string{"*.txt", "!a.txt", "b.txt"}, "a.txt", false},
+		{nil, "a.txt", false},
+	} {
+		if MatchIgnoreFile(testCase.slcLines,
+			testCase.strPath) != testCase.bExpected {
+			t.Errorf("MatchIgnoreFile(%q, %q) = %t, want %t",
+				testCase.slcLines, testCase.strPath, !testCase.bExpected,
+				testCase.bExpected)
+		}
+	}
+}
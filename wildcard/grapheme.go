@@ -0,0 +1,264 @@
+// Go routines for matching wildcards grapheme cluster by grapheme cluster.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import "unicode"
+
+// The properties of runes that decide where grapheme clusters break, per
+// Unicode Standard Annex #29.
+type graphemeProperty int
+
+const (
+	graphemeOther       graphemeProperty = iota
+	graphemeCR                           // Carriage return
+	graphemeLF                           // Line feed
+	graphemeControl                      // Other controls and separators
+	graphemeExtend                       // Combining marks and modifiers
+	graphemeZWJ                          // Zero width joiner
+	graphemeSpacingMark                  // Spacing combining marks
+	graphemeRegional                     // Regional indicators, for flags
+	graphemeL                            // Hangul leading consonants
+	graphemeV                            // Hangul vowels
+	graphemeT                            // Hangul trailing consonants
+	graphemeLV                           // Hangul LV syllables
+	graphemeLVT                          // Hangul LVT syllables
+)
+
+// The Extended_Pictographic runes of Unicode's emoji data, which can be
+// joined into one grapheme cluster via zero width joiners.
+var rangeTablePictographic = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{0x00a9, 0x00ae, 5},
+		{0x203c, 0x2049, 13},
+		{0x2122, 0x2139, 23},
+		{0x2194, 0x2199, 1},
+		{0x21a9, 0x21aa, 1},
+		{0x231a, 0x231b, 1},
+		{0x2328, 0x2388, 96},
+		{0x23cf, 0x23e9, 26},
+		{0x23ea, 0x23f3, 1},
+		{0x23f8, 0x23fa, 1},
+		{0x24c2, 0x25aa, 232},
+		{0x25ab, 0x25b6, 11},
+		{0x25c0, 0x25fb, 59},
+		{0x25fc, 0x25fe, 1},
+		{0x2600, 0x27bf, 1},
+		{0x2934, 0x2935, 1},
+		{0x2b05, 0x2b07, 1},
+		{0x2b1b, 0x2b1c, 1},
+		{0x2b50, 0x2b55, 5},
+		{0x3030, 0x303d, 13},
+		{0x3297, 0x3299, 2},
+	},
+	R32: []unicode.Range32{
+		{0x1f000, 0x1f0ff, 1},
+		{0x1f10d, 0x1f10f, 1},
+		{0x1f12f, 0x1f16c, 61},
+		{0x1f16d, 0x1f171, 1},
+		{0x1f17e, 0x1f17f, 1},
+		{0x1f18e, 0x1f191, 3},
+		{0x1f192, 0x1f19a, 1},
+		{0x1f1ad, 0x1f1e5, 1},
+		{0x1f201, 0x1f20f, 1},
+		{0x1f21a, 0x1f22f, 21},
+		{0x1f232, 0x1f23a, 1},
+		{0x1f23c, 0x1f23f, 1},
+		{0x1f249, 0x1f3fa, 1},
+		{0x1f400, 0x1f53d, 1},
+		{0x1f546, 0x1f64f, 1},
+		{0x1f680, 0x1f6ff, 1},
+		{0x1f774, 0x1f77f, 1},
+		{0x1f7d5, 0x1f7ff, 1},
+		{0x1f80c, 0x1f80f, 1},
+		{0x1f848, 0x1f84f, 1},
+		{0x1f85a, 0x1f85f, 1},
+		{0x1f888, 0x1f88f, 1},
+		{0x1f8ae, 0x1f8ff, 1},
+		{0x1f90c, 0x1f93a, 1},
+		{0x1f93c, 0x1f945, 1},
+		{0x1f947, 0x1faff, 1},
+		{0x1fc00, 0x1fffd, 1},
+	},
+	LatinOffset: 1,
+}
+
+// Returns the grapheme cluster break property of a rune.  The properties
+// are taken from the rune's general category where the annex derives them
+// from it, so a few rarely used runes, such as the prepended concatenation
+// marks, are treated as controls or as other runes.
+func graphemePropertyOf(r rune) graphemeProperty {
+	switch {
+	case r == '\r':
+		return graphemeCR
+	case r == '\n':
+		return graphemeLF
+	case r == 0x200d:
+		return graphemeZWJ
+	case r == 0x200c, r >= 0xe0020 && r <= 0xe007f,
+		r >= 0x1f3fb && r <= 0x1f3ff, unicode.In(r, unicode.Mn, unicode.Me):
+		// Among these are the tags of subdivision flags, such as that of
+		// Scotland, and the skin tone modifiers.
+		return graphemeExtend
+	case unicode.In(r, unicode.Cc, unicode.Cf, unicode.Zl, unicode.Zp):
+		return graphemeControl
+	case unicode.Is(unicode.Mc, r):
+		return graphemeSpacingMark
+	case r >= 0x1f1e6 && r <= 0x1f1ff:
+		return graphemeRegional
+	case r >= 0x1100 && r <= 0x115f, r >= 0xa960 && r <= 0xa97c:
+		return graphemeL
+	case r >= 0x1160 && r <= 0x11a7, r >= 0xd7b0 && r <= 0xd7c6:
+		return graphemeV
+	case r >= 0x11a8 && r <= 0x11ff, r >= 0xd7cb && r <= 0xd7fb:
+		return graphemeT
+	case r >= 0xac00 && r <= 0xd7a3:
+		if (r-0xac00)%28 == 0 {
+			return graphemeLV
+		}
+
+		return graphemeLVT
+	}
+
+	return graphemeOther
+}
+
+// Reports whether a rune is a virama that links consonants into a conjunct
+// in one of the Indic scripts whose conjuncts are single grapheme clusters:
+// Devanagari, Bengali, Gujarati, Oriya, Telugu, or Malayalam.
+func isConjunctLinker(r rune) bool {
+	switch r {
+	case 0x094d, 0x09cd, 0x0acd, 0x0b4d, 0x0c4d, 0x0d4d:
+		return true
+	}
+
+	return false
+}
+
+// Reports whether a rune is a letter of one of the scripts listed for
+// isConjunctLinker(), and so can be linked into a conjunct.
+func isConjunctConsonant(r rune) bool {
+	return (r >= 0x0900 && r <= 0x09ff || r >= 0x0a80 && r <= 0x0b7f ||
+		r >= 0x0c00 && r <= 0x0c7f || r >= 0x0d00 && r <= 0x0d7f) &&
+		unicode.Is(unicode.Lo, r)
+}
+
+// Splits a UTF-8 string into its extended grapheme clusters, the units
+// that a reader perceives as single characters, per Unicode Standard Annex
+// #29.  So a letter with combining accents is one cluster, as are an emoji
+// with a skin tone modifier, a sequence of emoji joined by zero width
+// joiners, a flag made of two regional indicators, a Hangul syllable
+// spelled with jamo, an Indic conjunct such as "क्ष", and "\r\n".
+func graphemeClusters(str string) []string {
+	var slcClusters []string
+	var propPrev graphemeProperty
+	iStart := 0         // Where the current cluster starts
+	iRegional := 0      // Regional indicators in a row, through the last rune
+	bPictogram := false // Whether a pictograph, then Extends or a ZWJ, ends it
+	iConjunct := 0      // 1 after a consonant, 2 once it's linked, else 0
+
+	for i, r := range str {
+		prop := graphemePropertyOf(r)
+		bBreak := true
+
+		switch {
+		case propPrev == graphemeCR && prop == graphemeLF:
+			bBreak = false
+		case propPrev == graphemeCR || propPrev == graphemeLF ||
+			propPrev == graphemeControl || prop == graphemeCR ||
+			prop == graphemeLF || prop == graphemeControl:
+		case propPrev == graphemeL && (prop == graphemeL ||
+			prop == graphemeV || prop == graphemeLV || prop == graphemeLVT),
+			(propPrev == graphemeLV || propPrev == graphemeV) &&
+				(prop == graphemeV || prop == graphemeT),
+			(propPrev == graphemeLVT || propPrev == graphemeT) &&
+				prop == graphemeT:
+			bBreak = false
+		case prop == graphemeExtend || prop == graphemeZWJ ||
+			prop == graphemeSpacingMark:
+			bBreak = false
+		case iConjunct == 2 && isConjunctConsonant(r),
+			bPictogram && propPrev == graphemeZWJ &&
+				unicode.Is(rangeTablePictographic, r),
+			prop == graphemeRegional && iRegional%2 == 1:
+			bBreak = false
+		}
+
+		if bBreak && i > 0 {
+			slcClusters = append(slcClusters, str[iStart:i])
+			iStart = i
+		}
+
+		if prop == graphemeRegional {
+			iRegional++
+		} else {
+			iRegional = 0
+		}
+
+		if unicode.Is(rangeTablePictographic, r) {
+			bPictogram = true
+		} else if prop != graphemeExtend && prop != graphemeZWJ {
+			bPictogram = false
+		}
+
+		if isConjunctConsonant(r) {
+			iConjunct = 1
+		} else if iConjunct > 0 && isConjunctLinker(r) {
+			iConjunct = 2
+		} else if prop != graphemeExtend && prop != graphemeZWJ {
+			iConjunct = 0
+		}
+
+		propPrev = prop
+	}
+
+	if iStart < len(str) {
+		slcClusters = append(slcClusters, str[iStart:])
+	}
+
+	return slcClusters
+}
+
+// Compares two UTF-8 strings grapheme cluster by grapheme cluster, so that
+// a '?' matches exactly one user-perceived character, however many runes
+// encode it, and a '*' matches any run of whole clusters.  So "?" matches
+// a family emoji made of several emoji joined by zero width joiners, a
+// thumbs-up with a skin tone, a flag made of two regional indicators, an
+// accented letter spelled with a combining accent, or the Devanagari
+// conjunct "क्ष".  Clusters are found as described for
+// graphemeClusters().
+//
+// Literals are compared a whole cluster at a time, so "*👩*" doesn't match
+// a family emoji that merely includes a woman, and "e" doesn't match "é",
+// however it's spelled.  A '*' or '?' that a combining mark follows forms
+// a cluster along with it, and is a literal.  Bracket classes aren't
+// supported, so a '[' is always a literal.
+func FastWildCompareGraphemes(strWild, strTame string) bool {
+	slcWild := graphemeClusters(strWild)
+
+	// Mark each '*' in the form that matchSegments() takes as matching
+	// any run of segments.  No cluster of the pattern is otherwise "**".
+	for i, strCluster := range slcWild {
+		if strCluster == "*" {
+			slcWild[i] = "**"
+		}
+	}
+
+	return matchSegments(slcWild, graphemeClusters(strTame),
+		func(strWild, strTame string) bool {
+			return strWild == "?" || strWild == strTame
+		})
+}
@@ -0,0 +1,101 @@
+// Go tests for matching wildcards grapheme cluster by grapheme cluster.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import "testing"
+
+// Tests for FastWildCompareGraphemes(), where a '?' matches one grapheme
+// cluster, with emoji sequences and Devanagari conjuncts.
+func TestGraphemes(t *testing.T) {
+	strFamily := "👨\u200d👩\u200d👧"
+
+	for _, testCase := range []struct {
+		strWild   string
+		strTame   string
+		bExpected bool
+	}{
+		// Emoji joined by zero width joiners make one cluster.
+		{"?", strFamily, true},
+		{"??", strFamily, false},
+		{"? ?", strFamily + " " + strFamily, true},
+		{"*👩*", strFamily, false},
+		{"*" + strFamily, "a" + strFamily, true},
+		{"?", "🏳\ufe0f\u200d🌈", true},
+		{"?", "❤\ufe0f", true},
+
+		// Flags, each a pair of regional indicators.
+		{"?", "🇯🇵", true},
+		{"??", "🇯🇵🇺🇸", true},
+		{"?", "🇯🇵🇺🇸", false},
+		{"🇯🇵*", "🇯🇵🇺🇸", true},
+		{"*🇵🇺*", "🇯🇵🇺🇸", false},
+		{"???", "🇯🇵🇺🇸🇫", true},
+		{"?", "🏴\U000e0067\U000e0062\U000e0073\U000e0063" +
+			"\U000e0074\U000e007f", true},
+
+		// Skin tone modifiers.
+		{"?", "👍🏽", true},
+		{"👍?", "👍🏽", false},
+		{"?? ok", "👍🏽👍🏿 ok", true},
+		{"?", "👩🏾\u200d💻", true},
+
+		// Devanagari, with vowel signs, a nukta, and conjuncts of consonants
+		// linked by a virama.
+		{"???", "नमस्ते", true},
+		{"नम?", "नमस्ते", true},
+		{"??", "नमस्ते", false},
+		{"?", "क्ष", true},
+		{"???", "क्षत्रिय", true},
+		{"?", "कि", true},
+		{"हि?", "हिन्दी", true},
+		{"ह*", "हिन्दी", false},
+		{"?", "हिन्दी", false},
+		{"?", "ज़", true},
+
+		// Combining accents, Hangul jamo, and CR LF.  A '*' with a combining
+		// accent is a literal.
+		{"caf?", "cafe\u0301", true},
+		{"cafe*", "cafe\u0301", false},
+		{"?", "\u1100\u1161\u11a8", true},
+		{"a?b", "a\r\nb", true},
+		{"?*\u0301", "a*\u0301", true},
+		{"*\u0301", "a*\u0301", false},
+		{"*\u0301", "*\u0301", true},
+	} {
+		if FastWildCompareGraphemes(testCase.strWild,
+			testCase.strTame) != testCase.bExpected {
+			t.Errorf("FastWildCompareGraphemes(%q, %q) = %t, want %t",
+				testCase.strWild, testCase.strTame, !testCase.bExpected,
+				testCase.bExpected)
+		}
+	}
+
+	// Where each rune is its own cluster, the results are those of
+	// FastWildCompareRuneSlices().
+	for _, strWild := range allStrings([]string{"*", "?", "a", "♥"},
+		4) {
+		for _, strTame := range allStrings([]string{"a", "♥"}, 5) {
+			bExpected := FastWildCompareRuneSlices([]rune(strWild),
+				[]rune(strTame))
+
+			if FastWildCompareGraphemes(strWild, strTame) != bExpected {
+				t.Errorf("FastWildCompareGraphemes(%q, %q) = %t, want %t",
+					strWild, strTame, !bExpected, bExpected)
+			}
+		}
+	}
+}
@@ -0,0 +1,58 @@
+// Go interface for holding matching strategies interchangeably.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+// A pattern bound to a matching strategy, for callers that hold patterns
+// of several kinds, such as the rules of a rules engine, and evaluate them
+// uniformly.
+type Matcher interface {
+	// Reports whether a tame string matches the pattern.
+	Match(strTame string) bool
+}
+
+// A function serving as a Matcher, so that any comparison, such as one
+// returned by BestMatcher(), can be held alongside the Matchers returned
+// by the constructors here.
+type MatcherFunc func(strTame string) bool
+
+// Calls the function.
+func (fn MatcherFunc) Match(strTame string) bool {
+	return fn(strTame)
+}
+
+// Returns a Matcher for an ASCII pattern, with the same results as
+// FastWildCompareAscii(), via the implementation that BestMatcher()
+// chooses for the pattern.
+func NewAsciiMatcher(strWild string) Matcher {
+	return MatcherFunc(BestMatcher(strWild))
+}
+
+// Returns a Matcher for a UTF-8 pattern, with the same results as
+// FastWildCompareRuneSlices() for the rune slices converted from the
+// pattern and the tame string, via FastWildCompareUtf8().
+func NewUtf8Matcher(strWild string) Matcher {
+	return MatcherFunc(func(strTame string) bool {
+		return FastWildCompareUtf8(strWild, strTame)
+	})
+}
+
+// Returns a Matcher for a UTF-8 pattern under simple Unicode case folding,
+// with the same results as FastWildCompareRuneSlicesFoldFast(), via a
+// pattern folded once by CompileFold().
+func NewFoldMatcher(strWild string) Matcher {
+	return MatcherFunc(CompileFold(strWild).MatchString)
+}
@@ -0,0 +1,88 @@
+// Go tests for the Matcher interface and its implementations.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import "testing"
+
+// Tests for the Matcher interface, via a slice of matchers of different
+// kinds, evaluated uniformly against the same tame strings.
+func TestMatchers(t *testing.T) {
+	slcMatchers := []Matcher{
+		NewAsciiMatcher("*.log"),
+		NewAsciiMatcher("err[0-9]*"),
+		NewUtf8Matcher("?.log"),
+		NewFoldMatcher("ÉTÉ*"),
+		MatcherFunc(func(strTame string) bool {
+			return len(strTame) > 8
+		}),
+	}
+
+	// Which of the matchers match each tame string, in order.  The last
+	// matcher counts bytes, not runes.
+	mapExpected := map[string][]bool{
+		"a.log":       {true, false, true, false, false},
+		"é.log":       {true, false, true, false, false},
+		"err7.log":    {true, true, false, false, false},
+		"été.log":     {true, false, false, true, true},
+		"Été 2025":    {false, false, false, true, true},
+		"error.txt":   {false, false, false, false, true},
+		"err42 ÉTÉ":   {false, true, false, false, true},
+		"":            {false, false, false, false, false},
+		"étéerr1.log": {true, false, false, true, true},
+	}
+
+	for strTame, slcExpected := range mapExpected {
+		for i, matcher := range slcMatchers {
+			if matcher.Match(strTame) != slcExpected[i] {
+				t.Errorf("matcher %d: Match(%q) = %t, want %t", i, strTame,
+					!slcExpected[i], slcExpected[i])
+			}
+		}
+	}
+
+	// For each short pair, each constructor's Matcher agrees with the
+	// routine it stands in for.
+	for _, strWild := range allStrings([]string{"*", "?", "a", "É",
+		"[!a]"}, 4) {
+		matcherAscii := NewAsciiMatcher(strWild)
+		matcherUtf8 := NewUtf8Matcher(strWild)
+		matcherFold := NewFoldMatcher(strWild)
+
+		for _, strTame := range allStrings([]string{"a", "é", "É"}, 4) {
+			rslcWild := []rune(strWild)
+			rslcTame := []rune(strTame)
+
+			if matcherAscii.Match(strTame) !=
+				FastWildCompareAscii(strWild, strTame) {
+				t.Errorf("NewAsciiMatcher(%q).Match(%q) disagrees",
+					strWild, strTame)
+			}
+
+			if matcherUtf8.Match(strTame) !=
+				FastWildCompareRuneSlices(rslcWild, rslcTame) {
+				t.Errorf("NewUtf8Matcher(%q).Match(%q) disagrees",
+					strWild, strTame)
+			}
+
+			if matcherFold.Match(strTame) !=
+				FastWildCompareRuneSlicesFoldFast(rslcWild, rslcTame) {
+				t.Errorf("NewFoldMatcher(%q).Match(%q) disagrees",
+					strWild, strTame)
+			}
+		}
+	}
+}
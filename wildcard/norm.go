@@ -0,0 +1,36 @@
+// Go routine for matching wildcards in Unicode-normalized text.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import "golang.org/x/text/unicode/norm"
+
+// Compares two rune slices as FastWildCompareRuneSlices() does, after
+// bringing both to the same Unicode normalization form, so that
+// canonically equivalent text matches however it's encoded.  So with
+// norm.NFC or norm.NFD, "café" written with the precomposed 'é' matches
+// "café" written as 'e' followed by a combining acute accent.  The
+// wildcards are unaffected by normalization, and a '?' matches one rune of
+// the normalized tame content.  So "caf?" matches either spelling of
+// "café" under norm.NFC, where 'é' is one rune, but neither under
+// norm.NFD, where it's two.  The compatibility forms, norm.NFKC and
+// norm.NFKD, further let "ﬁ*" match "file", since the ligature 'ﬁ'
+// decomposes to "fi".
+func FastWildCompareRuneSlicesNormalized(rslcWild, rslcTame []rune,
+	form norm.Form) bool {
+	return FastWildCompareRuneSlices([]rune(form.String(string(rslcWild))),
+		[]rune(form.String(string(rslcTame))))
+}
@@ -0,0 +1,109 @@
+// Go tests for matching wildcards in Unicode-normalized text.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import (
+	"testing"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Tests for FastWildCompareRuneSlicesNormalized(), with precomposed and
+// decomposed accented Latin text.
+func TestNormalized(t *testing.T) {
+	bMatches := func(strWild, strTame string, form norm.Form) bool {
+		return FastWildCompareRuneSlicesNormalized([]rune(strWild),
+			[]rune(strTame), form)
+	}
+	strComposed := "caf\u00e9 cr\u00e8me br\u00fbl\u00e9e"
+	strDecomposed := "cafe\u0301 cre\u0300me bru\u0302le\u0301e"
+
+	// Without normalization, the two spellings don't match each other.
+	if FastWildCompareRuneSlices([]rune(strComposed),
+		[]rune(strDecomposed)) {
+		t.Errorf("FastWildCompareRuneSlices(%q, %q) = true, want false",
+			strComposed, strDecomposed)
+	}
+
+	// With it, either spelling matches the other, in either form.
+	for _, form := range []norm.Form{norm.NFC, norm.NFD, norm.NFKC,
+		norm.NFKD} {
+		for _, testCase := range []struct {
+			strWild   string
+			strTame   string
+			bExpected bool
+		}{
+			{strComposed, strDecomposed, true},
+			{strDecomposed, strComposed, true},
+			{"*cr\u00e8me*", strDecomposed, true},
+			{"*cre\u0300me*", strComposed, true},
+			{"*creme*", strComposed, false},
+			{"Z\u00fcrich", "Zu\u0308rich", true},
+		} {
+			if bMatches(testCase.strWild, testCase.strTame,
+				form) != testCase.bExpected {
+				t.Errorf("FastWildCompareRuneSlicesNormalized(%q, %q, %d) "+
+					"= %t, want %t", testCase.strWild, testCase.strTame,
+					form, !testCase.bExpected, testCase.bExpected)
+			}
+		}
+	}
+
+	// A '?' matches one rune of the normalized content, so an accented
+	// letter takes one '?' when composed, and two when decomposed.  The
+	// compatibility forms also fold compatibility characters.
+	for _, testCase := range []struct {
+		strWild   string
+		strTame   string
+		form      norm.Form
+		bExpected bool
+	}{
+		{"caf?", "cafe\u0301", norm.NFC, true},
+		{"caf?", "caf\u00e9", norm.NFC, true},
+		{"caf?", "cafe\u0301", norm.NFD, false},
+		{"caf?", "caf\u00e9", norm.NFD, false},
+		{"caf??", "caf\u00e9", norm.NFD, true},
+		{"caf?\u0301", "caf\u00e9", norm.NFD, true},
+		{"caf??", "cafe\u0301", norm.NFC, false},
+		{"\ufb01*", "file", norm.NFKC, true},
+		{"\ufb01*", "file", norm.NFC, false},
+		{"x?", "x\u00b2", norm.NFKD, true},
+	} {
+		if bMatches(testCase.strWild, testCase.strTame,
+			testCase.form) != testCase.bExpected {
+			t.Errorf("FastWildCompareRuneSlicesNormalized(%q, %q, %d) = "+
+				"%t, want %t", testCase.strWild, testCase.strTame,
+				testCase.form, !testCase.bExpected, testCase.bExpected)
+		}
+	}
+
+	// Content that's normalized already matches as it does without
+	// normalization.
+	for _, strWild := range allStrings([]string{"*", "?", "a", "\u00e9"},
+		4) {
+		for _, strTame := range allStrings([]string{"a", "\u00e9"}, 5) {
+			bExpected := FastWildCompareRuneSlices([]rune(strWild),
+				[]rune(strTame))
+
+			if bMatches(strWild, strTame, norm.NFC) != bExpected {
+				t.Errorf("FastWildCompareRuneSlicesNormalized(%q, %q, "+
+					"NFC) = %t, want %t", strWild, strTame, !bExpected,
+					bExpected)
+			}
+		}
+	}
+}
@@ -0,0 +1,261 @@
+// Go routines for matching wildcards with optional behaviors.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+var (
+	// The error reported by MatchWithOptions() for a comparison that gave
+	// up after taking more steps than Options.MaxSteps allows.
+	ErrStepLimit = errors.New("comparison exceeded its step limit")
+
+	// The error reported by MatchWithOptions() for an Options.Separator
+	// that isn't an ASCII character.
+	ErrNonAsciiSeparator = errors.New("separator isn't an ASCII character")
+
+	// The error reported by the Options methods that read a stream, once
+	// they would keep more of it than Options.MaxBuffer allows.
+	ErrBufferLimit = errors.New("stream content exceeded the buffer limit")
+)
+
+// Optional matching behaviors for MatchWithOptions().  The zero value
+// selects none of them, for the same results as FastWildCompareAscii().
+type Options struct {
+	// Treats each run of identical characters as a single character, as
+	// for matching human-typed emphasis such as "sooo goood" against the
+	// pattern "so good".  Runs are collapsed in the tame string and among
+	// the pattern's literals alike, before matching.  So a '?' matches one
+	// run of identical tame characters, and a '*' matches any number of
+	// runs.  Runs of '?' in the pattern are left as they are.
+	CollapseRepeats bool
+
+	// The placeholder byte for MatchSingleCapture().  A zero value selects
+	// '*'.
+	SingleCaptureByte byte
+
+	// The separator that '*', '?', and bracket classes never match, for
+	// Options.MatchPath().  A zero value selects '/'.  A '\\' selects
+	// Windows-style paths, where a backslash is a separator rather than an
+	// escape, as for filepath.Match() on Windows.
+	PathSeparator byte
+
+	// Matches each ASCII letter regardless of case, as for
+	// FastWildCompareAsciiFold().
+	CaseInsensitive bool
+
+	// Accepts backslash escapes in the pattern, as for
+	// FastWildCompareAsciiEscaped().
+	Escape bool
+
+	// A character that '*', '?', and bracket classes never match, so that
+	// only a literal in the pattern can match it.  A class made of just
+	// the separator is a literal in this sense.  A zero value selects no
+	// separator.  Unlike for Options.MatchPath(), "**" is just a '*'.
+	Separator rune
+
+	// The most steps a comparison may take before MatchWithOptions() gives
+	// up on it.  A zero or negative value sets no limit.
+	MaxSteps int
+
+	// The most bytes of a stream that Options.MatchReader(),
+	// Options.ScanMatches(), Options.WindowMatch(), and
+	// Options.FastWildCompareGzip() may keep at once, to compare against
+	// a pattern, so that a server reading untrusted streams can bound its
+	// memory.  Once they'd keep more, they return ErrBufferLimit.  A zero
+	// or negative value, the default, sets no limit.
+	MaxBuffer int
+}
+
+// Compares an ASCII pattern against a tame string, with the behaviors
+// selected by opt, so that callers can combine them in one call.  They're
+// applied in this order:
+//
+//   - CollapseRepeats rewrites both strings, before anything else.  With
+//     Escape, each backslash escape in the pattern counts as one
+//     character, so that "a\\*" keeps its '*' wildcard, rather than
+//     becoming "a\*".
+//   - Escape decides which pattern bytes are wildcards or class syntax.
+//   - Separator is withheld from each '*', '?', and bracket class.
+//   - CaseInsensitive then compares the strings as if both were
+//     lowercased, so a letter separator is never matched in either case.
+//   - MaxSteps caps the work of the comparison itself.
+//
+// With none of CaseInsensitive, Escape, and Separator, the results are
+// those of FastWildCompareAscii().  Either way, steps are counted as for
+// FastWildCompareAsciiLimit().
+//
+// The error result is ErrStepLimit, along with false, for a comparison
+// that took more than MaxSteps steps, or ErrNonAsciiSeparator for a
+// Separator that isn't ASCII.  Otherwise it's nil.
+func MatchWithOptions(strWild, strTame string, opt Options) (bool, error) {
+	if opt.CollapseRepeats {
+		if opt.Escape {
+			strWild = collapseRepeatsEscaped(strWild)
+		} else {
+			strWild = collapseRepeats(strWild, '?')
+		}
+
+		strTame = collapseRepeats(strTame, 0)
+	}
+
+	if opt.Separator < 0 || opt.Separator > unicode.MaxASCII {
+		return false, ErrNonAsciiSeparator
+	}
+
+	iMaxSteps := -1
+
+	if opt.MaxSteps > 0 {
+		iMaxSteps = opt.MaxSteps
+	}
+
+	if !opt.CaseInsensitive && !opt.Escape && opt.Separator == 0 {
+		if iMaxSteps < 0 {
+			return FastWildCompareAscii(strWild, strTame), nil
+		}
+
+		bMatch, bGaveUp := FastWildCompareAsciiLimit(strWild, strTame,
+			iMaxSteps)
+
+		if bGaveUp {
+			return false, ErrStepLimit
+		}
+
+		return bMatch, nil
+	}
+
+	cSeparator := byte(opt.Separator)
+
+	if opt.CaseInsensitive {
+		strWild = lowerAsciiString(strWild)
+		cSeparator = lowerAscii(cSeparator)
+	}
+
+	slcTokens, _ := compileClassTokens(strWild, opt.Escape)
+
+	for i := range slcTokens {
+		set := &slcTokens[i].set
+
+		if opt.Separator != 0 && set.count() > 1 {
+			set.remove(cSeparator)
+		}
+
+		if opt.CaseInsensitive {
+			set.mirrorLowercase()
+		}
+	}
+
+	bMatch, iRemaining := matchClassTokensBudget(slcTokens, strTame,
+		iMaxSteps, iMaxSteps >= 0)
+
+	if iMaxSteps >= 0 && iRemaining < 0 {
+		return false, ErrStepLimit
+	}
+
+	return bMatch, nil
+}
+
+// Returns a copy of a string with each run of identical bytes, other than
+// cKeep, reduced to one byte.  A cKeep of 0 reduces runs of every byte,
+// including runs of 0.
+func collapseRepeats(str string, cKeep byte) string {
+	var sb strings.Builder
+
+	for i := 0; i < len(str); i++ {
+		if i == 0 || str[i] != str[i-1] || (cKeep != 0 && str[i] == cKeep) {
+			sb.WriteByte(str[i])
+		}
+	}
+
+	return sb.String()
+}
+
+// Returns a copy of a pattern with each run of identical characters, other
+// than '?', reduced to one character, where a backslash and the byte after
+// it are one character.  An escaped byte that needs no escaping is the
+// same character as the byte itself, so "a\a" becomes "a", while "*\*" is
+// left as it is.
+func collapseRepeatsEscaped(strWild string) string {
+	var sb strings.Builder
+	strLast := ""
+
+	for i := 0; i < len(strWild); i++ {
+		strChar := strWild[i : i+1]
+
+		if strWild[i] == '\\' && i+1 < len(strWild) {
+			strChar = strWild[i : i+2]
+			i++
+		}
+
+		strKey := strChar
+
+		if len(strChar) == 2 && strings.IndexByte(`*?[]\`, strChar[1]) < 0 {
+			strKey = strChar[1:]
+		}
+
+		if strKey != strLast || strKey == "?" {
+			sb.WriteString(strChar)
+		}
+
+		strLast = strKey
+	}
+
+	return sb.String()
+}
+
+// Matches a pattern made of a literal prefix, one placeholder, and a
+// literal suffix, returning the tame content that the placeholder
+// captured.  The placeholder is '*' unless opt.SingleCaptureByte says
+// otherwise, and it matches any run of characters, including an empty
+// run.  Since the prefix and suffix are literal, with no wildcards, the
+// capture is found by just comparing them against both ends of the tame
+// string.
+//
+// Returns "", false if the tame string doesn't match, or if the pattern
+// doesn't contain the placeholder exactly once.
+func (opt Options) MatchSingleCapture(strWild, strTame string) (string, bool) {
+	cCapture := opt.SingleCaptureByte
+
+	if cCapture == 0 {
+		cCapture = '*'
+	}
+
+	iCapture := strings.IndexByte(strWild, cCapture)
+
+	if iCapture < 0 || strings.IndexByte(strWild[iCapture+1:], cCapture) >= 0 {
+		return "", false
+	}
+
+	strPrefix := strWild[:iCapture]
+	strSuffix := strWild[iCapture+1:]
+
+	if len(strTame) < len(strPrefix)+len(strSuffix) ||
+		!strings.HasPrefix(strTame, strPrefix) ||
+		!strings.HasSuffix(strTame, strSuffix) {
+		return "", false
+	}
+
+	return strTame[len(strPrefix) : len(strTame)-len(strSuffix)], true
+}
+
+// Calls Options.MatchSingleCapture() with '*' as the placeholder.
+func MatchSingleCapture(strWild, strTame string) (string, bool) {
+	return Options{}.MatchSingleCapture(strWild, strTame)
+}
@@ -0,0 +1,285 @@
+// Go tests for the optional matching behaviors.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import (
+	"bytes"
+	"compress/gzip"
+	"math"
+	"strings"
+	"testing"
+)
+
+// Each routine that reads a stream returns ErrBufferLimit for a stream
+// crafted to make it keep more than Options.MaxBuffer bytes, and gets its
+// usual result for streams that fit.
+func TestMaxBuffer(t *testing.T) {
+	opt := Options{MaxBuffer: 64}
+	strLong := strings.Repeat("a", 1000)
+	strSegment := strings.Repeat("b", 100)
+
+	for _, testCase := range []struct {
+		strWild string
+		strTame string
+		bMatch  bool
+		errWant error
+	}{
+		{"*b", strLong, false, nil},
+		{"a*" + strSegment + "*b", strLong, false, ErrBufferLimit},
+		{"*" + strSegment, strLong, false, ErrBufferLimit},
+		{strings.Repeat("?", 100) + "*", strLong, true, nil},
+		{"*[b]", strLong, false, ErrBufferLimit},
+		{"*[a]", strLong[:64], true, nil},
+		{"a*b", strLong + "b", true, nil},
+	} {
+		bMatch, err := opt.MatchReader(testCase.strWild,
+			strings.NewReader(testCase.strTame))
+
+		if bMatch != testCase.bMatch || err != testCase.errWant {
+			t.Errorf("MatchReader(%q) against %d bytes = %v, %v; want "+
+				"%v, %v", testCase.strWild, len(testCase.strTame), bMatch,
+				err, testCase.bMatch, testCase.errWant)
+		}
+	}
+
+	// A line longer than the buffer, after one that fits.
+	var slcLines []string
+	err := opt.ScanMatches("a*", strings.NewReader("abc\n"+strLong+"\n"),
+		func(strLine string) bool {
+			slcLines = append(slcLines, strLine)
+			return true
+		})
+
+	if err != ErrBufferLimit || len(slcLines) != 1 {
+		t.Errorf("ScanMatches() = %v, after %d lines; want %v, after 1",
+			err, len(slcLines), ErrBufferLimit)
+	}
+
+	if err := opt.ScanMatches("a*", strings.NewReader(strLong[:60]+"\n"),
+		func(string) bool { return true }); err != nil {
+		t.Errorf("ScanMatches() = %v for a line that fits", err)
+	}
+
+	// A stream that compresses well, but decompresses to too much.
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write([]byte(strLong))
+	zw.Close()
+
+	if bMatch, err := opt.FastWildCompareGzip("a*",
+		bytes.NewReader(buf.Bytes())); bMatch || err != ErrBufferLimit {
+		t.Errorf("FastWildCompareGzip() = %v, %v; want false, %v", bMatch,
+			err, ErrBufferLimit)
+	}
+
+	if bMatch, err := (Options{MaxBuffer: 1000}).FastWildCompareGzip("a*",
+		bytes.NewReader(buf.Bytes())); !bMatch || err != nil {
+		t.Errorf("FastWildCompareGzip() = %v, %v; want true, nil", bMatch,
+			err)
+	}
+
+	// WindowMatch() keeps no stream content, whatever the window.
+	if bMatch, err := opt.WindowMatch("b*c", strings.NewReader(strLong+
+		"b"+strLong+"c"), 2000); !bMatch || err != nil {
+		t.Errorf("WindowMatch() = %v, %v; want true, nil", bMatch, err)
+	}
+}
+
+// Tests for combinations of the options of MatchWithOptions().
+func TestMatchOptions(t *testing.T) {
+	optFold := Options{CaseInsensitive: true}
+	optEscape := Options{Escape: true}
+	optFoldEscape := Options{CaseInsensitive: true, Escape: true}
+	optSlash := Options{Separator: '/'}
+	optAll := Options{CaseInsensitive: true, Escape: true, Separator: '/'}
+	optCollapseEscape := Options{CollapseRepeats: true, Escape: true}
+
+	for _, testCase := range []struct {
+		strWild   string
+		strTame   string
+		opt       Options
+		bExpected bool
+	}{
+		// Each option alone.
+		{"*.TXT", "notes.txt", optFold, true},
+		{"*.TXT", "notes.txt", Options{}, false},
+		{"why\\?", "why?", optEscape, true},
+		{"why\\?", "why!", optEscape, false},
+		{"src/*.go", "src/main.go", optSlash, true},
+		{"src/*.go", "src/cmd/main.go", optSlash, false},
+		{"a?b", "a/b", optSlash, false},
+		{"a[!x]b", "a/b", optSlash, false},
+		{"a[/]b", "a/b", optSlash, true},
+		{"**/x", "a/x", optSlash, true},
+		{"**/x", "a/b/x", optSlash, false},
+
+		// Case-insensitive matching with escaping.
+		{"WHY\\?", "why?", optFoldEscape, true},
+		{"WHY\\?", "whyx", optFoldEscape, false},
+		{"\\*NEW\\* *", "*new* item", optFoldEscape, true},
+		{"[\\]A-C]*", "]", optFoldEscape, true},
+		{"[\\]A-C]*", "bx", optFoldEscape, true},
+		{"[!\\]X]", "x", optFoldEscape, false},
+		{"[!\\]X]", "]", optFoldEscape, false},
+
+		// A separator with case-insensitive matching and escaping.
+		{"SRC/\\**.GO", "src/*main.go", optAll, true},
+		{"SRC/\\**.GO", "src/*cmd/x.go", optAll, false},
+		{"a*b", "aXb", Options{CaseInsensitive: true, Separator: 'x'},
+			false},
+		{"aXb", "axb", Options{CaseInsensitive: true, Separator: 'x'},
+			true},
+
+		// Collapsing repeats comes first.  With escaping, an escape is one
+		// character, whether or not it's followed by a wildcard.
+		{"SO GOOD", "sooo goood",
+			Options{CaseInsensitive: true, CollapseRepeats: true}, true},
+		{`a\\*`, `a\\xyz`, optCollapseEscape, true},
+		{`a\\*`, `a*`, optCollapseEscape, false},
+		{`\*\**`, "**x", optCollapseEscape, true},
+		{`\*\**`, "x", optCollapseEscape, false},
+		{`a\a?`, "aaab", optCollapseEscape, true},
+		{`why\??`, "why??!", optCollapseEscape, true},
+	} {
+		if bMatch, err := MatchWithOptions(testCase.strWild,
+			testCase.strTame, testCase.opt); bMatch != testCase.bExpected ||
+			err != nil {
+			t.Errorf("MatchWithOptions(%q, %q, %+v) = %t, %v; want %t, nil",
+				testCase.strWild, testCase.strTame, testCase.opt, bMatch,
+				err, testCase.bExpected)
+		}
+	}
+
+	// A step limit gives up on a pathological comparison, with or without
+	// other options, while letting an ordinary one finish.
+	strTame := strings.Repeat("a", 200)
+	strWild := "*" + strings.Repeat("a", 20) + "b"
+
+	for _, opt := range []Options{{}, optFold, optEscape, optSlash} {
+		opt.MaxSteps = 1000
+
+		if bMatch, err := MatchWithOptions(strWild, strTame,
+			opt); bMatch || err != ErrStepLimit {
+			t.Errorf("MatchWithOptions(%q, %+v) = %t, %v; want false, %v",
+				strWild, opt, bMatch, err, ErrStepLimit)
+		}
+
+		if bMatch, err := MatchWithOptions("a*", strTame, opt); !bMatch ||
+			err != nil {
+			t.Errorf("MatchWithOptions(%q, %+v) = %t, %v; want true, nil",
+				"a*", opt, bMatch, err)
+		}
+
+		opt.MaxSteps = 0
+
+		if bMatch, err := MatchWithOptions(strWild, strTame,
+			opt); bMatch || err != nil {
+			t.Errorf("MatchWithOptions(%q, %+v) = %t, %v; want false, nil",
+				strWild, opt, bMatch, err)
+		}
+	}
+
+	// A separator must be ASCII.
+	if _, err := MatchWithOptions("*", "a",
+		Options{Separator: '\u00e9'}); err != ErrNonAsciiSeparator {
+		t.Errorf("MatchWithOptions() with a separator of '\u00e9' = %v, "+
+			"want %v", err, ErrNonAsciiSeparator)
+	}
+
+	// Each option alone gets the results of the routine that provides it.
+	for _, strWild := range allStrings([]string{"*", "?", "a", "B",
+		"\\", "[!a]"}, 4) {
+		for _, strTame := range allStrings([]string{"a", "b", "A", "?",
+			"\\"}, 4) {
+			for _, testCase := range []struct {
+				opt       Options
+				bExpected bool
+			}{
+				{optFold, FastWildCompareAsciiFold(strWild, strTame)},
+				{optEscape, FastWildCompareAsciiEscaped(strWild, strTame)},
+				{Options{MaxSteps: math.MaxInt},
+					FastWildCompareAscii(strWild, strTame)},
+			} {
+				if bMatch, err := MatchWithOptions(strWild, strTame,
+					testCase.opt); bMatch != testCase.bExpected ||
+					err != nil {
+					t.Errorf("MatchWithOptions(%q, %q, %+v) = %t, %v; "+
+						"want %t, nil", strWild, strTame, testCase.opt,
+						bMatch, err, testCase.bExpected)
+				}
+			}
+		}
+	}
+}
+
+// Tests for the CollapseRepeats option of MatchWithOptions().
+func TestCollapseRepeats(t *testing.T) {
+	for _, testCase := range []struct {
+		strWild   string
+		strTame   string
+		bCollapse bool
+		bExpected bool
+	}{
+		// Collapsing repeats turns these non-matches into matches.
+		{"so good", "sooo goood", false, false},
+		{"so good", "sooo goood", true, true},
+		{"yes!", "yesss!!!", false, false},
+		{"yes!", "yesss!!!", true, true},
+		{"no*way", "nooooo wayyy", false, false},
+		{"no*way", "nooooo wayyy", true, true},
+		{"mississippi", "misisipi", true, true},
+		{"misisipi", "mississippi", true, true},
+
+		// A '?' matches one run of identical characters.
+		{"w?w", "woooow", true, true},
+		{"w?w", "woooow", false, false},
+		{"w??w", "woooow", true, false},
+		{"w??w", "wooaaw", true, true},
+		{"*aa?", "aaaaab", true, true},
+
+		// A '*' matches any number of runs.
+		{"h*!", "heeey!!", true, true},
+		{"*", "", true, true},
+
+		// Runs of different characters still have to match.
+		{"so good", "so bad", true, false},
+		{"abc", "abbd", true, false},
+	} {
+		opt := Options{CollapseRepeats: testCase.bCollapse}
+
+		if bMatch, err := MatchWithOptions(testCase.strWild,
+			testCase.strTame, opt); bMatch != testCase.bExpected ||
+			err != nil {
+			t.Errorf("MatchWithOptions(%q, %q) with CollapseRepeats: %t = "+
+				"%t, %v; want %t, nil", testCase.strWild, testCase.strTame,
+				testCase.bCollapse, bMatch, err, testCase.bExpected)
+		}
+	}
+
+	// Without the option, results are those of FastWildCompareAscii().
+	for _, strWild := range allStrings([]string{"*", "?", "a", "b"}, 4) {
+		for _, strTame := range allStrings([]string{"a", "b"}, 5) {
+			bExpected := FastWildCompareAscii(strWild, strTame)
+
+			if bMatch, err := MatchWithOptions(strWild, strTame,
+				Options{}); bMatch != bExpected || err != nil {
+				t.Errorf("MatchWithOptions(%q, %q) = %t, %v; want %t, nil",
+					strWild, strTame, bMatch, err, bExpected)
+			}
+		}
+	}
+}
@@ -0,0 +1,366 @@
+// Go routines for matching wildcards by finding the runs between '*'s.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import "strings"
+
+// A run of literals and '?'s between '*'s in a compiled Pattern.
+type patternSegment struct {
+	strRun    string // The run, where each '?' matches any one byte
+	bQuestion bool   // Whether the run contains any '?'
+}
+
+// Reports whether the segment matches the tame string at offset i.
+func (segment *patternSegment) matchAt(strTame string, i int) bool {
+	if i < 0 || len(strTame)-i < len(segment.strRun) {
+		return false
+	} else if !segment.bQuestion {
+		return strTame[i:i+len(segment.strRun)] == segment.strRun
+	}
+
+	for j := 0; j < len(segment.strRun); j++ {
+		if segment.strRun[j] != '?' && segment.strRun[j] != strTame[i+j] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Returns the offset of the first match of the segment within the tame
+// string, or -1 if there's none.
+func (segment *patternSegment) indexIn(strTame string) int {
+	if !segment.bQuestion {
+		return strings.Index(strTame, segment.strRun)
+	}
+
+	for i := 0; i+len(segment.strRun) <= len(strTame); i++ {
+		if segment.matchAt(strTame, i) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Reports whether a tame string ends with content that a run of literals
+// and '?'s matches, as does a pattern made of a '*' followed by the run.
+// The run is compared from its last byte back, against the end of the tame
+// string, so however long the tame string is, only as many bytes are
+// compared as the run has, and a mismatch at the very end is found first.
+func matchTailReverse(strRun, strTame string) bool {
+	if len(strTame) < len(strRun) {
+		return false
+	}
+
+	iTame := len(strTame)
+
+	for iWild := len(strRun) - 1; iWild >= 0; iWild-- {
+		iTame--
+
+		if strRun[iWild] != '?' && strRun[iWild] != strTame[iTame] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// An ASCII pattern compiled by Compile() for matching against many tame
+// strings, as when filtering a log stream.
+//
+// The pattern is split at its '*'s into segments, each a run of literals
+// and '?'s, so the '*'s needn't be found again on each comparison.  The
+// first segment must match at the start of a tame string, and the last at
+// the end, while those in between need only be found, in order, in what
+// lies between.  Taking the first place each of those is found never rules
+// out a match that a later place would allow, so no segment is ever
+// retried.  A segment with no '?' is found via strings.Index().
+type Pattern struct {
+	strWild     string           // The pattern, normalized
+	slcSegments []patternSegment // The runs before, between, and after '*'s
+	iMinLen     int              // The shortest tame length that can match
+	slcTokens   []wildToken      // The pattern's tokens, if it has classes
+}
+
+// Compiles an ASCII pattern for repeated matching via MatchString(), with
+// the same results as FastWildCompareAscii().  A pattern with a malformed
+// bracket class, such as "[z-a]", is reported via ErrEmptyRange, as by
+// MatchValidated(), with the offset of the class in the pattern as given.
+// Every other pattern compiles.
+//
+// The pattern is first normalized, as reported by String(), so that each
+// run of '*'s and '?'s becomes its '?'s followed by at most one '*'.  So
+// "a***b" is compiled as "a*b", and "*?*?" as "??*".  Each run matches the
+// same content either way: any content at least as long as its '?'s, if
+// it has a '*', or otherwise exactly that long.
+func Compile(strWild string) (*Pattern, error) {
+	pattern := &Pattern{strWild: normalizeStars(strWild)}
+
+	// Bracket classes take more than one pattern character apiece, so
+	// patterns that have them are compiled to tokens, each matching one
+	// character, like a '?'.
+	if strings.IndexByte(pattern.strWild, '[') >= 0 {
+		slcTokens, err := compileClassTokens(pattern.strWild, false)
+
+		if err != nil {
+			// Report the offset of the class in the pattern as given.
+			_, err = compileClassTokens(strWild, false)
+			return nil, err
+		}
+
+		pattern.slcTokens = slcTokens
+		return pattern, nil
+	}
+
+	for _, strRun := range strings.Split(pattern.strWild, "*") {
+		pattern.slcSegments = append(pattern.slcSegments, patternSegment{
+			strRun:    strRun,
+			bQuestion: strings.IndexByte(strRun, '?') >= 0,
+		})
+		pattern.iMinLen += len(strRun)
+	}
+
+	return pattern, nil
+}
+
+// Returns a copy of an ASCII pattern with each run of '*'s and '?'s
+// rewritten as its '?'s followed by at most one '*', leaving bracket
+// classes as they are.  A pattern that has no such run to rewrite is
+// returned as is.
+func normalizeStars(strWild string) string {
+	if !strings.Contains(strWild, "**") && !strings.Contains(strWild, "*?") {
+		return strWild
+	}
+
+	var sb strings.Builder
+
+	for i := 0; i < len(strWild); {
+		if strWild[i] == '*' || strWild[i] == '?' {
+			iQuestions := 0
+			bStar := false
+
+			for ; i < len(strWild) &&
+				(strWild[i] == '*' || strWild[i] == '?'); i++ {
+				if strWild[i] == '?' {
+					iQuestions++
+				} else {
+					bStar = true
+				}
+			}
+
+			sb.WriteString(strings.Repeat("?", iQuestions))
+
+			if bStar {
+				sb.WriteByte('*')
+			}
+
+			continue
+		} else if strWild[i] == '[' {
+			// A '*' or '?' inside a class is just a member.
+			if _, iNext, _ := parseClass(strWild, i, false); iNext >= 0 {
+				sb.WriteString(strWild[i:iNext])
+				i = iNext
+				continue
+			}
+		}
+
+		sb.WriteByte(strWild[i])
+		i++
+	}
+
+	return sb.String()
+}
+
+// Returns the compiled pattern in its normalized form, as described for
+// Compile(), which matches the same tame strings as the pattern as given.
+func (pattern *Pattern) String() string {
+	return pattern.strWild
+}
+
+// Reports whether a tame string matches the compiled pattern.
+func (pattern *Pattern) MatchString(strTame string) bool {
+	if pattern.slcTokens != nil {
+		return matchClassTokens(pattern.slcTokens, strTame)
+	} else if len(strTame) < pattern.iMinLen {
+		return false
+	}
+
+	slcSegments := pattern.slcSegments
+	first := &slcSegments[0]
+
+	// A pattern without any '*' is a single segment, which must match the
+	// whole tame string.
+	if len(slcSegments) == 1 {
+		return len(strTame) == len(first.strRun) && first.matchAt(strTame, 0)
+	}
+
+	last := &slcSegments[len(slcSegments)-1]
+	iEnd := len(strTame) - len(last.strRun)
+
+	if !first.matchAt(strTame, 0) || !last.matchAt(strTame, iEnd) {
+		return false
+	}
+
+	// The tame length is at least iMinLen, so the first and last segments
+	// don't overlap, and the others are found in the content between them.
+	iTame := len(first.strRun)
+
+	for i := 1; i < len(slcSegments)-1; i++ {
+		iFound := slcSegments[i].indexIn(strTame[iTame:iEnd])
+
+		if iFound < 0 {
+			return false
+		}
+
+		iTame += iFound + len(slcSegments[i].strRun)
+	}
+
+	return true
+}
+
+// Reports whether an ASCII pattern matches any part of a tame string, as
+// FastWildCompareAscii() reports for the pattern with a '*' added at each
+// end, but without building that pattern.  So "bcd" matches "abcde",
+// though it matches only "bcd" itself when anchored.  A '?' still matches
+// exactly one character, so "b?d" matches "abcde", but "b?c" doesn't.
+//
+// With neither end anchored, each run of literals and '?'s between the
+// pattern's '*'s need only be found, as for the middle segments of a
+// compiled Pattern, after the run before it.
+func FastWildCompareAsciiContains(strWild, strTame string) bool {
+	if strings.IndexByte(strWild, '[') >= 0 {
+		slcTokens, _ := compileClassTokens(strWild, false)
+		slcTokens = append([]wildToken{runToken(byteSetAll)}, slcTokens...)
+		slcTokens = append(slcTokens, runToken(byteSetAll))
+		return matchClassTokens(slcTokens, strTame)
+	}
+
+	iTame := 0
+
+	for {
+		strRun := strWild
+		iStar := strings.IndexByte(strWild, '*')
+
+		if iStar >= 0 {
+			strRun = strWild[:iStar]
+		}
+
+		segment := patternSegment{
+			strRun:    strRun,
+			bQuestion: strings.IndexByte(strRun, '?') >= 0,
+		}
+		iFound := segment.indexIn(strTame[iTame:])
+
+		if iFound < 0 {
+			return false
+		} else if iStar < 0 {
+			return true
+		}
+
+		iTame += iFound + len(strRun)
+		strWild = strWild[iStar+1:]
+	}
+}
+
+// A set of ASCII patterns compiled by CompileSet(), for matching each tame
+// string against all of them, as for the rules of an access-control list.
+type PatternSet []*Pattern
+
+// Compiles a set of ASCII patterns for repeated matching via MatchAny()
+// and MatchAll(), with the same results as the functions of those names.
+// The patterns keep their order.  The first pattern that doesn't compile
+// is reported via its error, as from Compile().
+func CompileSet(slcWild []string) (PatternSet, error) {
+	set := make(PatternSet, 0, len(slcWild))
+
+	for _, strWild := range slcWild {
+		pattern, err := Compile(strWild)
+
+		if err != nil {
+			return nil, err
+		}
+
+		set = append(set, pattern)
+	}
+
+	return set, nil
+}
+
+// Reports whether a tame string matches any pattern in the set, trying the
+// patterns in order until one matches.  An empty set matches nothing.
+func (set PatternSet) MatchAny(strTame string) bool {
+	for _, pattern := range set {
+		if pattern.MatchString(strTame) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Reports whether a tame string matches every pattern in the set, trying
+// the patterns in order until one doesn't match.  An empty set matches
+// everything.
+func (set PatternSet) MatchAll(strTame string) bool {
+	for _, pattern := range set {
+		if !pattern.MatchString(strTame) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Returns the strings from a slice that match an ASCII pattern, in their
+// order in the slice, as for listing the names in a directory that match.
+// The pattern is compiled once, via Compile(), for all the candidates.  A
+// candidate that appears more than once and matches is returned as many
+// times.  If nothing matches, the result is nil.  A pattern that doesn't
+// compile is compared against each candidate via FastWildCompareAscii(),
+// so the results are always the same as from that routine.
+func MatchStrings(strWild string, slcCandidates []string) []string {
+	var slcMatches []string
+	pattern, err := Compile(strWild)
+
+	for _, strCandidate := range slcCandidates {
+		if err == nil && pattern.MatchString(strCandidate) ||
+			err != nil && FastWildCompareAscii(strWild, strCandidate) {
+			slcMatches = append(slcMatches, strCandidate)
+		}
+	}
+
+	return slcMatches
+}
+
+// Returns how many strings from a slice match an ASCII pattern, counting
+// duplicates, for the same result as len(MatchStrings()) but without
+// building a slice of the matches.  Only compiling the pattern allocates
+// memory, so the cost of a large corpus is just in the comparisons.
+func CountMatches(strWild string, slcCandidates []string) int {
+	iCount := 0
+	pattern, err := Compile(strWild)
+
+	for _, strCandidate := range slcCandidates {
+		if err == nil && pattern.MatchString(strCandidate) ||
+			err != nil && FastWildCompareAscii(strWild, strCandidate) {
+			iCount++
+		}
+	}
+
+	return iCount
+}
@@ -0,0 +1,304 @@
+// Go tests for compiled patterns.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Tests for Compile() and Pattern.MatchString(), which must agree with
+// FastWildCompareAscii().
+func TestCompile(t *testing.T) {
+	// Each short pattern, compiled once, matches each short tame string
+	// just as the uncompiled pattern does.
+	slcTames := allStrings([]string{"a", "b", "-"}, 5)
+
+	for _, strWild := range allStrings([]string{"*", "?", "a", "b",
+		"[!a]", "[-b]"}, 4) {
+		pattern, err := Compile(strWild)
+
+		if err != nil {
+			t.Fatalf("Compile(%q) failed: %v", strWild, err)
+		}
+
+		for _, strTame := range slcTames {
+			bExpected := FastWildCompareAscii(strWild, strTame)
+
+			if pattern.MatchString(strTame) != bExpected {
+				t.Errorf("Compile(%q).MatchString(%q) = %t, want %t",
+					strWild, strTame, !bExpected, bExpected)
+			}
+		}
+	}
+
+	// Segments with '?', repeated segments, and bracket classes.
+	for _, testCase := range []struct {
+		strWild   string
+		strTame   string
+		bExpected bool
+	}{
+		{"a?c*a?c*[xy]?", "abcadcyz", true},
+		{"a?c*a?c*[xy]?", "abcaXcxz", true},
+		{"a?c*a?c*[xy]?", "abcadczz", false},
+		{"a?c*a?c*[xy]?", "abcyz", false},
+		{"*ab*ab*", "abab", true},
+		{"*ab*ab*", "aba", false},
+	} {
+		pattern, err := Compile(testCase.strWild)
+
+		if err != nil {
+			t.Fatalf("Compile(%q) failed: %v", testCase.strWild, err)
+		} else if pattern.MatchString(testCase.strTame) !=
+			testCase.bExpected {
+			t.Errorf("Compile(%q).MatchString(%q) = %t, want %t",
+				testCase.strWild, testCase.strTame, !testCase.bExpected,
+				testCase.bExpected)
+		}
+	}
+
+	// Malformed bracket classes are reported.
+	if pattern, err := Compile("[z-a]*"); pattern != nil ||
+		!errors.Is(err, ErrEmptyRange) {
+		t.Errorf("Compile(%q) = %v, %v, want nil, %v", "[z-a]*", pattern,
+			err, ErrEmptyRange)
+	}
+}
+
+// Tests for FastWildCompareAsciiContains(), which finds a match anywhere
+// in the tame string, in contrast with FastWildCompareAscii().
+func TestFastWildCompareAsciiContains(t *testing.T) {
+	for _, testCase := range []struct {
+		strWild   string
+		strTame   string
+		bAnchored bool
+		bContains bool
+	}{
+		// Matches that are found inside the tame string, but not anchored.
+		{"bcd", "abcde", false, true},
+		{"bcd", "bcd", true, true},
+		{"b?d", "abcde", false, true},
+		{"b*e", "abcdef", false, true},
+		{"a*e", "abcde", true, true},
+		{"[b-c]d", "abcde", false, true},
+		{"", "abc", false, true},
+		{"", "", true, true},
+
+		// A '?' still means exactly one character.
+		{"b?c", "abcde", false, false},
+		{"?????", "abcd", false, false},
+		{"????", "abcde", false, true},
+		{"c??", "abcde", false, true},
+		{"d??", "abcde", false, false},
+
+		// Runs are found in order, without overlapping.
+		{"ab*ba", "xabax", false, false},
+		{"ab*ba", "xabbax", false, true},
+		{"e*a", "abcde", false, false},
+		{"[x]", "abcde", false, false},
+	} {
+		if bAnchored := FastWildCompareAscii(testCase.strWild,
+			testCase.strTame); bAnchored != testCase.bAnchored {
+			t.Errorf("FastWildCompareAscii(%q, %q) = %t, want %t",
+				testCase.strWild, testCase.strTame, bAnchored,
+				testCase.bAnchored)
+		}
+
+		if bContains := FastWildCompareAsciiContains(testCase.strWild,
+			testCase.strTame); bContains != testCase.bContains {
+			t.Errorf("FastWildCompareAsciiContains(%q, %q) = %t, want %t",
+				testCase.strWild, testCase.strTame, bContains,
+				testCase.bContains)
+		}
+	}
+
+	// For each short pair, the result is that of the pattern with a '*'
+	// at each end.
+	for _, strWild := range allStrings([]string{"*", "?", "a", "b",
+		"[!a]"}, 4) {
+		for _, strTame := range allStrings([]string{"a", "b", "c"}, 5) {
+			bExpected := FastWildCompareAscii("*"+strWild+"*", strTame)
+
+			if FastWildCompareAsciiContains(strWild, strTame) != bExpected {
+				t.Errorf("FastWildCompareAsciiContains(%q, %q) = %t, "+
+					"want %t", strWild, strTame, !bExpected, bExpected)
+			}
+		}
+	}
+}
+
+// Tests for MatchStrings().
+func TestMatchStrings(t *testing.T) {
+	slcNames := []string{"main.go", "README.md", "util.go", "main.go",
+		"go.mod", "util_test.go"}
+
+	// Matches keep their order, and duplicates are kept.  Nothing matches a
+	// pattern that matches none of the candidates, and nothing is in an
+	// empty list.
+	for _, testCase := range []struct {
+		strWild     string
+		slcTames    []string
+		slcExpected []string
+	}{
+		{"*.go", slcNames,
+			[]string{"main.go", "util.go", "main.go", "util_test.go"}},
+		{"main.go", slcNames, []string{"main.go", "main.go"}},
+		{"[gm]*.??", slcNames, []string{"main.go", "main.go"}},
+		{"*", []string{"", "a", ""}, []string{"", "a", ""}},
+		{"*.txt", slcNames, nil},
+		{"*", nil, nil},
+		{"*", []string{}, nil},
+	} {
+		if slcMatches := MatchStrings(testCase.strWild,
+			testCase.slcTames); !reflect.DeepEqual(slcMatches,
+			testCase.slcExpected) {
+			t.Errorf("MatchStrings(%q, %q) = %q, want %q",
+				testCase.strWild, testCase.slcTames, slcMatches,
+				testCase.slcExpected)
+		}
+	}
+
+	// A pattern that doesn't compile is compared as FastWildCompareAscii()
+	// compares it.
+	slcTames := []string{"z", "a", "[z-a]", "-"}
+	var slcExpected []string
+
+	for _, strTame := range slcTames {
+		if FastWildCompareAscii("[z-a]", strTame) {
+			slcExpected = append(slcExpected, strTame)
+		}
+	}
+
+	if slcMatches := MatchStrings("[z-a]", slcTames); !reflect.DeepEqual(
+		slcMatches, slcExpected) {
+		t.Errorf("MatchStrings(%q, %q) = %q, want %q", "[z-a]", slcTames,
+			slcMatches, slcExpected)
+	}
+
+	// Each comparison gets the same result as from FastWildCompareAscii().
+	for _, strWild := range allStrings([]string{"*", "?", "a", "[!a]",
+		"[a"}, 4) {
+		for _, strTame := range allStrings([]string{"a", "b", "["}, 4) {
+			bExpected := FastWildCompareAscii(strWild, strTame)
+
+			if slcMatches := MatchStrings(strWild,
+				[]string{strTame}); (len(slcMatches) == 1) != bExpected {
+				t.Errorf("MatchStrings(%q, %q) = %q", strWild,
+					[]string{strTame}, slcMatches)
+			}
+		}
+	}
+}
+
+// Tests for CountMatches(), which must count just what MatchStrings()
+// returns.
+func TestCountMatches(t *testing.T) {
+	slcNames := []string{"main.go", "README.md", "util.go", "main.go",
+		"go.mod", "util_test.go"}
+
+	for _, testCase := range []struct {
+		strWild   string
+		slcTames  []string
+		iExpected int
+	}{
+		{"*.go", slcNames, 4},
+		{"main.go", slcNames, 2},
+		{"*.txt", slcNames, 0},
+		{"*", nil, 0},
+	} {
+		if iCount := CountMatches(testCase.strWild,
+			testCase.slcTames); iCount != testCase.iExpected {
+			t.Errorf("CountMatches(%q, %q) = %d, want %d",
+				testCase.strWild, testCase.slcTames, iCount,
+				testCase.iExpected)
+		}
+	}
+
+	// Each pattern against a corpus of tame strings.
+	slcCorpus := allStrings([]string{"a", "b", "["}, 4)
+
+	for _, strWild := range allStrings([]string{"*", "?", "a", "[!a]",
+		"[a"}, 4) {
+		iExpected := len(MatchStrings(strWild, slcCorpus))
+
+		if iCount := CountMatches(strWild, slcCorpus); iCount != iExpected {
+			t.Errorf("CountMatches(%q) = %d, want %d", strWild, iCount,
+				iExpected)
+		}
+	}
+}
+
+// Tests for the normalized patterns reported by Pattern.String().
+func TestPatternString(t *testing.T) {
+	for _, testCase := range []struct {
+		strWild     string
+		strExpected string
+	}{
+		// Runs of '*'s collapse, and '?'s come first within each run.
+		{"a***b", "a*b"},
+		{"***", "*"},
+		{"*?*?", "??*"},
+		{"a*?b?*c", "a?*b?*c"},
+		{"?*", "?*"},
+		{"a?b", "a?b"},
+		{"", ""},
+
+		// A '*' or '?' inside a bracket class is left alone, while one in a
+		// '[' that's never closed isn't part of any class.
+		{"[**?]**x", "[**?]*x"},
+		{"[**", "[*"},
+	} {
+		pattern, err := Compile(testCase.strWild)
+
+		if err != nil || pattern.String() != testCase.strExpected {
+			t.Errorf("Compile(%q) = %v, %v; want %q", testCase.strWild,
+				pattern, err, testCase.strExpected)
+		}
+	}
+
+	// The offset of a malformed class is that of the pattern as given.
+	if _, err := Compile("***[z-a]"); !errors.Is(err, ErrEmptyRange) ||
+		!strings.HasSuffix(err.Error(), "at offset 4") {
+		t.Errorf("Compile(%q) = %v, want %v at offset 4", "***[z-a]", err,
+			ErrEmptyRange)
+	}
+
+	// The normalized pattern matches just what the pattern as given
+	// matches.
+	for _, strWild := range allStrings([]string{"*", "?", "a", "[*?]",
+		"["}, 4) {
+		pattern, err := Compile(strWild)
+
+		if err != nil {
+			t.Errorf("Compile(%q) = %v", strWild, err)
+			continue
+		}
+
+		for _, strTame := range allStrings([]string{"a", "*", "["}, 5) {
+			bExpected := FastWildCompareAscii(strWild, strTame)
+
+			if FastWildCompareAscii(pattern.String(),
+				strTame) != bExpected ||
+				pattern.MatchString(strTame) != bExpected {
+				t.Errorf("%q, normalized as %q, doesn't match %q as "+
+					"given", strWild, pattern.String(), strTame)
+			}
+		}
+	}
+}
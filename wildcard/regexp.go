@@ -0,0 +1,141 @@
+// Go routine for converting wildcard patterns to regular expressions.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	errInvalidUtf8   = errors.New("pattern isn't valid UTF-8")
+	errNonAsciiClass = errors.New("bracket class lists non-ASCII bytes")
+)
+
+// Converts an ASCII pattern to an anchored RE2 regular expression, as
+// accepted by regexp.Compile(), for callers that offer wildcard patterns
+// to their users while matching via an existing regexp pipeline.  A '*'
+// becomes ".*", a '?' becomes ".", and each bracket class becomes a
+// character class of the same characters.  Runs of literals are escaped
+// via regexp.QuoteMeta(), and the "s" flag lets '.' match a newline, as a
+// wildcard does.  So "*.go" becomes `(?s)^.*\.go$`.
+//
+// For ASCII tame strings, the regular expression matches just what the
+// pattern matches via FastWildCompareAscii().  Since a '.' matches a whole
+// rune, for other UTF-8 tame strings it agrees with FastWildCompareUtf8()
+// instead.  A pattern that isn't valid UTF-8, or with a bracket class that
+// lists non-ASCII bytes, can't be converted, and an error is returned, as
+// it is for a malformed bracket class such as "[z-a]".
+func ToRegexp(strWild string) (string, error) {
+	if !utf8.ValidString(strWild) {
+		return "", errInvalidUtf8
+	}
+
+	slcTokens, err := compileClassTokens(strWild, false)
+
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	var slcLiteral []byte // The run of literal bytes not yet written
+
+	sb.WriteString("(?s)^")
+
+	for _, token := range slcTokens {
+		iMembers := 0
+		var cMember byte
+
+		for c := 0; c < 256; c++ {
+			if token.set.has(byte(c)) {
+				iMembers++
+				cMember = byte(c)
+			}
+		}
+
+		if iMembers == 1 {
+			slcLiteral = append(slcLiteral, cMember)
+			continue
+		}
+
+		sb.WriteString(regexp.QuoteMeta(string(slcLiteral)))
+		slcLiteral = slcLiteral[:0]
+
+		if token.iMax < 0 {
+			sb.WriteString(".*")
+		} else if token.set == byteSetAll {
+			sb.WriteByte('.')
+		} else if strClass, bOk := regexpClass(token.set); bOk {
+			sb.WriteString(strClass)
+		} else {
+			return "", errNonAsciiClass
+		}
+	}
+
+	sb.WriteString(regexp.QuoteMeta(string(slcLiteral)))
+	sb.WriteByte('$')
+	return sb.String(), nil
+}
+
+// Returns an RE2 character class matching the ASCII members of a bracket
+// class's set of bytes.  A set with every non-ASCII byte, as from a
+// negated bracket class, becomes a negated character class, which matches
+// any non-ASCII rune.  Returns false for a set with some non-ASCII bytes,
+// but not all of them, which no character class can express.
+func regexpClass(set byteSet) (string, bool) {
+	var sb strings.Builder
+	bNegated := set.has(0x80)
+
+	for c := 0x81; c < 256; c++ {
+		if set.has(byte(c)) != bNegated {
+			return "", false
+		}
+	}
+
+	sb.WriteByte('[')
+
+	if bNegated {
+		sb.WriteByte('^')
+	}
+
+	// List the ASCII bytes that are members, or for a negated class, those
+	// that aren't, in ranges.
+	for c := 0; c < 128; {
+		if set.has(byte(c)) == bNegated {
+			c++
+			continue
+		}
+
+		cFirst := c
+
+		for c < 128 && set.has(byte(c)) != bNegated {
+			c++
+		}
+
+		fmt.Fprintf(&sb, `\x%02X`, cFirst)
+
+		if c-1 > cFirst {
+			fmt.Fprintf(&sb, `-\x%02X`, c-1)
+		}
+	}
+
+	sb.WriteByte(']')
+	return sb.String(), true
+}
@@ -0,0 +1,84 @@
+// Go tests for converting patterns to regular expressions.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import (
+	"regexp"
+	"testing"
+)
+
+// Differential tests for ToRegexp(), comparing the results of each
+// converted pattern, as compiled by the regexp package, with those of the
+// native matchers.
+func TestToRegexp(t *testing.T) {
+	// Conversions, including escaped metacharacters and bracket classes.
+	for _, testCase := range []struct {
+		strWild   string
+		strRegexp string
+	}{
+		{"*.go", `(?s)^.*\.go$`},
+		{"a?c", `(?s)^a.c$`},
+		{"(a+b)|c^$", `(?s)^\(a\+b\)\|c\^\$$`},
+		{"[a-cx]*", `(?s)^[\x61-\x63\x78].*$`},
+		{"[!0-9]", `(?s)^[^\x30-\x39]$`},
+		{"[a]b[", `(?s)^ab\[$`},
+		{"♥*", `(?s)^♥.*$`},
+	} {
+		if strRegexp, err := ToRegexp(testCase.strWild); err != nil ||
+			strRegexp != testCase.strRegexp {
+			t.Errorf("ToRegexp(%q) = %q, %v, want %q, nil",
+				testCase.strWild, strRegexp, err, testCase.strRegexp)
+		}
+	}
+
+	// Patterns that can't be converted.
+	for _, strWild := range []string{"[z-a]", "[é]", "[!é]", "a\xff"} {
+		if _, err := ToRegexp(strWild); err == nil {
+			t.Errorf("ToRegexp(%q) succeeded", strWild)
+		}
+	}
+
+	// For each short pair, the converted pattern agrees with
+	// FastWildCompareAscii() for an ASCII tame string, or otherwise with
+	// FastWildCompareUtf8(), which also matches a '?' against a whole rune.
+	for _, strWild := range allStrings([]string{"*", "?", "a", ".", "♥",
+		"[!a]", "[.-a]"}, 4) {
+		strRegexp, err := ToRegexp(strWild)
+
+		if err != nil {
+			t.Fatalf("ToRegexp(%q) failed: %v", strWild, err)
+		}
+
+		re := regexp.MustCompile(strRegexp)
+
+		for _, strTame := range allStrings([]string{"a", ".", "\n"}, 4) {
+			if re.MatchString(strTame) !=
+				FastWildCompareAscii(strWild, strTame) {
+				t.Errorf("%q, from ToRegexp(%q), disagrees on %q",
+					strRegexp, strWild, strTame)
+			}
+		}
+
+		for _, strTame := range allStrings([]string{"a", "♥", "é"}, 4) {
+			if re.MatchString(strTame) !=
+				FastWildCompareUtf8(strWild, strTame) {
+				t.Errorf("%q, from ToRegexp(%q), disagrees on %q",
+					strRegexp, strWild, strTame)
+			}
+		}
+	}
+}
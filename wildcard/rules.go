@@ -0,0 +1,309 @@
+// Go routines for analyzing sets of patterns used as rules.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Measures how much more permissive pattern strWildA is than pattern
+// strWildB, on a sample of tame strings.  Returns the number of samples
+// that match strWildA but not strWildB, minus the number that match
+// strWildB but not strWildA.  A positive result means that strWildA let
+// more of the samples through; zero means the two patterns are equally
+// permissive on the sample, though not necessarily for the same samples.
+func ComparePermissiveness(strWildA, strWildB string, slcSamples []string) int {
+	iBalance := 0
+
+	for _, strTame := range slcSamples {
+		bMatchA := FastWildCompareAscii(strWildA, strTame)
+		bMatchB := FastWildCompareAscii(strWildB, strTame)
+
+		if bMatchA && !bMatchB {
+			iBalance++
+		} else if bMatchB && !bMatchA {
+			iBalance--
+		}
+	}
+
+	return iBalance
+}
+
+// Returns the canonical form of an ASCII pattern, which matches exactly the
+// same tame strings.  Each run of wildcards containing at least one '*' is
+// rewritten as its '?'s followed by a single '*', so "a**b", "a*?*b" and
+// "a?*b" become "a*b", "a?*b", and "a?*b".  Everything else is kept as is,
+// including each bracket class, so the '*'s and '?'s in "[*?]" are left
+// alone as the class members they are.
+func Simplify(strWild string) string {
+	return normalizeStars(strWild)
+}
+
+// Reports whether two ASCII patterns have the same canonical form, as
+// returned by Simplify(), and so match the same tame strings.
+func Equivalent(strWildA, strWildB string) bool {
+	return Simplify(strWildA) == Simplify(strWildB)
+}
+
+// Returns the canonical form of a set of ASCII patterns, for storing rule
+// sets deterministically and diffing them meaningfully.  Each pattern is
+// simplified via Simplify(), patterns that are Equivalent() are reduced to
+// one, and the result is sorted.  So any ordering of a set, with any
+// redundant spellings of its patterns, normalizes to the same list.
+func Normalize(slcWild []string) []string {
+	slcNormal := make([]string, 0, len(slcWild))
+	mapSeen := make(map[string]bool, len(slcWild))
+
+	for _, strWild := range slcWild {
+		strSimple := Simplify(strWild)
+
+		if !mapSeen[strSimple] {
+			mapSeen[strSimple] = true
+			slcNormal = append(slcNormal, strSimple)
+		}
+	}
+
+	sort.Strings(slcNormal)
+	return slcNormal
+}
+
+// Returns a pattern matching exactly the tame strings that both of two ASCII
+// patterns match, for merging overlapping rules.  The boolean result is
+// false if no such pattern could be found.  That's the case when the
+// patterns have no match in common, since no pattern matches nothing, and
+// it's the case in general when the common matches can't be described by
+// one pattern, as for "*a*" and "*b*", which share "ab" and "ba" but not
+// "aa" or "bb".  The cases handled, after each pattern is put in canonical
+// form via Simplify(), are these:
+//
+//   - Identical patterns, or a pattern and "*", intersect to the pattern.
+//   - A pattern without wildcards intersects to itself, if the other
+//     pattern matches it.
+//   - Patterns of fixed length, without '*', intersect to a pattern of that
+//     length where each position takes the more specific of the two
+//     patterns' characters there, if they agree, so "a?c" and "?b?"
+//     intersect to "abc".
+//   - A pattern of fixed length and a pattern with one '*' intersect
+//     likewise, with the second pattern's characters before and after its
+//     '*' aligned with the start and end of the first.
+//   - Patterns with one '*' each intersect to a pattern whose characters
+//     before and after its '*' combine those of both patterns, so "ab*" and
+//     "*cd" intersect to "ab*cd".  But if some shorter tame string could
+//     match both, with those characters overlapping, as "abc" matches "ab*"
+//     and "*bc", there's no pattern for the intersection.
+//
+// Other intersections, such as of patterns with several '*'s, aren't
+// worked out, so the result is false for them, even where a pattern could
+// describe them.  Nor are intersections involving bracket classes, other
+// than those of identical patterns or of a pattern and "*".
+func Intersect(strWildA, strWildB string) (string, bool) {
+	strWildA = Simplify(strWildA)
+	strWildB = Simplify(strWildB)
+	iStarsA := strings.Count(strWildA, "*")
+	iStarsB := strings.Count(strWildB, "*")
+
+	// Order the patterns so that strWildA has fewer '*'s.
+	if iStarsA > iStarsB {
+		strWildA, strWildB = strWildB, strWildA
+		iStarsA, iStarsB = iStarsB, iStarsA
+	}
+
+	switch {
+	case strWildA == strWildB || strWildB == "*":
+		return strWildA, true
+	case strWildA == "*":
+		return strWildB, true
+	case strings.IndexByte(strWildA+strWildB, '[') >= 0:
+		return "", false
+	case !strings.Contains(strWildA, "?") && iStarsA == 0:
+		return strWildA, FastWildCompareAscii(strWildB, strWildA)
+	case iStarsB == 0:
+		if len(strWildA) != len(strWildB) {
+			return "", false // Lengths that can't both match.
+		}
+
+		return overlayWild(strWildA, 0, strWildB)
+	case iStarsA == 0 && iStarsB == 1:
+		iStar := strings.IndexByte(strWildB, '*')
+
+		if len(strWildB)-1 > len(strWildA) {
+			return "", false // Too short for the starred pattern.
+		}
+
+		strWild, bOk := overlayWild(strWildA, 0, strWildB[:iStar])
+
+		if bOk {
+			strWild, bOk = overlayWild(strWild,
+				len(strWild)-len(strWildB[iStar+1:]), strWildB[iStar+1:])
+		}
+
+		return strWild, bOk
+	case iStarsA == 1 && iStarsB == 1:
+		iStarA := strings.IndexByte(strWildA, '*')
+		iStarB := strings.IndexByte(strWildB, '*')
+		strPrefix, bPrefixOk := overlayWild(
+			strings.Repeat("?", max(iStarA, iStarB)), 0, strWildA[:iStarA])
+		strSuffixA := strWildA[iStarA+1:]
+		strSuffixB := strWildB[iStarB+1:]
+		strSuffix, bSuffixOk := overlayWild(
+			strings.Repeat("?", max(len(strSuffixA), len(strSuffixB))),
+			max(0, len(strSuffixB)-len(strSuffixA)), strSuffixA)
+
+		if bPrefixOk {
+			strPrefix, bPrefixOk = overlayWild(strPrefix, 0,
+				strWildB[:iStarB])
+		}
+
+		if bSuffixOk {
+			strSuffix, bSuffixOk = overlayWild(strSuffix,
+				len(strSuffix)-len(strSuffixB), strSuffixB)
+		}
+
+		if !bPrefixOk || !bSuffixOk {
+			return "", false
+		}
+
+		// Look for a shorter tame string, where the prefix and suffix
+		// overlap, that both patterns match.
+		for iLen := max(len(strWildA), len(strWildB)) - 1; iLen <
+			len(strPrefix)+len(strSuffix); iLen++ {
+			strWild, bOk := overlayWild(strings.Repeat("?", iLen), 0,
+				strPrefix)
+
+			if bOk {
+				strWild, bOk = overlayWild(strWild, iLen-len(strSuffix),
+					strSuffix)
+			}
+
+			if bOk {
+				return "", false
+			}
+		}
+
+		return strPrefix + "*" + strSuffix, true
+	}
+
+	return "", false
+}
+
+// Overlays a pattern without '*' onto another, starting at position i,
+// where the overlaid pattern must fit.  At each position, a '?' gives way
+// to the other pattern's character.  Returns the combined pattern, or
+// false if the patterns call for different characters at some position.
+func overlayWild(strWild string, i int, strOverlay string) (string, bool) {
+	slcWild := []byte(strWild)
+
+	for iOverlay := 0; iOverlay < len(strOverlay); iOverlay++ {
+		c := strOverlay[iOverlay]
+
+		if slcWild[i+iOverlay] == '?' {
+			slcWild[i+iOverlay] = c
+		} else if c != '?' && c != slcWild[i+iOverlay] {
+			return "", false
+		}
+	}
+
+	return string(slcWild), true
+}
+
+// The cost and yield of one pattern over a set of inputs, as reported by
+// ProfileMatch().
+type PatternProfile struct {
+	Pattern string        // The pattern profiled
+	Matches int           // How many of the inputs it matched
+	Elapsed time.Duration // Total time spent matching it against them
+}
+
+// Matches each of a set of ASCII patterns against every one of a set of
+// inputs, reporting how many inputs each pattern matched and how long it
+// took, so that the slowest rules in a large rule set can be found and
+// tuned.  The profiles are in the same order as the patterns.  Each
+// pattern is timed across all of the inputs together, so that the timer's
+// own cost is spread thin; for timings to be meaningful, the inputs should
+// be numerous or the patterns expensive.
+func ProfileMatch(slcWild []string, slcTame []string) []PatternProfile {
+	slcProfiles := make([]PatternProfile, len(slcWild))
+
+	for i, strWild := range slcWild {
+		iMatches := 0
+		timeStart := time.Now()
+
+		for _, strTame := range slcTame {
+			if FastWildCompareAscii(strWild, strTame) {
+				iMatches++
+			}
+		}
+
+		slcProfiles[i] = PatternProfile{strWild, iMatches, time.Since(timeStart)}
+	}
+
+	return slcProfiles
+}
+
+// Reports whether a tame string matches any of a set of ASCII patterns,
+// as for an access-control list that grants access on any match.  The
+// patterns are compared in order, via FastWildCompareAscii(), until one
+// matches.  An empty set matches nothing.  For a set that's matched many
+// times, CompileSet() avoids finding each pattern's '*'s again each time.
+func MatchAny(slcWild []string, strTame string) bool {
+	for _, strWild := range slcWild {
+		if FastWildCompareAscii(strWild, strTame) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Reports whether a tame string matches every one of a set of ASCII
+// patterns.  The patterns are compared in order, via
+// FastWildCompareAscii(), until one doesn't match.  An empty set matches
+// everything.
+func MatchAll(slcWild []string, strTame string) bool {
+	for _, strWild := range slcWild {
+		if !FastWildCompareAscii(strWild, strTame) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Compares the behavior of two rule sets on a sample of tame strings, for
+// reviewing a change from one set of ASCII patterns to another.  A sample
+// is matched by a rule set if it matches any of its patterns.  Returns the
+// samples matched by the old set but not the new one, and those matched by
+// the new set but not the old one, each in the order given.
+func DiffRuleSets(slcOld, slcNew []string,
+	slcSamples []string) ([]string, []string) {
+	var slcOnlyOld, slcOnlyNew []string
+
+	for _, strTame := range slcSamples {
+		bMatchOld := MatchAny(slcOld, strTame)
+		bMatchNew := MatchAny(slcNew, strTame)
+
+		if bMatchOld && !bMatchNew {
+			slcOnlyOld = append(slcOnlyOld, strTame)
+		} else if bMatchNew && !bMatchOld {
+			slcOnlyNew = append(slcOnlyNew, strTame)
+		}
+	}
+
+	return slcOnlyOld, slcOnlyNew
+}
@@ -0,0 +1,420 @@
+// Go tests for the routines for maintaining sets of pattern rules.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// Simplify() rewrites runs of '*'s and '?'s outside bracket classes, and
+// leaves the classes as they are, so that a class whose members include
+// '*' or '?' keeps matching what it did.  Equivalent() and Normalize()
+// follow suit.
+func TestSimplifyClasses(t *testing.T) {
+	for _, testCase := range []struct {
+		strWild   string
+		strSimple string
+	}{
+		{"[ *?-~]", "[ *?-~]"},
+		{"*?[*?]?*", "?*[*?]?*"},
+		{"a**[!**]**b", "a*[!**]*b"},
+		{"[]*?]*?", "[]*?]?*"},
+		{"[*?", "[?*"},
+	} {
+		if strSimple := Simplify(testCase.strWild); strSimple !=
+			testCase.strSimple {
+			t.Errorf("Simplify(%q) = %q; want %q", testCase.strWild,
+				strSimple, testCase.strSimple)
+		}
+
+		for _, strTame := range allStrings([]string{"a", "b", "*", "?",
+			"]", "["}, 4) {
+			if FastWildCompareAscii(testCase.strWild, strTame) !=
+				FastWildCompareAscii(testCase.strSimple, strTame) {
+				t.Errorf("%q and %q differ on %q", testCase.strWild,
+					testCase.strSimple, strTame)
+			}
+		}
+	}
+
+	if !Equivalent("a**[*]", "a*[*]") {
+		t.Errorf("Equivalent(%q, %q) = false; want true", "a**[*]", "a*[*]")
+	}
+
+	if Equivalent("[*?]", "[?]") {
+		t.Errorf("Equivalent(%q, %q) = true; want false", "[*?]", "[?]")
+	}
+
+	slcNormal := Normalize([]string{"[ *?-~]", "a**b", "a*b"})
+
+	if !slices.Equal(slcNormal, []string{"[ *?-~]", "a*b"}) {
+		t.Errorf("Normalize() = %q; want %q", slcNormal,
+			[]string{"[ *?-~]", "a*b"})
+	}
+}
+
+// Tests for MatchAny() and MatchAll(), and for their compiled forms via
+// CompileSet(), with overlapping and empty sets of patterns.
+func TestMatchAnyAll(t *testing.T) {
+	checkSet := func(slcWild []string, strTame string, bAny, bAll bool) {
+		set, err := CompileSet(slcWild)
+
+		if err != nil {
+			t.Fatalf("CompileSet(%q) failed: %v", slcWild, err)
+		}
+
+		if MatchAny(slcWild, strTame) != bAny || set.MatchAny(strTame) !=
+			bAny {
+			t.Errorf("MatchAny(%q, %q) isn't %t", slcWild, strTame, bAny)
+		}
+
+		if MatchAll(slcWild, strTame) != bAll || set.MatchAll(strTame) !=
+			bAll {
+			t.Errorf("MatchAll(%q, %q) isn't %t", slcWild, strTame, bAll)
+		}
+	}
+
+	// Overlapping patterns, of which some or all match.
+	slcAcl := []string{"/api/*", "/api/v?/*", "*/users/*", "/api/v1/users/?*"}
+	checkSet(slcAcl, "/api/v1/users/42", true, true)
+	checkSet(slcAcl, "/api/v1/users/", true, false)
+	checkSet(slcAcl, "/api/v2/items", true, false)
+	checkSet(slcAcl, "/web/users/42", true, false)
+	checkSet(slcAcl, "/web/items", false, false)
+	checkSet([]string{"*", "[a-c]*"}, "b", true, true)
+
+	// Empty sets, which match nothing via MatchAny(), and everything via
+	// MatchAll().
+	checkSet(nil, "anything", false, true)
+	checkSet([]string{}, "", false, true)
+
+	// A set with a pattern that doesn't compile.
+	if set, err := CompileSet([]string{"*", "[z-a]"}); set != nil ||
+		!errors.Is(err, ErrEmptyRange) {
+		t.Errorf("CompileSet() = %v, %v, want nil, %v", set, err,
+			ErrEmptyRange)
+	}
+
+	// Sets of a few short patterns each, against each short tame string.
+	slcWilds := allStrings([]string{"*", "?", "a", "[!a]"}, 3)
+
+	for i := 0; i+3 <= len(slcWilds); i++ {
+		slcWild := slcWilds[i : i+3]
+
+		for _, strTame := range allStrings([]string{"a", "b"}, 3) {
+			bAny := false
+			bAll := true
+
+			for _, strWild := range slcWild {
+				bMatch := FastWildCompareAscii(strWild, strTame)
+				bAny = bAny || bMatch
+				bAll = bAll && bMatch
+			}
+
+			checkSet(slcWild, strTame, bAny, bAll)
+		}
+	}
+}
+
+// Tests for ComparePermissiveness(), on a crafted sample of file names.
+func TestComparePermissiveness(t *testing.T) {
+	slcSamples := []string{
+		"error.log", "error.log.1", "access.log", "debug.txt", "notes.txt",
+		"log", "a.log", "catalog",
+	}
+
+	for _, testCase := range []struct {
+		strWildA   string
+		strWildB   string
+		slcSamples []string
+		iExpected  int
+	}{
+		// "*.log" matches error.log, access.log, and a.log; "*log" matches
+		// those plus log and catalog.
+		{"*log", "*.log", slcSamples, 2},
+		{"*.log", "*log", slcSamples, -2},
+
+		// "*.log*" adds error.log.1, while "*.txt" matches two other
+		// samples: 4 matching only the first, minus 2 matching only the
+		// second.
+		{"*.log*", "*.txt", slcSamples, 2},
+
+		// "*.txt" matches two samples, and "?.log*" matches just a.log.
+		{"*.txt", "?.log*", slcSamples, 1},
+
+		// Equally permissive, on different samples.
+		{"debug.*", "notes.*", slcSamples, 0},
+
+		// Identical patterns, and an empty sample.
+		{"*", "*", slcSamples, 0},
+		{"*", "", nil, 0},
+
+		// The universal pattern is at least as permissive as any other.
+		{"*", "*.log", slcSamples, len(slcSamples) - 3},
+	} {
+		if iResult := ComparePermissiveness(testCase.strWildA,
+			testCase.strWildB,
+			testCase.slcSamples); iResult != testCase.iExpected {
+			t.Errorf("ComparePermissiveness(%q, %q) = %d, want %d",
+				testCase.strWildA, testCase.strWildB, iResult,
+				testCase.iExpected)
+		}
+	}
+}
+
+// Tests for Simplify(), Equivalent(), and Normalize().
+func TestNormalize(t *testing.T) {
+	for _, simplifyCase := range []struct {
+		strWild     string
+		strExpected string
+	}{
+		{"a**b", "a*b"},
+		{"a*?*b", "a?*b"},
+		{"*?*?**", "??*"},
+		{"a??b", "a??b"},
+		{"", ""},
+	} {
+		if strSimple := Simplify(
+			simplifyCase.strWild); strSimple != simplifyCase.strExpected {
+			t.Errorf("Simplify(%q) = %q, want %q", simplifyCase.strWild,
+				strSimple, simplifyCase.strExpected)
+		}
+	}
+
+	for _, equivalentCase := range []struct {
+		strWildA  string
+		strWildB  string
+		bExpected bool
+	}{
+		{"*?", "?*", true},
+		{"x***y", "x*y", true},
+		{"x*y", "x?y", false},
+		{"*a*", "*a*a*", false},
+	} {
+		if Equivalent(equivalentCase.strWildA,
+			equivalentCase.strWildB) != equivalentCase.bExpected {
+			t.Errorf("Equivalent(%q, %q) = %t, want %t",
+				equivalentCase.strWildA, equivalentCase.strWildB,
+				!equivalentCase.bExpected, equivalentCase.bExpected)
+		}
+	}
+
+	// A simplified pattern matches just what the original pattern matches.
+	for _, strWild := range allStrings([]string{"*", "?", "a", "b"}, 5) {
+		strSimple := Simplify(strWild)
+
+		for _, strTame := range allStrings([]string{"a", "b"}, 5) {
+			bExpected := FastWildCompareAscii(strWild, strTame)
+
+			if FastWildCompareAscii(strSimple, strTame) != bExpected {
+				t.Errorf("Simplify(%q) = %q, which gets %t for %q",
+					strWild, strSimple, !bExpected, strTame)
+			}
+		}
+	}
+
+	// Permuted and redundantly expressed sets normalize to the same list.
+	slcExpected := []string{"*.go", "?*.tmp", "main.go"}
+
+	for _, slcSet := range [][]string{
+		{"main.go", "*.go", "?*.tmp"},
+		{"?*.tmp", "main.go", "*.go"},
+		{"**.go", "main.go", "*?.tmp", "*.go", "?**.tmp", "main.go"},
+	} {
+		if slcNormal := Normalize(slcSet); !slices.Equal(slcNormal,
+			slcExpected) {
+			t.Errorf("Normalize(%q) = %q, want %q", slcSet, slcNormal,
+				slcExpected)
+		}
+	}
+
+	if slcNormal := Normalize(nil); len(slcNormal) != 0 {
+		t.Errorf("Normalize(nil) = %q", slcNormal)
+	}
+}
+
+// Tests for ProfileMatch().  Timings are only checked for plausibility.
+func TestProfileMatch(t *testing.T) {
+	slcWild := []string{"*.go", "main.*", "*", "*.rs", "*a*a*a*a*b"}
+	slcTame := []string{"main.go", "main.rs", "wrappers.go", "README.md",
+		strings.Repeat("a", 200)}
+	slcExpected := []int{2, 2, 5, 1, 0}
+
+	slcProfiles := ProfileMatch(slcWild, slcTame)
+
+	if len(slcProfiles) != len(slcWild) {
+		t.Fatalf("ProfileMatch() returned %d profiles, want %d",
+			len(slcProfiles), len(slcWild))
+	}
+
+	for i, profile := range slcProfiles {
+		if profile.Pattern != slcWild[i] ||
+			profile.Matches != slcExpected[i] || profile.Elapsed < 0 {
+			t.Errorf("ProfileMatch() profiled %q as %+v, want %d matches",
+				slcWild[i], profile, slcExpected[i])
+		}
+	}
+
+	// Counts agree with matching the pairs one at a time.
+	for _, strWild := range allStrings([]string{"*", "?", "a", "b"}, 4) {
+		for _, strTame := range allStrings([]string{"a", "b"}, 4) {
+			iExpected := 0
+
+			if FastWildCompareAscii(strWild, strTame) {
+				iExpected = 1
+			}
+
+			slcProfiles = ProfileMatch([]string{strWild}, []string{strTame})
+
+			if slcProfiles[0].Matches != iExpected {
+				t.Errorf("ProfileMatch(%q, %q) counted %d matches, want %d",
+					strWild, strTame, slcProfiles[0].Matches, iExpected)
+			}
+		}
+	}
+
+	if slcProfiles = ProfileMatch(nil, slcTame); len(slcProfiles) != 0 {
+		t.Errorf("ProfileMatch(nil) = %+v", slcProfiles)
+	}
+
+	if slcProfiles = ProfileMatch(slcWild, nil); slcProfiles[0].Matches != 0 {
+		t.Errorf("ProfileMatch() with no tame strings = %+v", slcProfiles)
+	}
+}
+
+// Tests for DiffRuleSets().
+func TestDiffRuleSets(t *testing.T) {
+	slcSamples := []string{"app.log", "scratch.tmp", "main.go",
+		"main.go.bak", "build/out", "build/out.tmp", "notes.txt"}
+
+	for _, diffCase := range []struct {
+		slcOld         []string
+		slcNew         []string
+		slcOnlyOldWant []string
+		slcOnlyNewWant []string
+	}{
+		// The change drops "*.tmp" and adds "*.bak" and "build/*", while
+		// "*.log" stays.
+		{[]string{"*.log", "*.tmp"}, []string{"*.log", "*.bak", "build/*"},
+			[]string{"scratch.tmp"}, []string{"main.go.bak", "build/out"}},
+
+		// Swapping the sets swaps the results.
+		{[]string{"*.log", "*.bak", "build/*"}, []string{"*.log", "*.tmp"},
+			[]string{"main.go.bak", "build/out"}, []string{"scratch.tmp"}},
+
+		// Equivalent sets differ on nothing.
+		{[]string{"*.log", "*.tmp"}, []string{"*.tmp", "**.log"}, nil, nil},
+
+		// An empty set matches nothing.
+		{nil, []string{"*"}, nil, slcSamples},
+	} {
+		slcOnlyOld, slcOnlyNew := DiffRuleSets(diffCase.slcOld,
+			diffCase.slcNew, slcSamples)
+
+		if !slices.Equal(slcOnlyOld, diffCase.slcOnlyOldWant) ||
+			!slices.Equal(slcOnlyNew, diffCase.slcOnlyNewWant) {
+			t.Errorf("DiffRuleSets(%q, %q) = %q, %q; want %q, %q",
+				diffCase.slcOld, diffCase.slcNew, slcOnlyOld, slcOnlyNew,
+				diffCase.slcOnlyOldWant, diffCase.slcOnlyNewWant)
+		}
+	}
+}
+
+// Tests for Intersect().
+func TestIntersect(t *testing.T) {
+	for _, intersectCase := range []struct {
+		strWildA    string
+		strWildB    string
+		strExpected string
+		bOk         bool
+	}{
+		// Expressible intersections.
+		{"ab*", "*cd", "ab*cd", true},
+		{"a*", "*z", "a*z", true},
+		{"a?*", "?b*", "ab*", true},
+		{"*.log", "app-*", "app-*.log", true},
+		{"a?c", "?b?", "abc", true},
+		{"a??", "*c", "a?c", true},
+		{"???*", "a*", "a??*", true},
+		{"abc", "*b*", "abc", true},
+		{"*", "x*y*z", "x*y*z", true},
+		{"a**b", "a*b", "a*b", true},
+		{"*a*b*", "*a*b*", "*a*b*", true},
+		{"", "*", "", true},
+		{"ab*", "??", "ab", true},
+
+		// Intersections with no common matches.
+		{"a*", "b*", "", false},
+		{"abc", "*x*", "", false},
+		{"a?c", "?bd", "", false},
+		{"??", "???", "", false},
+		{"ab*", "?", "", false},
+		{"", "?*", "", false},
+
+		// Intersections that no pattern describes, or that aren't worked
+		// out.
+		{"ab*", "*bc", "", false},
+		{"*a*", "*b*", "", false},
+		{"??", "*a*", "", false},
+		{"a*b*", "*c", "", false},
+	} {
+		// The order of the patterns makes no difference.
+		for _, slcPair := range [][2]string{
+			{intersectCase.strWildA, intersectCase.strWildB},
+			{intersectCase.strWildB, intersectCase.strWildA},
+		} {
+			strWild, bOk := Intersect(slcPair[0], slcPair[1])
+
+			if bOk != intersectCase.bOk ||
+				(bOk && strWild != intersectCase.strExpected) {
+				t.Errorf("Intersect(%q, %q) = %q, %t; want %q, %t",
+					slcPair[0], slcPair[1], strWild, bOk,
+					intersectCase.strExpected, intersectCase.bOk)
+			}
+		}
+	}
+
+	// Each intersection found matches just the tame strings that both of
+	// its patterns match.
+	slcWild := []string{"a*", "*b", "?a*", "*b?", "a?b", "ab*", "*ab",
+		"??*", "a*b", "*", "b", "???"}
+	slcTame := allStrings([]string{"a", "b"}, 5)
+
+	for _, strWildA := range slcWild {
+		for _, strWildB := range slcWild {
+			strWild, bOk := Intersect(strWildA, strWildB)
+
+			if !bOk {
+				continue
+			}
+
+			for _, strTame := range slcTame {
+				bExpected := FastWildCompareAscii(strWildA, strTame) &&
+					FastWildCompareAscii(strWildB, strTame)
+
+				if FastWildCompareAscii(strWild, strTame) != bExpected {
+					t.Errorf("Intersect(%q, %q) = %q, which on %q "+
+						"doesn't give %t", strWildA, strWildB, strWild,
+						strTame, bExpected)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,156 @@
+// Go routines for matching wildcards against sequences of segments, such
+// as the labels of a hostname.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import "strings"
+
+// Compares a sequence of pattern segments against a sequence of tame
+// segments.  A "**" segment matches any run of tame segments, including
+// an empty run.  Each other pattern segment matches one tame segment, as
+// decided by fnMatch.
+//
+// This is the single-fallback form of the algorithm in
+// FastWildCompareAscii(), with segments in place of characters and "**"
+// in place of '*'.
+func matchSegments(slcWild, slcTame []string,
+	fnMatch func(strWild, strTame string) bool) bool {
+	iWild := 0
+	iTame := 0
+	iWildStar := -1 // Index of the "**" we can fall back to, if any
+	iTameStar := 0  // Where segments consumed by that "**" end
+
+	for iTame < len(slcTame) {
+		if iWild < len(slcWild) && slcWild[iWild] == "**" {
+			iWildStar = iWild
+			iTameStar = iTame
+			iWild++
+		} else if iWild < len(slcWild) &&
+			fnMatch(slcWild[iWild], slcTame[iTame]) {
+			iWild++
+			iTame++
+		} else if iWildStar >= 0 {
+			// Let the last "**" consume one more segment, and retry.
+			iTameStar++
+			iWild = iWildStar + 1
+			iTame = iTameStar
+		} else {
+			return false
+		}
+	}
+
+	for iWild < len(slcWild) && slcWild[iWild] == "**" {
+		iWild++
+	}
+
+	return iWild == len(slcWild)
+}
+
+// Compares a hostname pattern against a hostname, label by label.  A label
+// of "*" matches any one label, and a label of "**" matches one or more
+// labels.  So "*.example.com" matches "api.example.com" but not
+// "a.b.example.com" or "example.com", while "**.example.com" matches
+// "api.example.com" and "a.b.example.com" but still not "example.com".
+// Other labels are compared via FastWildCompareAscii(), so "api-*" matches
+// "api-v2" but a '*' or '?' within a label never matches a '.'.
+//
+// As in DNS, the comparison ignores ASCII case, and a single trailing '.'
+// on either name, marking it as fully qualified, is ignored.
+func MatchHostname(strWild, strHost string) bool {
+	slcWild := strings.Split(strings.ToLower(
+		strings.TrimSuffix(strWild, ".")), ".")
+	slcHost := strings.Split(strings.ToLower(
+		strings.TrimSuffix(strHost, ".")), ".")
+
+	// Require "**" to match at least one label, via a "*" ahead of it.
+	slcLabels := make([]string, 0, len(slcWild))
+
+	for _, strLabel := range slcWild {
+		if strLabel == "**" {
+			slcLabels = append(slcLabels, "*")
+		}
+
+		slcLabels = append(slcLabels, strLabel)
+	}
+
+	return matchSegments(slcLabels, slcHost, FastWildCompareAscii)
+}
+
+// Splits a JSON path such as "a.b[0].c" into its keys and indices, here
+// "a", "b", "0", and "c".  Keys are separated by '.', and each bracketed
+// index or key is a segment of its own, with any quotes around it removed,
+// so "a['b.c']" splits into "a" and "b.c".  A '[' without a closing ']'
+// is just part of a key.
+func splitJSONPath(strPath string) []string {
+	var slcSegments []string
+	iStart := 0
+	bAfterBracket := false // Whether a ']' just ended a segment
+
+	for i := 0; i < len(strPath); i++ {
+		if strPath[i] == '.' {
+			if i > iStart || !bAfterBracket {
+				slcSegments = append(slcSegments, strPath[iStart:i])
+			}
+
+			iStart = i + 1
+			bAfterBracket = false
+		} else if strPath[i] == '[' {
+			iClose := strings.IndexByte(strPath[i:], ']')
+
+			if iClose < 0 {
+				break
+			} else if i > iStart {
+				slcSegments = append(slcSegments, strPath[iStart:i])
+			}
+
+			strSegment := strPath[i+1 : i+iClose]
+
+			if len(strSegment) >= 2 && strSegment[0] == strSegment[len(
+				strSegment)-1] && (strSegment[0] == '\'' ||
+				strSegment[0] == '"') {
+				strSegment = strSegment[1 : len(strSegment)-1]
+			}
+
+			slcSegments = append(slcSegments, strSegment)
+			i += iClose
+			iStart = i + 1
+			bAfterBracket = true
+		}
+	}
+
+	if iStart < len(strPath) || !bAfterBracket {
+		slcSegments = append(slcSegments, strPath[iStart:])
+	}
+
+	return slcSegments
+}
+
+// Compares a JSON path pattern against a JSON path, such as "a.b[0].c",
+// segment by segment, as for config tools that select parts of a document
+// by path.  Both are split into their keys and indices, so "a.b[0].c" and
+// "a.b.0.c" are the same path.  A segment of "*" matches any one key or
+// index, and a segment of "**" matches any number of them, including none.
+// So "a.*.c" matches "a.b.c" but not "a.b.x.c", while "a.**.c" matches
+// both, as well as "a.c".  Other segments are compared via
+// FastWildCompareAscii(), so "items[*].name*" matches "items[3].names",
+// but a '*' or '?' within a segment never matches a '.'.  Since brackets
+// mark indices, bracket classes aren't available in the pattern, and a
+// bracketed "*" or "**" is a wildcard segment like any other.
+func MatchJSONPath(strWild, strPath string) bool {
+	return matchSegments(splitJSONPath(strWild), splitJSONPath(strPath),
+		FastWildCompareAscii)
+}
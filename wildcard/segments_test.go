@@ -0,0 +1,112 @@
+// Go tests for the routines for matching wildcards against sequences of
+// segments.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import "testing"
+
+// Tests for MatchHostname().
+func TestMatchHostname(t *testing.T) {
+	for _, testCase := range []struct {
+		strWild   string
+		strHost   string
+		bExpected bool
+	}{
+		// A "*" label matches exactly one label.
+		{"*.example.com", "api.example.com", true},
+		{"*.example.com", "a.b.example.com", false},
+		{"*.example.com", "example.com", false},
+		{"*.example.com", "example.org", false},
+		{"www.*.com", "www.example.com", true},
+
+		// A "**" label matches one or more labels.
+		{"**.example.com", "api.example.com", true},
+		{"**.example.com", "a.b.example.com", true},
+		{"**.example.com", "x.y.z.example.com", true},
+		{"**.example.com", "example.com", false},
+		{"**.example.com", "a.b.example.org", false},
+		{"api.**", "api.eu.example.com", true},
+		{"api.**", "api", false},
+		{"**.eu.**", "a.b.eu.example.com", true},
+		{"**.eu.**", "eu.example.com", false},
+
+		// The bare domain matches itself.
+		{"example.com", "example.com", true},
+		{"example.com", "www.example.com", false},
+
+		// Wildcards within a label stay within it.
+		{"api-*.example.com", "api-v2.example.com", true},
+		{"api-*.example.com", "api-v2.eu.example.com", false},
+		{"db?.example.com", "db1.example.com", true},
+		{"a*b.com", "a.b.com", false},
+
+		// Case and a trailing dot are ignored.
+		{"*.Example.COM", "API.example.com", true},
+		{"*.example.com.", "api.example.com", true},
+		{"*.example.com", "api.example.com.", true},
+	} {
+		if MatchHostname(testCase.strWild,
+			testCase.strHost) != testCase.bExpected {
+			t.Errorf("MatchHostname(%q, %q) = %t, want %t",
+				testCase.strWild, testCase.strHost, !testCase.bExpected,
+				testCase.bExpected)
+		}
+	}
+}
+
+// Tests for MatchJSONPath().
+func TestMatchJSONPath(t *testing.T) {
+	for _, testCase := range []struct {
+		strWild   string
+		strPath   string
+		bExpected bool
+	}{
+		// A "**" matches any depth, while a "*" matches one segment.
+		{"a.**.c", "a.b.x.c", true},
+		{"a.*.c", "a.b.x.c", false},
+		{"a.*.c", "a.b.c", true},
+		{"a.**.c", "a.c", true},
+		{"a.*.c", "a.c", false},
+		{"**", "a.b[0].c", true},
+		{"**.id", "users[2].id", true},
+		{"**.id", "users[2].ids", false},
+
+		// Indices are segments, whether bracketed or dotted.
+		{"a.b[0].c", "a.b[0].c", true},
+		{"a.b.0.c", "a.b[0].c", true},
+		{"a.b[*].c", "a.b[12].c", true},
+		{"a.b[*].c", "a.b.c", false},
+		{"m[*][*]", "m[1][2]", true},
+		{"m[*][*]", "m[1]", false},
+		{"a['b.c'].d", "a[\"b.c\"].d", true},
+		{"a.b.c.d", "a['b.c'].d", false},
+
+		// Wildcards within a segment don't cross into the next.
+		{"items[*].name*", "items[3].names", true},
+		{"log?.level", "logs.level", true},
+		{"a*", "ab.c", false},
+		{"", "", true},
+		{"", "a", false},
+	} {
+		if MatchJSONPath(testCase.strWild,
+			testCase.strPath) != testCase.bExpected {
+			t.Errorf("MatchJSONPath(%q, %q) = %t, want %t",
+				testCase.strWild, testCase.strPath, !testCase.bExpected,
+				testCase.bExpected)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// Go routine for matching wildcards via a plain dynamic-programming table.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+// Compares two ASCII strings, accepting '*', '?', and bracket classes in
+// the pattern, via the most straightforward approach that still takes
+// polynomial time.  This is for users who would rather audit a matcher than
+// trust a fast one, and it documents what FastWildCompareAscii() computes,
+// as a cross check: the results are the same, for any pattern.  Bracket
+// classes are as described for parseClass(), so a '[' without a closing
+// ']' is a literal.
+//
+// For each prefix of the pattern, in turn, a table row records which
+// prefixes of the tame string it matches.  The empty pattern matches just
+// the empty tame string.  A pattern ending in '*' matches a tame prefix if
+// the pattern without the '*' matches it, or if the whole pattern matches
+// the tame prefix one character shorter.  A pattern ending in '?', in a
+// bracket class, or in a literal matches a tame prefix whose last
+// character matches that '?', class, or literal, if the rest of the
+// pattern matches the rest of the tame prefix.  The cost is proportional
+// to the product of the lengths.
+func SlowWildCompareAscii(strWild, strTame string) bool {
+	// slcRow[j] is whether the pattern prefix matches strTame[:j].
+	slcRow := make([]bool, len(strTame)+1)
+	slcRow[0] = true
+
+	for i := 0; i < len(strWild); {
+		slcNext := make([]bool, len(strTame)+1)
+		set := byteSetOf(strWild[i])
+		iNext := i + 1
+
+		if strWild[i] == '?' {
+			set = byteSetAll
+		} else if strWild[i] == '[' {
+			if setClass, iEnd, _ := parseClass(strWild, i, false); iEnd >= 0 {
+				set = setClass
+				iNext = iEnd
+			}
+		}
+
+		for j := 0; j <= len(strTame); j++ {
+			if strWild[i] == '*' {
+				slcNext[j] = slcRow[j] || j > 0 && slcNext[j-1]
+			} else if j > 0 {
+				slcNext[j] = slcRow[j-1] && set.has(strTame[j-1])
+			}
+		}
+
+		slcRow = slcNext
+		i = iNext
+	}
+
+	return slcRow[len(strTame)]
+}
@@ -0,0 +1,56 @@
+// Go tests for matching wildcards via a plain dynamic-programming table.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import "testing"
+
+// Differential tests for SlowWildCompareAscii(), which must always agree
+// with FastWildCompareAscii().
+func TestSlowCompare(t *testing.T) {
+	for _, testCase := range []struct {
+		strWild   string
+		strTame   string
+		bExpected bool
+	}{
+		{"", "", true},
+		{"", "a", false},
+		{"*", "", true},
+		{"?", "", false},
+		{"a*b?d", "abbcd", true},
+		{"[a]", "a", true},
+		{"[a", "[a", true},
+	} {
+		if SlowWildCompareAscii(testCase.strWild,
+			testCase.strTame) != testCase.bExpected {
+			t.Errorf("SlowWildCompareAscii(%q, %q) = %t, want %t",
+				testCase.strWild, testCase.strTame, !testCase.bExpected,
+				testCase.bExpected)
+		}
+	}
+
+	for _, strWild := range allStrings([]string{"*", "?", "a", "[!a]",
+		"[a"}, 4) {
+		for _, strTame := range allStrings([]string{"a", "b", "["}, 5) {
+			bExpected := FastWildCompareAscii(strWild, strTame)
+
+			if SlowWildCompareAscii(strWild, strTame) != bExpected {
+				t.Errorf("SlowWildCompareAscii(%q, %q) = %t, want %t",
+					strWild, strTame, !bExpected, bExpected)
+			}
+		}
+	}
+}
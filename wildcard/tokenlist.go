@@ -0,0 +1,96 @@
+// Go routines for matching wildcards against lists of tokens.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+// A position in a list of tokens logically joined by single spaces.  The
+// offset of a token's length stands for the space after it, or for the
+// end, after the last token.
+type tokenCursor struct {
+	slcTokens []string
+	iToken    int
+	iOffset   int
+}
+
+// Reports whether the cursor has passed the last token.
+func (cursor *tokenCursor) atEnd() bool {
+	return cursor.iToken >= len(cursor.slcTokens)-1 &&
+		(len(cursor.slcTokens) == 0 ||
+			cursor.iOffset >= len(cursor.slcTokens[cursor.iToken]))
+}
+
+// Returns the byte at the cursor, which must not be at the end.
+func (cursor *tokenCursor) char() byte {
+	strToken := cursor.slcTokens[cursor.iToken]
+
+	if cursor.iOffset < len(strToken) {
+		return strToken[cursor.iOffset]
+	}
+
+	return ' '
+}
+
+// Moves the cursor to the next byte, which must not be at the end.
+func (cursor *tokenCursor) advance() {
+	if cursor.iOffset < len(cursor.slcTokens[cursor.iToken]) {
+		cursor.iOffset++
+	} else {
+		cursor.iToken++
+		cursor.iOffset = 0
+	}
+}
+
+// Compares an ASCII pattern against a list of tokens as if they were
+// joined by single spaces, as in strings.Join(slcTokens, " "), but without
+// building the joined string.  That's handy for matching command lines
+// held as argv-style slices.  The space between two tokens is an ordinary
+// byte that a literal ' ', a '?', or a '*' can match, so a '*' may span
+// several tokens.
+//
+// Since the joined content can only be walked from token to token, this
+// uses the single-fallback form of the algorithm, which never needs to
+// look back further than the position after the most recent '*'.
+func FastWildCompareTokens(strWild string, slcTokens []string) bool {
+	cursor := tokenCursor{slcTokens: slcTokens}
+	var cursorStar tokenCursor // Where content consumed by the last '*' ends
+	iWild := 0
+	iWildStar := -1 // Index of the '*' we can fall back to, if any
+
+	for !cursor.atEnd() {
+		if iWild < len(strWild) && strWild[iWild] == '*' {
+			iWildStar = iWild
+			cursorStar = cursor
+			iWild++
+		} else if iWild < len(strWild) &&
+			(strWild[iWild] == '?' || strWild[iWild] == cursor.char()) {
+			iWild++
+			cursor.advance()
+		} else if iWildStar >= 0 {
+			// Let the last '*' consume one more byte, and retry.
+			cursorStar.advance()
+			cursor = cursorStar
+			iWild = iWildStar + 1
+		} else {
+			return false
+		}
+	}
+
+	for iWild < len(strWild) && strWild[iWild] == '*' {
+		iWild++
+	}
+
+	return iWild == len(strWild)
+}
@@ -0,0 +1,87 @@
+// Go tests for the routines for matching wildcards against token lists.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import (
+	"strings"
+	"testing"
+)
+
+// Tests for FastWildCompareTokens(), including matches whose boundaries
+// fall on token boundaries.
+func TestFastWildCompareTokens(t *testing.T) {
+	slcArgs := []string{"git", "commit", "-m", "fix"}
+
+	for _, testCase := range []struct {
+		strWild   string
+		slcTokens []string
+		bExpected bool
+	}{
+		{"git commit -m fix", slcArgs, true},
+		{"git *", slcArgs, true},
+		{"* -m *", slcArgs, true},
+		{"git commit*", slcArgs, true},
+		{"git?commit?-m?fix", slcArgs, true},
+		{"gitcommit*", slcArgs, false},
+		{"git commit", slcArgs, false},
+		{"git commit -m fix ", slcArgs, false},
+
+		// A '*' spanning several tokens, and backtracking across boundaries.
+		{"g*x", slcArgs, true},
+		{"*m*m*", slcArgs, true},
+		{"*t c*", []string{"git", "commit", "git", "checkout"}, true},
+		{"*is?sip*", []string{"mis", "sis", "sippi"}, true},
+		{"*issip*", []string{"mis", "sis", "sippi"}, false},
+		{"*s s*", []string{"mis", "sis", "sippi"}, true},
+
+		// Empty tokens contribute only their spaces.
+		{"a  b", []string{"a", "", "b"}, true},
+		{" ", []string{"", ""}, true},
+		{"", []string{""}, true},
+		{"", nil, true},
+		{"*", nil, true},
+		{"?", nil, false},
+	} {
+		if FastWildCompareTokens(testCase.strWild,
+			testCase.slcTokens) != testCase.bExpected {
+			t.Errorf("FastWildCompareTokens(%q, %q) = %t, want %t",
+				testCase.strWild, testCase.slcTokens, !testCase.bExpected,
+				testCase.bExpected)
+		}
+	}
+
+	// Agreement with matching the joined string.
+	for _, strWild := range allStrings([]string{"*", "?", "a", " "}, 4) {
+		for _, strTame := range allStrings([]string{"a", "b", " "}, 5) {
+			slcWords := strings.Split(strTame, " ")
+			bExpected := FastWildCompareAscii(strWild, strTame)
+
+			if FastWildCompareTokens(strWild, slcWords) != bExpected {
+				t.Errorf("FastWildCompareTokens(%q, %q) = %t, want %t",
+					strWild, slcWords, !bExpected, bExpected)
+			}
+		}
+	}
+
+	// No allocations.
+	if iAllocs := testing.AllocsPerRun(100, func() {
+		FastWildCompareTokens("*t c*", slcArgs)
+	}); iAllocs != 0 {
+		t.Errorf("FastWildCompareTokens() allocates %v times per run",
+			iAllocs)
+	}
+}
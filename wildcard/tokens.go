@@ -0,0 +1,171 @@
+// Go routines for matching wildcards against patterns compiled to tokens.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// The extended pattern syntaxes have wildcards that '*' and '?' can't
+// express, such as a run limited to certain characters.  Those don't fit
+// the single-fallback approach of FastWildCompareAscii(), where a later '*'
+// can always stand in for an earlier one.  Instead, each such pattern is
+// compiled to a sequence of tokens and matched with a search that
+// remembers which (token, tame offset) pairs are dead ends, so that no pair
+// is explored twice.
+
+package wildcard
+
+import "math/bits"
+
+// A set of bytes, as a 256-bit bitmap.
+type byteSet [4]uint64
+
+// Adds a byte to the set.
+func (set *byteSet) add(c byte) {
+	set[c>>6] |= 1 << (c & 63)
+}
+
+// Adds each byte from cFirst through cLast to the set.
+func (set *byteSet) addRange(cFirst, cLast byte) {
+	for c := int(cFirst); c <= int(cLast); c++ {
+		set.add(byte(c))
+	}
+}
+
+// Reports whether a byte is in the set.
+func (set *byteSet) has(c byte) bool {
+	return set[c>>6]&(1<<(c&63)) != 0
+}
+
+// Removes a byte from the set.
+func (set *byteSet) remove(c byte) {
+	set[c>>6] &^= 1 << (c & 63)
+}
+
+// Returns the number of bytes in the set.
+func (set *byteSet) count() int {
+	return bits.OnesCount64(set[0]) + bits.OnesCount64(set[1]) +
+		bits.OnesCount64(set[2]) + bits.OnesCount64(set[3])
+}
+
+// The set of all bytes, as matched by '*' and '?'.
+var byteSetAll = byteSet{^uint64(0), ^uint64(0), ^uint64(0), ^uint64(0)}
+
+// Returns the set containing just one byte, as matched by a literal.
+func byteSetOf(c byte) byteSet {
+	var set byteSet
+	set.add(c)
+	return set
+}
+
+// One position in a compiled pattern: a run of at least iMin and at most
+// iMax tame bytes, each of which must be in set.  An iMax of -1 leaves the
+// run unbounded.
+type wildToken struct {
+	set  byteSet
+	iMin int
+	iMax int
+}
+
+// Returns a token matching exactly one byte from set.
+func singleToken(set byteSet) wildToken {
+	return wildToken{set, 1, 1}
+}
+
+// Returns a token matching a run of any length of bytes from set.
+func runToken(set byteSet) wildToken {
+	return wildToken{set, 0, -1}
+}
+
+// Compiles a '*'/'?' pattern to tokens, except that bytes for which
+// fnExtended returns true are replaced by the token it provides.
+func compileWildTokens(strWild string,
+	fnExtended func(c byte) (wildToken, bool)) []wildToken {
+	slcTokens := make([]wildToken, 0, len(strWild))
+
+	for i := 0; i < len(strWild); i++ {
+		if token, bExtended := fnExtended(strWild[i]); bExtended {
+			slcTokens = append(slcTokens, token)
+		} else if strWild[i] == '*' {
+			slcTokens = append(slcTokens, runToken(byteSetAll))
+		} else if strWild[i] == '?' {
+			slcTokens = append(slcTokens, singleToken(byteSetAll))
+		} else {
+			slcTokens = append(slcTokens, singleToken(byteSetOf(strWild[i])))
+		}
+	}
+
+	return slcTokens
+}
+
+// Reports whether the tame string or byte slice, in its entirety, matches
+// the tokens.
+func matchWildTokens[T string | []byte](slcTokens []wildToken, tame T) bool {
+	bMatch, _ := matchWildTokensLimit(slcTokens, tame, -1)
+	return bMatch
+}
+
+// Reports whether the tame string or byte slice, in its entirety, matches
+// the tokens, as matchWildTokens() does, but giving up once more than
+// iMaxSteps tame bytes have been compared against tokens, unless iMaxSteps
+// is negative.  Returns false along with true if the comparison gave up.
+func matchWildTokensLimit[T string | []byte](slcTokens []wildToken, tame T,
+	iMaxSteps int) (bool, bool) {
+	iStride := len(tame) + 1
+	slcDeadEnds := make([]uint64, (len(slcTokens)*iStride+63)/64)
+	iSteps := 0
+	bGaveUp := false
+
+	var matchFrom func(iToken, iTame int) bool
+	matchFrom = func(iToken, iTame int) bool {
+		if iToken == len(slcTokens) {
+			return iTame == len(tame)
+		}
+
+		iDeadEnd := iToken*iStride + iTame
+
+		if slcDeadEnds[iDeadEnd/64]&(1<<(iDeadEnd%64)) != 0 {
+			return false
+		}
+
+		token := &slcTokens[iToken]
+		iCount := 0
+
+		for {
+			if iCount >= token.iMin && matchFrom(iToken+1, iTame+iCount) {
+				return true
+			} else if bGaveUp {
+				return false
+			}
+
+			if iCount == token.iMax || iTame+iCount >= len(tame) {
+				break
+			}
+
+			iSteps++
+
+			if iMaxSteps >= 0 && iSteps > iMaxSteps {
+				bGaveUp = true
+				return false
+			} else if !token.set.has(tame[iTame+iCount]) {
+				break
+			}
+
+			iCount++
+		}
+
+		slcDeadEnds[iDeadEnd/64] |= 1 << (iDeadEnd % 64)
+		return false
+	}
+
+	return matchFrom(0, 0) && !bGaveUp, bGaveUp
+}
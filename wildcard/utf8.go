@@ -0,0 +1,278 @@
+// Go routine for matching wildcards in UTF-8 strings, rune by rune.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// The error reported by FastWildCompareUtf8Strict() for a pattern or tame
+// string that isn't valid UTF-8.
+var ErrInvalidUtf8 = errors.New("invalid UTF-8")
+
+// Compares two UTF-8 strings as FastWildCompareRuneSlices() compares the
+// rune slices converted from them, with identical results, but without
+// allocating those slices.  Runes are decoded on the fly, and the fallback
+// positions kept for each '*' are byte offsets.  A '?' matches one rune,
+// however many bytes encode it.  As with a conversion to []rune, each byte
+// of invalid UTF-8 is decoded as utf8.RuneError.
+//
+// Patterns with bracket classes are left to FastWildCompareRuneSlices(),
+// so for them, the rune slices are allocated after all.
+func FastWildCompareUtf8(strWild, strTame string) bool {
+	var iWild int = 0     // Byte offset into the pattern
+	var iTame int = 0     // Byte offset into the tame content
+	var iWildSequence int // Offset of prospective match after '*'
+	var iTameSequence int // Offset of match in tame content
+	var rWild, rTame rune // Runes decoded at the pattern and tame offsets
+	var iSize int         // Byte length of a decoded rune
+
+	// A pattern without wildcards can only match identical content, unless
+	// invalid UTF-8 on one side decodes to a utf8.RuneError on the other.
+	if !hasWildcards(strWild) && (strWild == strTame ||
+		utf8.ValidString(strWild) && utf8.ValidString(strTame)) {
+		return strWild == strTame
+	}
+
+	if strings.IndexByte(strWild, '[') >= 0 {
+		return FastWildCompareRuneSlices([]rune(strWild), []rune(strTame))
+	}
+
+	// Returns the offset of the rune following the one at the offset, or
+	// just the next offset past the end of the string.
+	nextRune := func(str string, i int) int {
+		if i >= len(str) {
+			return i + 1
+		}
+
+		_, iRuneSize := utf8.DecodeRuneInString(str[i:])
+		return i + iRuneSize
+	}
+
+	// Find a first wildcard, if one exists, and the beginning of any
+	// prospectively matching sequence after it.
+	for {
+		// Check for the end from the start.  Get out fast, if possible.
+		if len(strTame) <= iTame {
+			if len(strWild) > iWild {
+				for strWild[iWild] == '*' {
+					iWild++
+
+					if len(strWild) <= iWild {
+						return true // "ab" matches "ab*".
+					}
+				}
+
+				return false // "abcd" doesn't match "abc".
+			} else {
+				return true // "abc" matches "abc".
+			}
+		} else if len(strWild) <= iWild {
+			return false // "abc" doesn't match "abcd".
+		} else if strWild[iWild] == '*' {
+			// Got wild: set up for the second loop and skip on down there.
+			for {
+				iWild++
+
+				if len(strWild) <= iWild {
+					return true // "abc*" matches "abcd".
+				}
+
+				if strWild[iWild] != '*' {
+					break
+				}
+			}
+
+			// Search for the next prospective match.
+			if strWild[iWild] != '?' {
+				rWild, _ = utf8.DecodeRuneInString(strWild[iWild:])
+
+				for {
+					rTame, iSize = utf8.DecodeRuneInString(strTame[iTame:])
+
+					if rWild == rTame {
+						break
+					}
+
+					iTame += iSize
+
+					if len(strTame) <= iTame {
+						return false // "a*bc" doesn't match "ab".
+					}
+				}
+			}
+
+			// Keep fallback positions for retry in case of incomplete match.
+			iWildSequence = iWild
+			iTameSequence = iTame
+			break
+		}
+
+		rWild, iSize = utf8.DecodeRuneInString(strWild[iWild:])
+		iWild += iSize
+		rTame, iSize = utf8.DecodeRuneInString(strTame[iTame:])
+		iTame += iSize
+
+		if rWild != rTame && rWild != '?' {
+			return false // "abc" doesn't match "abd".
+		}
+	}
+
+	// Find any further wildcards and any further matching sequences.
+	for {
+		if len(strWild) > iWild && strWild[iWild] == '*' {
+			// Got wild again.
+			for {
+				iWild++
+
+				if len(strWild) <= iWild {
+					return true // "ab*c*" matches "abcd".
+				}
+
+				if strWild[iWild] != '*' {
+					break
+				}
+			}
+
+			if len(strTame) <= iTame {
+				return false // "*bcd*" doesn't match "abc".
+			}
+
+			// Search for the next prospective match.
+			if strWild[iWild] != '?' {
+				rWild, _ = utf8.DecodeRuneInString(strWild[iWild:])
+
+				for {
+					rTame, iSize = utf8.DecodeRuneInString(strTame[iTame:])
+
+					if rWild == rTame {
+						break
+					}
+
+					iTame += iSize
+
+					if len(strTame) <= iTame {
+						return false // "a*b*c" doesn't match "ab".
+					}
+				}
+			}
+
+			// Keep the new fallback positions.
+			iWildSequence = iWild
+			iTameSequence = iTame
+		} else {
+			// The equivalent portion of the upper loop is really simple.
+			if len(strTame) <= iTame {
+				if len(strWild) <= iWild {
+					return true // "*b*c" matches "abc".
+				}
+
+				return false // "*bcd" doesn't match "abc".
+			}
+
+			bMismatch := len(strWild) <= iWild
+
+			if !bMismatch {
+				rWild, _ = utf8.DecodeRuneInString(strWild[iWild:])
+				rTame, _ = utf8.DecodeRuneInString(strTame[iTame:])
+				bMismatch = rWild != rTame && rWild != '?'
+			}
+
+			if bMismatch {
+				// A fine time for questions.
+				for len(strWild) > iWildSequence &&
+					strWild[iWildSequence] == '?' {
+					iWildSequence++
+					iTameSequence = nextRune(strTame, iTameSequence)
+				}
+
+				iWild = iWildSequence
+
+				// Fall back, but never so far again.
+				for {
+					iTameSequence = nextRune(strTame, iTameSequence)
+
+					if len(strTame) <= iTameSequence {
+						if len(strWild) <= iWild {
+							return true // "*a*b" matches "ab".
+						} else {
+							return false // "*a*b" doesn't match "ac".
+						}
+					}
+
+					if len(strWild) > iWild {
+						rWild, _ = utf8.DecodeRuneInString(strWild[iWild:])
+						rTame, _ = utf8.DecodeRuneInString(
+							strTame[iTameSequence:])
+
+						if rWild == rTame {
+							break
+						}
+					}
+				}
+
+				iTame = iTameSequence
+			}
+		}
+
+		// Another check for the end, at the end.
+		if len(strTame) <= iTame {
+			if len(strWild) <= iWild {
+				return true // "*bc" matches "abc".
+			}
+
+			return false // "*bc" doesn't match "abcd".
+		}
+
+		iWild = nextRune(strWild, iWild) // Everything's still a match.
+		iTame = nextRune(strTame, iTame)
+	}
+}
+
+// Compares two UTF-8 strings as FastWildCompareUtf8() does, but only if
+// both are valid UTF-8, for callers that would rather reject malformed
+// input than have it match in surprising ways.  FastWildCompareUtf8()
+// decodes each byte of invalid UTF-8 as utf8.RuneError, so a lone
+// continuation byte, or a sequence cut short, matches a '?', or a literal
+// U+FFFD, or any other invalid byte.  Here, the first invalid byte in
+// either string is instead reported via an error wrapping ErrInvalidUtf8,
+// naming the string and the byte offset, along with false.
+func FastWildCompareUtf8Strict(strWild, strTame string) (bool, error) {
+	for _, input := range []struct {
+		strName string
+		str     string
+	}{
+		{"pattern", strWild},
+		{"tame string", strTame},
+	} {
+		for i := 0; i < len(input.str); {
+			r, iSize := utf8.DecodeRuneInString(input.str[i:])
+
+			if r == utf8.RuneError && iSize == 1 {
+				return false, fmt.Errorf("%w in %s at offset %d",
+					ErrInvalidUtf8, input.strName, i)
+			}
+
+			i += iSize
+		}
+	}
+
+	return FastWildCompareUtf8(strWild, strTame), nil
+}
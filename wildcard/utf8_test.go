@@ -0,0 +1,190 @@
+// Go tests for matching wildcards in UTF-8 strings, rune by rune.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import (
+	"errors"
+	"testing"
+	"unicode/utf8"
+)
+
+// Returns every string of up to iMaxLen elements drawn from slcAlphabet.
+func allStrings(slcAlphabet []string, iMaxLen int) []string {
+	slcStrings := []string{""}
+	slcLonger := []string{""}
+
+	for iLen := 1; iLen <= iMaxLen; iLen++ {
+		var slcNext []string
+
+		for _, str := range slcLonger {
+			for _, strElem := range slcAlphabet {
+				slcNext = append(slcNext, str+strElem)
+			}
+		}
+
+		slcStrings = append(slcStrings, slcNext...)
+		slcLonger = slcNext
+	}
+
+	return slcStrings
+}
+
+// Tests for FastWildCompareUtf8(), which must agree with
+// FastWildCompareRuneSlices() without being handed rune slices.
+func TestFastWildCompareUtf8(t *testing.T) {
+	for _, testCase := range []struct {
+		strTame   string
+		strWild   string
+		bExpected bool
+	}{
+		// Each byte of invalid UTF-8 is decoded as utf8.RuneError.
+		{"\xff", "\uFFFD", true},
+		{"\uFFFD", "\xff", true},
+		{"\xffa", "?a", true},
+		{"\xe2\x98", "??", true},
+		{"\xe2\x98", "?", false},
+		{"★\xe2\x98", "*\xff\xff", true},
+		{"★\xe2\x98", "?\xff", false},
+		{"\xff\xfe", "\xfe\xff", true},
+	} {
+		if FastWildCompareUtf8(testCase.strWild,
+			testCase.strTame) != testCase.bExpected {
+			t.Errorf("FastWildCompareUtf8(%q, %q) = %t, want %t",
+				testCase.strWild, testCase.strTame, !testCase.bExpected,
+				testCase.bExpected)
+		}
+
+		if FastWildCompareRuneSlices([]rune(testCase.strWild),
+			[]rune(testCase.strTame)) != testCase.bExpected {
+			t.Errorf("FastWildCompareRuneSlices(%q, %q) = %t, want %t",
+				testCase.strWild, testCase.strTame, !testCase.bExpected,
+				testCase.bExpected)
+		}
+	}
+}
+
+// Every short pattern and tame string built from a few runes of different
+// lengths, along with invalid UTF-8, gets the same result as from the rune
+// slice matcher.
+func TestFastWildCompareUtf8Parity(t *testing.T) {
+	slcWilds := allStrings([]string{"*", "?", "a", "★", "𓋍", "\xff"}, 4)
+	slcTames := allStrings([]string{"a", "★", "𓋍", "\xff", "�"}, 5)
+
+	for _, strWild := range slcWilds {
+		rslcWild := []rune(strWild)
+
+		for _, strTame := range slcTames {
+			bExpected := FastWildCompareRuneSlices(rslcWild, []rune(strTame))
+
+			if FastWildCompareUtf8(strWild, strTame) != bExpected {
+				t.Fatalf("FastWildCompareUtf8(%q, %q) = %t, want %t",
+					strWild, strTame, !bExpected, bExpected)
+			}
+		}
+	}
+}
+
+// Tests for FastWildCompareUtf8Strict(), which rejects invalid UTF-8 that
+// FastWildCompareUtf8() decodes as utf8.RuneError.
+func TestUtf8Strict(t *testing.T) {
+	// Valid input gets the results of FastWildCompareUtf8().
+	for _, testCase := range []struct {
+		strWild   string
+		strTame   string
+		bExpected bool
+	}{
+		{"caf?", "café", true},
+		{"*ße", "straße", true},
+		{"*ße", "strasse", false},
+		{"?", "�", true},
+	} {
+		if bMatch, err := FastWildCompareUtf8Strict(testCase.strWild,
+			testCase.strTame); bMatch != testCase.bExpected || err != nil {
+			t.Errorf("FastWildCompareUtf8Strict(%q, %q) = %t, %v; want "+
+				"%t, nil", testCase.strWild, testCase.strTame, bMatch, err,
+				testCase.bExpected)
+		}
+	}
+
+	// A truncated multi-byte sequence, each byte of which
+	// FastWildCompareUtf8() matches against a '?', is rejected in either
+	// string.  So is a lone continuation byte, which FastWildCompareUtf8()
+	// matches against any other invalid byte.
+	for _, testCase := range []struct {
+		strWild string
+		strTame string
+	}{
+		{"caf?", "caf\xc3"},
+		{"??", "\xe2\x82"},
+		{"*\x80", "ab\xbf"},
+	} {
+		if !FastWildCompareUtf8(testCase.strWild, testCase.strTame) {
+			t.Errorf("FastWildCompareUtf8(%q, %q) = false, want true",
+				testCase.strWild, testCase.strTame)
+		}
+	}
+
+	// Each is rejected at the offset of its first invalid byte, as are
+	// encodings that Go never decodes, such as an overlong '/' or a
+	// surrogate half.
+	for _, testCase := range []struct {
+		strWild  string
+		strTame  string
+		strError string
+	}{
+		{"caf?", "caf\xc3", "invalid UTF-8 in tame string at offset 3"},
+		{"??", "\xe2\x82", "invalid UTF-8 in tame string at offset 0"},
+		{"a\xf0\x9f\x98*", "a", "invalid UTF-8 in pattern at offset 1"},
+		{"*\x80", "ab\xbf", "invalid UTF-8 in pattern at offset 1"},
+		{"*", "é\x80", "invalid UTF-8 in tame string at offset 2"},
+		{"?", "\xc0\xaf", "invalid UTF-8 in tame string at offset 0"},
+		{"?", "\xed\xa0\x80", "invalid UTF-8 in tame string at offset 0"},
+	} {
+		bMatch, err := FastWildCompareUtf8Strict(testCase.strWild,
+			testCase.strTame)
+
+		if bMatch || !errors.Is(err, ErrInvalidUtf8) ||
+			err.Error() != testCase.strError {
+			t.Errorf("FastWildCompareUtf8Strict(%q, %q) = %t, %v; want "+
+				"false, %s", testCase.strWild, testCase.strTame, bMatch,
+				err, testCase.strError)
+		}
+	}
+
+	// Each valid comparison gets the same result as from
+	// FastWildCompareUtf8(), and each other one is rejected.
+	for _, strWild := range allStrings([]string{"*", "?", "a",
+		"é", "\xc3"}, 4) {
+		for _, strTame := range allStrings([]string{"a", "é",
+			"\xc3", "\xa9"}, 4) {
+			bMatch, err := FastWildCompareUtf8Strict(strWild, strTame)
+
+			if utf8.ValidString(strWild) && utf8.ValidString(strTame) {
+				if bExpected := FastWildCompareUtf8(strWild,
+					strTame); bMatch != bExpected || err != nil {
+					t.Errorf("FastWildCompareUtf8Strict(%q, %q) = %t, %v; "+
+						"want %t, nil", strWild, strTame, bMatch, err,
+						bExpected)
+				}
+			} else if bMatch || !errors.Is(err, ErrInvalidUtf8) {
+				t.Errorf("FastWildCompareUtf8Strict(%q, %q) = %t, %v; "+
+					"want false, %v", strWild, strTame, bMatch, err,
+					ErrInvalidUtf8)
+			}
+		}
+	}
+}
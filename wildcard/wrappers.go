@@ -0,0 +1,642 @@
+// Go routines adapting the matching wildcards routines to common tasks.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"sort"
+	"strings"
+)
+
+// How many bytes Options.WindowMatch() reads from its stream at a time,
+// unless Options.MaxBuffer is smaller.
+const streamChunkSize = 4096
+
+// Compares an ASCII pattern against just the first n bytes of a tame
+// string, ignoring the rest, as for checking a file's magic number.  The
+// match is anchored at both ends of those n bytes, so "PK??" matches the
+// first 4 bytes of a zip file but "PK?" doesn't.  If n exceeds the length
+// of the tame string, the whole tame string is compared.  A negative n is
+// treated as 0.
+func MatchPrefixN(strWild, strTame string, n int) bool {
+	if n < 0 {
+		n = 0
+	} else if n > len(strTame) {
+		n = len(strTame)
+	}
+
+	return FastWildCompareAscii(strWild, strTame[:n])
+}
+
+// Compares an ASCII pattern against a tame string, where a pattern that
+// begins with '!' is negated, as in ignore-file syntaxes.  So "!*.tmp"
+// matches exactly the strings that "*.tmp" doesn't.  Only the first '!'
+// negates; the rest of the pattern is compared as usual.  A pattern that
+// begins with "\!" matches a literal leading '!' instead.  Backslashes
+// elsewhere in the pattern are literal.
+func MatchNegatable(strWild, strTame string) bool {
+	if strings.HasPrefix(strWild, "!") {
+		return !FastWildCompareAscii(strWild[1:], strTame)
+	} else if strings.HasPrefix(strWild, "\\!") {
+		strWild = strWild[1:]
+	}
+
+	return FastWildCompareAscii(strWild, strTame)
+}
+
+// Compares an ASCII pattern against a tame string, after stripping any
+// comment from the pattern, so that rule files can carry inline notes such
+// as "*.log # error logs".  A comment begins at a '#' that starts the
+// pattern or follows a space or tab, and runs to the end of the pattern.
+// The spaces and tabs just ahead of it are stripped along with it, so that
+// example matches just what "*.log" does.  A '#' elsewhere, as in
+// "issue#*", is literal, and "\#" matches a literal '#' anywhere, so
+// "* \#1" matches "take #1".  Backslashes elsewhere in the pattern are
+// literal.
+func MatchAnnotated(strWild, strTame string) bool {
+	var sb strings.Builder
+	bComment := false
+
+	for i := 0; i < len(strWild); i++ {
+		if strWild[i] == '\\' && i+1 < len(strWild) && strWild[i+1] == '#' {
+			sb.WriteByte('#')
+			i++
+		} else if strWild[i] == '#' &&
+			(i == 0 || strWild[i-1] == ' ' || strWild[i-1] == '\t') {
+			bComment = true
+			break
+		} else {
+			sb.WriteByte(strWild[i])
+		}
+	}
+
+	strWild = sb.String()
+
+	if bComment {
+		strWild = strings.TrimRight(strWild, " \t")
+	}
+
+	return FastWildCompareAscii(strWild, strTame)
+}
+
+// Compares an ASCII pattern against the decompressed content of a
+// gzip-compressed stream, as for scanning compressed logs.  The whole
+// decompressed content is compared as one tame string.  If the stream
+// can't be decompressed, the error from the gzip package is returned along
+// with false, so that a corrupt stream isn't mistaken for a non-match.
+//
+// The decompressed content is kept whole, so with a MaxBuffer, a stream
+// that decompresses to more than that many bytes is reported via
+// ErrBufferLimit, however little of it was compressed.
+func (opt Options) FastWildCompareGzip(strWild string, r io.Reader) (bool,
+	error) {
+	zr, err := gzip.NewReader(r)
+
+	if err != nil {
+		return false, err
+	}
+
+	defer zr.Close()
+
+	// Bring in the decompressed content, since the algorithm may need to
+	// fall back to any position after a '*'.
+	slcTame, err := readAllLimit(zr, opt.MaxBuffer)
+
+	if err != nil {
+		return false, err
+	}
+
+	return FastWildCompareAscii(strWild, string(slcTame)), nil
+}
+
+// Calls Options.FastWildCompareGzip() with no buffer limit.
+func FastWildCompareGzip(strWild string, r io.Reader) (bool, error) {
+	return Options{}.FastWildCompareGzip(strWild, r)
+}
+
+// Reads a stream to its end, as io.ReadAll() does, unless that would take
+// more than iMax bytes, in which case ErrBufferLimit is returned.  A zero
+// or negative iMax sets no limit.
+func readAllLimit(r io.Reader, iMax int) ([]byte, error) {
+	if iMax <= 0 {
+		return io.ReadAll(r)
+	}
+
+	slcContent, err := io.ReadAll(io.LimitReader(r, int64(iMax)+1))
+
+	if err == nil && len(slcContent) > iMax {
+		return nil, ErrBufferLimit
+	}
+
+	return slcContent, err
+}
+
+// Returns how many leading bytes of a tame string are consistent with an
+// ASCII pattern, for an autocomplete UI that greys out input past the point
+// where it could no longer match.  The first n bytes are consistent if
+// some continuation of them would match the pattern, so the result is
+// len(strTame) for a tame string that matches, or that could still be
+// completed to match.  For example, with the pattern "ab*yz", the result
+// for "abcy" is 4, and for "axyz" it's 1.
+//
+// The positions in the pattern that each tame prefix can reach are tracked
+// together, so the cost is proportional to the product of the lengths.
+// Bracket classes aren't supported, so a '[' is always a literal.
+func MatchablePrefixLen(strWild, strTame string) int {
+	slcReached := make([]bool, len(strWild)+1)
+	slcNext := make([]bool, len(strWild)+1)
+
+	// Marks iWild as reached, along with any positions past the stars
+	// starting there, since a '*' can match nothing.
+	reach := func(slcSet []bool, iWild int) {
+		for ; iWild < len(strWild) && strWild[iWild] == '*'; iWild++ {
+			slcSet[iWild] = true
+		}
+
+		slcSet[iWild] = true
+	}
+
+	reach(slcReached, 0)
+
+	for iTame := 0; iTame < len(strTame); iTame++ {
+		bAny := false
+
+		for iWild := range slcNext {
+			slcNext[iWild] = false
+		}
+
+		for iWild := 0; iWild < len(strWild); iWild++ {
+			if !slcReached[iWild] {
+				continue
+			} else if strWild[iWild] == '*' {
+				reach(slcNext, iWild)
+				bAny = true
+			} else if strWild[iWild] == '?' || strWild[iWild] == strTame[iTame] {
+				reach(slcNext, iWild+1)
+				bAny = true
+			}
+		}
+
+		if !bAny {
+			return iTame
+		}
+
+		slcReached, slcNext = slcNext, slcReached
+	}
+
+	return len(strTame)
+}
+
+// Reads lines from a stream and calls onMatch for each line that matches
+// an ASCII pattern, as for following a log as it grows.  Scanning stops
+// early if onMatch returns false.  Lines are split as by bufio.ScanLines(),
+// so the end-of-line marker, including any carriage return, isn't part of
+// the line.  Returns any error from reading the stream, including
+// bufio.ErrTooLong for a line too long to buffer, or nil once the stream
+// ends or onMatch asks to stop.
+//
+// With a MaxBuffer, the buffer holding each line, along with its
+// end-of-line marker, is kept to that many bytes, and a longer line is
+// reported via ErrBufferLimit rather than bufio.ErrTooLong.
+func (opt Options) ScanMatches(strWild string, r io.Reader,
+	onMatch func(strLine string) bool) error {
+	scanner := bufio.NewScanner(r)
+
+	if opt.MaxBuffer > 0 {
+		scanner.Buffer(nil, opt.MaxBuffer)
+	}
+
+	for scanner.Scan() {
+		strLine := scanner.Text()
+
+		if FastWildCompareAscii(strWild, strLine) && !onMatch(strLine) {
+			return nil
+		}
+	}
+
+	if opt.MaxBuffer > 0 && scanner.Err() == bufio.ErrTooLong {
+		return ErrBufferLimit
+	}
+
+	return scanner.Err()
+}
+
+// Calls Options.ScanMatches() with no buffer limit.
+func ScanMatches(strWild string, r io.Reader,
+	onMatch func(strLine string) bool) error {
+	return Options{}.ScanMatches(strWild, r, onMatch)
+}
+
+// Reads a stream until some stretch of it, no longer than window bytes,
+// matches an ASCII pattern, as for raising an alert as soon as a log being
+// followed shows a pattern of events within a short span.  The match is
+// unanchored, so "ERROR*disk" finds "ERROR: disk" anywhere in the stream,
+// but only if the stretch from the 'E' through the 'k' fits in the window.
+// Returns true once a match is found, without reading further, or false
+// at the end of the stream.  Any error from reading the stream is returned
+// with false.  A pattern made only of '*'s matches an empty stretch, and so
+// matches before anything is read.
+//
+// The positions in the pattern that the stream has reached are tracked
+// together, each with the latest stream offset where a match leading to it
+// could have started, so no stream content is kept, and memory is
+// proportional to the length of the pattern, however large the window.
+// The stream is read a chunk at a time, and with a MaxBuffer, each chunk
+// is no larger than that, so WindowMatch() never keeps too much of the
+// stream, and never returns ErrBufferLimit.
+func (opt Options) WindowMatch(strWild string, r io.Reader,
+	window int) (bool, error) {
+	slcStart := make([]int, len(strWild)+1)
+	slcNext := make([]int, len(strWild)+1)
+
+	// Marks iWild as reached via a match that started at offset iStart,
+	// along with any positions past the stars starting there, unless a
+	// later start already reached them.
+	reach := func(slcSet []int, iWild, iStart int) {
+		for ; iWild < len(strWild) && strWild[iWild] == '*'; iWild++ {
+			slcSet[iWild] = max(slcSet[iWild], iStart)
+		}
+
+		slcSet[iWild] = max(slcSet[iWild], iStart)
+	}
+
+	for iWild := range slcStart {
+		slcStart[iWild] = -1
+	}
+
+	reach(slcStart, 0, 0)
+
+	if slcStart[len(strWild)] >= 0 {
+		return true, nil
+	}
+
+	slcChunk := make([]byte, streamChunkSize)
+
+	if opt.MaxBuffer > 0 && opt.MaxBuffer < streamChunkSize {
+		slcChunk = slcChunk[:opt.MaxBuffer]
+	}
+
+	for iOffset := 0; ; {
+		n, err := r.Read(slcChunk)
+
+		for _, c := range slcChunk[:n] {
+			// A match may start with this byte.
+			reach(slcStart, 0, iOffset)
+
+			for iWild := range slcNext {
+				slcNext[iWild] = -1
+			}
+
+			for iWild := 0; iWild < len(strWild); iWild++ {
+				if slcStart[iWild] < 0 {
+					continue
+				} else if strWild[iWild] == '*' {
+					reach(slcNext, iWild, slcStart[iWild])
+				} else if strWild[iWild] == '?' || strWild[iWild] == c {
+					reach(slcNext, iWild+1, slcStart[iWild])
+				}
+			}
+
+			slcStart, slcNext = slcNext, slcStart
+
+			if iStart := slcStart[len(strWild)]; iStart >= 0 &&
+				iOffset-iStart < window {
+				return true, nil
+			}
+
+			iOffset++
+		}
+
+		if err == io.EOF {
+			return false, nil
+		} else if err != nil {
+			return false, err
+		}
+	}
+}
+
+// Calls Options.WindowMatch() with no buffer limit.
+func WindowMatch(strWild string, r io.Reader, window int) (bool, error) {
+	return Options{}.WindowMatch(strWild, r, window)
+}
+
+// Compares an ASCII pattern against the whole content of a stream, as
+// FastWildCompareAscii() compares it against a tame string, but without
+// reading the content into memory first, as for checking a large file.
+// Any error from reading the stream is returned with false.  Reading stops
+// as soon as the result is known, so "abc*" needs only three bytes of a
+// stream, while "*abc" needs all of it.
+//
+// The pattern is split at its '*'s into segments, as by Compile().  Each
+// byte that the first segment covers is compared as it's read, so a
+// pattern without any '*' needs no buffer at all.  Each segment in between
+// is searched for via a window holding the latest bytes read, only as many
+// as the segment has, and the last segment is compared against a window
+// holding the last bytes of the stream.  So the memory needed depends on
+// the longest segment, not on the length of the stream.  A pattern with
+// bracket classes is compared against the whole content, read in at once.
+//
+// With a MaxBuffer, ErrBufferLimit is returned once a window would hold
+// more bytes than that, as it would for a segment longer than MaxBuffer,
+// or for a stream longer than that, for a pattern with bracket classes.
+func (opt Options) MatchReader(strWild string, r io.Reader) (bool, error) {
+	reader := bufio.NewReader(r)
+
+	if strings.IndexByte(strWild, '[') >= 0 {
+		slcTame, err := readAllLimit(reader, opt.MaxBuffer)
+
+		if err != nil {
+			return false, err
+		}
+
+		return FastWildCompareAscii(strWild, string(slcTame)), nil
+	}
+
+	pattern, _ := Compile(strWild)
+	slcSegments := pattern.slcSegments
+	first := &slcSegments[0]
+	last := &slcSegments[len(slcSegments)-1]
+	var slcWindow []byte
+
+	// Reads the next byte into the window, dropping the earliest byte from
+	// the window if it would otherwise hold more than iKeep bytes.  Reports
+	// false at the end of the stream.
+	readByte := func(iKeep int) (bool, error) {
+		c, err := reader.ReadByte()
+
+		if err == io.EOF {
+			return false, nil
+		} else if err != nil {
+			return false, err
+		}
+
+		slcWindow = append(slcWindow, c)
+
+		if len(slcWindow) > iKeep {
+			slcWindow = slcWindow[1:]
+		}
+
+		if opt.MaxBuffer > 0 && len(slcWindow) > opt.MaxBuffer {
+			return false, ErrBufferLimit
+		}
+
+		return true, nil
+	}
+
+	// Reports whether the window holds just what a segment matches.
+	bWindowMatches := func(segment *patternSegment) bool {
+		if len(slcWindow) != len(segment.strRun) {
+			return false
+		}
+
+		for i := 0; i < len(slcWindow); i++ {
+			if segment.strRun[i] != '?' && segment.strRun[i] != slcWindow[i] {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	// The first segment must match at the start of the stream.
+	for i := 0; i < len(first.strRun); i++ {
+		bRead, err := readByte(1)
+
+		if !bRead || err != nil {
+			return false, err // "abc" doesn't match "ab".
+		} else if first.strRun[i] != '?' && first.strRun[i] != slcWindow[0] {
+			return false, nil // "abc" doesn't match "abd".
+		}
+	}
+
+	// A pattern without any '*' matches only if the stream ends here.
+	if len(slcSegments) == 1 {
+		bRead, err := readByte(1)
+		return !bRead && err == nil, err
+	}
+
+	// Each segment between '*'s is found at the first place it can be,
+	// which never rules out a match that a later place would allow.
+	for i := 1; i < len(slcSegments)-1; i++ {
+		segment := &slcSegments[i]
+		slcWindow = slcWindow[:0]
+
+		for !bWindowMatches(segment) {
+			bRead, err := readByte(len(segment.strRun))
+
+			if !bRead || err != nil {
+				return false, err // "a*b*c" doesn't match "ac".
+			}
+		}
+	}
+
+	// A pattern that ends with a '*' matches whatever follows.
+	if len(last.strRun) == 0 {
+		return true, nil
+	}
+
+	// The last segment must match at the end of the stream, after what the
+	// other segments matched.
+	slcWindow = slcWindow[:0]
+
+	for {
+		bRead, err := readByte(len(last.strRun))
+
+		if err != nil {
+			return false, err
+		} else if !bRead {
+			return bWindowMatches(last), nil
+		}
+	}
+}
+
+// Calls Options.MatchReader() with no buffer limit.
+func MatchReader(strWild string, r io.Reader) (bool, error) {
+	return Options{}.MatchReader(strWild, r)
+}
+
+// Returns the length of the longest run of literal characters from an
+// ASCII pattern that appears anywhere in a tame string, for ranking search
+// candidates even when none of them matches the whole pattern.  A run is
+// any part of the pattern between its wildcards, or any part of such a
+// part, so for the pattern "*report?final*", the tame string "final draft"
+// scores 5 and "reporting" scores 6.
+func LongestLiteralMatch(strWild, strTame string) int {
+	iLongest := 0
+
+	// Row of a longest-common-substring table: the length of the common run
+	// ending at the current pattern byte and at each tame byte.
+	slcRun := make([]int, len(strTame)+1)
+
+	for iWild := 0; iWild < len(strWild); iWild++ {
+		c := strWild[iWild]
+
+		for iTame := len(strTame); iTame > 0; iTame-- {
+			if c != '*' && c != '?' && c == strTame[iTame-1] {
+				slcRun[iTame] = slcRun[iTame-1] + 1
+
+				if slcRun[iTame] > iLongest {
+					iLongest = slcRun[iTame]
+				}
+			} else {
+				slcRun[iTame] = 0
+			}
+		}
+	}
+
+	return iLongest
+}
+
+// Compares an ASCII pattern against a tame string, after checking that the
+// pattern is well formed, so that callers needn't validate it separately.
+// A bracket class with a range that ends before it starts, such as
+// "[z-a]", is malformed, and is reported via ErrEmptyRange along with
+// false.  Every other pattern is well formed, so the error result is nil.
+func MatchValidated(strWild, strTame string) (bool, error) {
+	if strings.IndexByte(strWild, '[') < 0 {
+		return FastWildCompareAscii(strWild, strTame), nil
+	}
+
+	slcTokens, err := compileClassTokens(strWild, false)
+
+	if err != nil {
+		return false, err
+	}
+
+	return matchClassTokens(slcTokens, strTame), nil
+}
+
+// Compares an ASCII pattern against a tame string, where the pattern
+// declares its own anchoring, as in grep.  A leading '^' anchors the
+// pattern to the start of the tame string, and a trailing '$' anchors it
+// to the end.  Without either, the pattern may match anywhere within the
+// tame string.  So "^abc" matches tame strings that start with "abc",
+// "abc$" matches those that end with it, "^abc$" matches just "abc", and
+// "abc" matches any that contain it.  A "\^" at the start or a "\$" at the
+// end, just inside any anchors, matches a literal '^' or '$', so "^\^$"
+// matches just "^".  Backslashes elsewhere in the pattern are literal.
+func MatchAnchored(strWild, strTame string) bool {
+	bAnchorStart := false
+	bAnchorEnd := false
+
+	if strings.HasPrefix(strWild, "^") {
+		bAnchorStart = true
+		strWild = strWild[1:]
+	}
+
+	if strings.HasSuffix(strWild, "$") && !strings.HasSuffix(strWild, "\\$") {
+		bAnchorEnd = true
+		strWild = strWild[:len(strWild)-1]
+	}
+
+	// Unescape a literal '^' or '$' just inside any anchors.
+	if strings.HasPrefix(strWild, "\\^") {
+		strWild = strWild[1:]
+	}
+
+	if strings.HasSuffix(strWild, "\\$") {
+		strWild = strWild[:len(strWild)-2] + "$"
+	}
+
+	if !bAnchorStart {
+		strWild = "*" + strWild
+	}
+
+	if !bAnchorEnd {
+		strWild += "*"
+	}
+
+	return FastWildCompareAscii(strWild, strTame)
+}
+
+// Compares an ASCII pattern against each line of a multiline tame string,
+// as MatchAnchored() compares it against a whole string, reporting whether
+// any line matches.  So a leading '^' matches at the start of the string or
+// just after any '\n', and a trailing '$' matches just before any '\n' or
+// at the end of the string.  "^ERROR" matches a buffer in which any line
+// starts with "ERROR", and "^ERROR*disk$" one in which a line starts with
+// "ERROR" and ends with "disk".  A match never spans lines, so a '*' or '?'
+// never matches a '\n'.  Lines are found in place, so the tame string is
+// never split or copied.
+func MatchMultiline(strWild, strTame string) bool {
+	for {
+		iNewline := strings.IndexByte(strTame, '\n')
+
+		if iNewline < 0 {
+			return MatchAnchored(strWild, strTame)
+		}
+
+		if MatchAnchored(strWild, strTame[:iNewline]) {
+			return true
+		}
+
+		strTame = strTame[iNewline+1:]
+	}
+}
+
+// Returns the literal prefix of an ASCII pattern, which every tame string
+// that matches the pattern begins with, as regexp.Regexp.LiteralPrefix()
+// does for a regular expression.  This suits search systems that scan an
+// index for the keys beginning with the prefix before comparing each one
+// against the whole pattern.  The prefix is the part of the pattern before
+// its first '*', '?', or bracket class, and exact is true if the pattern
+// has none of those, so that it matches only the prefix itself.  A '['
+// that's never closed is a literal, as FastWildCompareAscii() treats it.
+// So "abc*" has the prefix "abc", "abc" has the exact prefix "abc", and
+// "*abc" has an empty prefix.
+func LiteralPrefix(strWild string) (strPrefix string, bExact bool) {
+	for i := 0; i < len(strWild); i++ {
+		if strWild[i] == '*' || strWild[i] == '?' {
+			return strWild[:i], false
+		} else if strWild[i] == '[' {
+			if _, iNext, _ := parseClass(strWild, i, false); iNext >= 0 {
+				return strWild[:i], false
+			}
+		}
+	}
+
+	return strWild, true
+}
+
+// Returns the keys from a sorted slice that match an ASCII pattern, in
+// their sorted order, as for querying the keys of an ordered index.  Only
+// keys that begin with the pattern's literal prefix, the part before its
+// first wildcard, can match, and those keys are adjacent in sorted order.
+// So the range they occupy is found by binary search, and only the keys in
+// that range are compared against the whole pattern.  A pattern such as
+// "user:42:*" thus costs little more than a lookup, while one that begins
+// with a wildcard still compares every key.
+//
+// The keys must be sorted in increasing order, as by sort.Strings().
+func MatchRangeInSorted(slcSortedKeys []string, strWild string) []string {
+	strPrefix, _ := LiteralPrefix(strWild)
+	var slcMatches []string
+
+	iFirst := sort.SearchStrings(slcSortedKeys, strPrefix)
+
+	for _, strKey := range slcSortedKeys[iFirst:] {
+		if !strings.HasPrefix(strKey, strPrefix) {
+			break // Past the range of keys that begin with the prefix.
+		} else if FastWildCompareAscii(strWild, strKey) {
+			slcMatches = append(slcMatches, strKey)
+		}
+	}
+
+	return slcMatches
+}
@@ -0,0 +1,781 @@
+// Go tests for the convenience wrappers around the matching routines.
+//
+// Copyright 2025 Kirk J Krauss.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wildcard
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// Tests for MatchMultiline(), where '^' and '$' anchor a pattern to the
+// start or end of any line.
+func TestMatchMultiline(t *testing.T) {
+	strLog := "INFO starting up\n" +
+		"WARN low disk\n" +
+		"ERROR disk full on /var\n" +
+		"INFO retrying ERROR handler\n" +
+		"DEBUG done"
+
+	for _, testCase := range []struct {
+		strTame   string
+		strWild   string
+		bExpected bool
+	}{
+		// Anchors at the start of interior lines.
+		{strLog, "^ERROR", true},
+		{strLog, "^WARN*disk$", true},
+		{strLog, "^ERROR*/var$", true},
+		{strLog, "^ERROR*full", true},
+		{strLog, "^FATAL", false},
+		{strLog, "^retrying", false},
+
+		// Anchors at the end of interior and final lines.
+		{strLog, "up$", true},
+		{strLog, "handler$", true},
+		{strLog, "done$", true},
+		{strLog, "^DEBUG done$", true},
+		{strLog, "full$", false},
+
+		// Unanchored patterns match anywhere within a line.
+		{strLog, "retrying ERROR", true},
+		{strLog, "disk?full", true},
+		{strLog, "d?sk", true},
+
+		// Matches never span lines.
+		{strLog, "^INFO*disk$", false},
+		{strLog, "up?WARN", false},
+		{strLog, "^INFO*ERROR*/var$", false},
+		{"a\nb", "^a*b$", false},
+		{"a\nb", "a?b", false},
+
+		// Empty lines, and tame strings with no newline.
+		{"a\n\nb", "^$", true},
+		{"a\nb", "^$", false},
+		{"a\n", "^$", true},
+		{"", "^$", true},
+		{"", "*", true},
+		{"ERROR", "^ERROR$", true},
+		{"ERRORS", "^ERROR$", false},
+		{"x\n^y", "^\\^y", true},
+	} {
+		if MatchMultiline(testCase.strWild, testCase.strTame) !=
+			testCase.bExpected {
+			t.Errorf("MatchMultiline(%q, %q) = %t, want %t",
+				testCase.strWild, testCase.strTame, !testCase.bExpected,
+				testCase.bExpected)
+		}
+	}
+
+	// With no newlines, results are the same as MatchAnchored().
+	for _, strWild := range allStrings([]string{"*", "?", "a", "^", "$",
+		`\`}, 4) {
+		for _, strTame := range allStrings([]string{"a", "^", "$"}, 4) {
+			bExpected := MatchAnchored(strWild, strTame)
+
+			if MatchMultiline(strWild, strTame) != bExpected {
+				t.Errorf("MatchMultiline(%q, %q) = %t, want %t",
+					strWild, strTame, !bExpected, bExpected)
+			}
+		}
+	}
+}
+
+// Tests for MatchReader(), with the content read all at once and one byte
+// at a time.
+func TestMatchReader(t *testing.T) {
+	checkMatchReader := func(strWild, strTame string, bExpected bool) {
+		for _, r := range []io.Reader{
+			strings.NewReader(strTame),
+			iotest.OneByteReader(strings.NewReader(strTame)),
+		} {
+			if bMatch, err := MatchReader(strWild, r); bMatch != bExpected ||
+				err != nil {
+				t.Errorf("MatchReader(%q) on %q = %t, %v; want %t, nil",
+					strWild, strTame, bMatch, err, bExpected)
+			}
+		}
+	}
+
+	for _, testCase := range []struct {
+		strWild   string
+		strTame   string
+		bExpected bool
+	}{
+		{"abc", "abc", true},
+		{"abc", "ab", false},
+		{"abc", "abcd", false},
+		{"a?c", "abc", true},
+		{"", "", true},
+		{"*", "", true},
+		{"ab*", "ab", true},
+		{"*ab", "b", false},
+		{"ab*ba", "aba", false},
+		{"ab*ba", "abba", true},
+		{"*a?a*", "xxaabax", true},
+		{"*ERROR*disk*full", "2025-01-01 ERROR: disk 91% full", true},
+		{"[a-c]*[!x]", "by", true},
+		{"[a-c]*[!x]", "bx", false},
+	} {
+		checkMatchReader(testCase.strWild, testCase.strTame,
+			testCase.bExpected)
+	}
+
+	// Reading stops as soon as the result is known, before the stream's
+	// error, and any other error is returned.
+	errStream := errors.New("stream failed")
+
+	for _, testCase := range []struct {
+		strWild string
+		bMatch  bool
+		errWant error
+	}{
+		{"abc*", true, nil},
+		{"abd*", false, nil},
+		{"*def", false, errStream},
+	} {
+		bMatch, err := MatchReader(testCase.strWild, io.MultiReader(
+			strings.NewReader("abcdef"), iotest.ErrReader(errStream)))
+
+		if bMatch != testCase.bMatch || err != testCase.errWant {
+			t.Errorf("MatchReader(%q) on a failing stream = %t, %v; want "+
+				"%t, %v", testCase.strWild, bMatch, err, testCase.bMatch,
+				testCase.errWant)
+		}
+	}
+
+	// Each comparison gets the same result from the stream.
+	for _, strWild := range allStrings([]string{"*", "?", "a", "b",
+		"[!a]"}, 4) {
+		for _, strTame := range allStrings([]string{"a", "b"}, 5) {
+			checkMatchReader(strWild, strTame,
+				FastWildCompareAscii(strWild, strTame))
+		}
+	}
+}
+
+// Tests for LiteralPrefix().
+func TestLiteralPrefix(t *testing.T) {
+	for _, testCase := range []struct {
+		strWild     string
+		strExpected string
+		bExact      bool
+	}{
+		{"abc*", "abc", false},
+		{"abc", "abc", true},
+		{"*abc", "", false},
+		{"", "", true},
+		{"user:42:?", "user:42:", false},
+		{"log[0-9].txt", "log", false},
+		{"a*b?c", "a", false},
+
+		// A '[' that's never closed is a literal.
+		{"a[b", "a[b", true},
+		{"a[b*", "a[b", false},
+		{"a[b]*", "a", false},
+	} {
+		if strPrefix, bExact := LiteralPrefix(
+			testCase.strWild); strPrefix != testCase.strExpected ||
+			bExact != testCase.bExact {
+			t.Errorf("LiteralPrefix(%q) = %q, %t; want %q, %t",
+				testCase.strWild, strPrefix, bExact, testCase.strExpected,
+				testCase.bExact)
+		}
+	}
+
+	// A matching tame string begins with the prefix, and an exact prefix
+	// matches just itself.
+	for _, strWild := range allStrings([]string{"*", "?", "a", "b",
+		"[a]", "["}, 4) {
+		strPrefix, bExact := LiteralPrefix(strWild)
+
+		for _, strTame := range allStrings([]string{"a", "b", "["}, 5) {
+			bMatch := FastWildCompareAscii(strWild, strTame)
+
+			if bMatch && !strings.HasPrefix(strTame, strPrefix) ||
+				bExact && bMatch != (strTame == strPrefix) {
+				t.Errorf("LiteralPrefix(%q) = %q, %t, with a match of "+
+					"%q = %t", strWild, strPrefix, bExact, strTame, bMatch)
+			}
+		}
+	}
+}
+
+// Tests for MatchPrefixN().
+func TestMatchPrefixN(t *testing.T) {
+	strZip := "PK\x03\x04\x14\x00\x00\x00"
+
+	for _, testCase := range []struct {
+		strWild   string
+		strTame   string
+		n         int
+		bExpected bool
+	}{
+		// n smaller than the tame length.
+		{"PK??", strZip, 4, true},
+		{"PK\x03\x04", strZip, 4, true},
+		{"PK?", strZip, 4, false},
+		{"PK???", strZip, 4, false},
+		{"PK*", strZip, 4, true},
+		{"%PDF-*", strZip, 5, false},
+		{"%PDF-*", "%PDF-1.7\n%", 5, true},
+		{"mi*sip", "mississippi", 9, true},
+		{"mi*sip", "mississippi", 8, false},
+
+		// n equal to the tame length.
+		{"PK*\x00", strZip, len(strZip), true},
+		{"*sip*", "mississippi", 11, true},
+
+		// n larger than the tame length compares the whole tame string.
+		{"PK*\x00", strZip, 100, true},
+		{"PK??????????", strZip, 100, false},
+		{"", "", 1, true},
+
+		// n of zero or less compares the empty string.
+		{"", strZip, 0, true},
+		{"*", strZip, -1, true},
+		{"?", strZip, 0, false},
+	} {
+		if MatchPrefixN(testCase.strWild, testCase.strTame,
+			testCase.n) != testCase.bExpected {
+			t.Errorf("MatchPrefixN(%q, %q, %d) = %t, want %t",
+				testCase.strWild, testCase.strTame, testCase.n,
+				!testCase.bExpected, testCase.bExpected)
+		}
+	}
+}
+
+// Tests for MatchNegatable().
+func TestMatchNegatable(t *testing.T) {
+	for _, testCase := range []struct {
+		strWild   string
+		strTame   string
+		bExpected bool
+	}{
+		// A leading '!' matches everything the rest of the pattern doesn't.
+		{"!*.tmp", "scratch.tmp", false},
+		{"!*.tmp", ".tmp", false},
+		{"!*.tmp", "main.go", true},
+		{"!*.tmp", "scratch.tmp.bak", true},
+		{"!*.tmp", "", true},
+		{"!", "x", true},
+		{"!", "", false},
+		{"!*", "anything", false},
+
+		// Only the first '!' negates.
+		{"!!foo", "!foo", false},
+		{"!!foo", "foo", true},
+
+		// An escaped leading '!' is literal.
+		{"\\!foo", "!foo", true},
+		{"\\!foo", "foo", false},
+		{"\\!foo", "\\!foo", false},
+		{"\\!*", "!important", true},
+
+		// Patterns without a leading '!' match as usual.
+		{"*.tmp", "scratch.tmp", true},
+		{"a!b", "a!b", true},
+		{"a\\!b", "a\\!b", true},
+	} {
+		if MatchNegatable(testCase.strWild,
+			testCase.strTame) != testCase.bExpected {
+			t.Errorf("MatchNegatable(%q, %q) = %t, want %t",
+				testCase.strWild, testCase.strTame, !testCase.bExpected,
+				testCase.bExpected)
+		}
+	}
+}
+
+// Tests for FastWildCompareGzip(), on content compressed here.
+func TestFastWildCompareGzip(t *testing.T) {
+	var buf bytes.Buffer
+
+	zw := gzip.NewWriter(&buf)
+	zw.Write([]byte("2025-01-01 ERROR disk full\n2025-01-02 INFO ok\n"))
+	zw.Close()
+	slcCompressed := buf.Bytes()
+
+	for _, testCase := range []struct {
+		strWild   string
+		bExpected bool
+	}{
+		{"*ERROR*full*", true},
+		{"2025-01-0?*ok?", true},
+		{"*WARN*", false},
+	} {
+		if bMatch, err := FastWildCompareGzip(testCase.strWild,
+			bytes.NewReader(slcCompressed)); bMatch != testCase.bExpected ||
+			err != nil {
+			t.Errorf("FastWildCompareGzip(%q) = %t, %v; want %t, nil",
+				testCase.strWild, bMatch, err, testCase.bExpected)
+		}
+	}
+
+	// Content that isn't gzip-compressed, or that's cut short, is an error
+	// rather than a non-match.
+	for _, r := range []io.Reader{
+		strings.NewReader("2025-01-01 ERROR disk full"),
+		bytes.NewReader(slcCompressed[:len(slcCompressed)-4]),
+	} {
+		if bMatch, err := FastWildCompareGzip("*", r); bMatch || err == nil {
+			t.Errorf("FastWildCompareGzip(\"*\") = %t, %v; want an error",
+				bMatch, err)
+		}
+	}
+}
+
+// Tests for MatchablePrefixLen().
+func TestMatchablePrefixLen(t *testing.T) {
+	for _, testCase := range []struct {
+		strWild   string
+		strTame   string
+		iExpected int
+	}{
+		// Input that could still be completed to match is entirely viable.
+		{"ab*yz", "abcy", 4},
+		{"ab*yz", "a", 1},
+		{"ab*yz", "", 0},
+		{"mi*sip*", "missis", 6},
+		{"abc", "ab", 2},
+
+		// Matching input is entirely viable too.
+		{"ab*yz", "abxyz", 5},
+		{"*", "anything", 8},
+
+		// Otherwise, the result is where the input stops being viable.
+		{"ab*yz", "axyz", 1},
+		{"a?c*", "abdc", 2},
+		{"abc", "abcd", 3},
+		{"", "a", 0},
+		{"x*", "yx", 0},
+	} {
+		if iViable := MatchablePrefixLen(testCase.strWild,
+			testCase.strTame); iViable != testCase.iExpected {
+			t.Errorf("MatchablePrefixLen(%q, %q) = %d, want %d",
+				testCase.strWild, testCase.strTame, iViable,
+				testCase.iExpected)
+		}
+	}
+
+	// A match is viable through its full length, and so is any extension of
+	// it, once a '*' is appended to the pattern.
+	for _, strWild := range allStrings([]string{"*", "?", "a", "b"}, 4) {
+		for _, strTame := range allStrings([]string{"a", "b"}, 5) {
+			if !FastWildCompareAscii(strWild, strTame) {
+				continue
+			}
+
+			if iViable := MatchablePrefixLen(strWild,
+				strTame); iViable != len(strTame) {
+				t.Errorf("MatchablePrefixLen(%q, %q) = %d, want %d",
+					strWild, strTame, iViable, len(strTame))
+			}
+
+			if iViable := MatchablePrefixLen(strWild+"*",
+				strTame+"~"); iViable != len(strTame)+1 {
+				t.Errorf("MatchablePrefixLen(%q, %q) = %d, want %d",
+					strWild+"*", strTame+"~", iViable, len(strTame)+1)
+			}
+		}
+	}
+}
+
+// Tests for ScanMatches().
+func TestScanMatches(t *testing.T) {
+	strLog := "INFO start\nERROR disk full\r\nINFO ok\nERROR net down\n" +
+		"ERROR again"
+	var slcLines []string
+
+	// The callback fires for each matching line.
+	err := ScanMatches("ERROR *", strings.NewReader(strLog),
+		func(strLine string) bool {
+			slcLines = append(slcLines, strLine)
+			return true
+		})
+	slcExpected := []string{"ERROR disk full", "ERROR net down",
+		"ERROR again"}
+
+	if err != nil || !slices.Equal(slcLines, slcExpected) {
+		t.Errorf("ScanMatches(\"ERROR *\") found %q, %v; want %q, nil",
+			slcLines, err, slcExpected)
+	}
+
+	// Scanning stops once the callback returns false.
+	slcLines = nil
+	err = ScanMatches("ERROR *", strings.NewReader(strLog),
+		func(strLine string) bool {
+			slcLines = append(slcLines, strLine)
+			return len(slcLines) < 2
+		})
+
+	if err != nil || len(slcLines) != 2 {
+		t.Errorf("ScanMatches(\"ERROR *\") found %q, %v after stopping "+
+			"at 2", slcLines, err)
+	}
+
+	// No matches, no calls.
+	err = ScanMatches("WARN *", strings.NewReader(strLog),
+		func(strLine string) bool {
+			t.Errorf("ScanMatches(\"WARN *\") found %q", strLine)
+			return true
+		})
+
+	if err != nil {
+		t.Errorf("ScanMatches(\"WARN *\") = %v", err)
+	}
+
+	// Read errors are passed along.
+	err = ScanMatches("*", strings.NewReader(strings.Repeat("x", 1<<17)),
+		func(strLine string) bool {
+			return true
+		})
+
+	if err != bufio.ErrTooLong {
+		t.Errorf("ScanMatches() on an overlong line = %v, want %v", err,
+			bufio.ErrTooLong)
+	}
+}
+
+// Tests for LongestLiteralMatch().
+func TestLongestLiteralMatch(t *testing.T) {
+	for _, testCase := range []struct {
+		strWild   string
+		strTame   string
+		iExpected int
+	}{
+		{"*report?final*", "final draft", 5},
+		{"*report?final*", "reporting", 6},
+		{"*report?final*", "report final", 6},
+		{"*report?final*", "", 0},
+		{"*?*", "anything", 0},
+		{"abc", "xxabxabcx", 3},
+
+		// Wildcards never join literal runs, even where the tame string
+		// has the wildcard characters themselves.
+		{"ab*cd", "ab*cd", 2},
+	} {
+		if iLongest := LongestLiteralMatch(testCase.strWild,
+			testCase.strTame); iLongest != testCase.iExpected {
+			t.Errorf("LongestLiteralMatch(%q, %q) = %d, want %d",
+				testCase.strWild, testCase.strTame, iLongest,
+				testCase.iExpected)
+		}
+	}
+
+	// Candidates rank by their longest run, whether or not they match.
+	strWild := "*quarterly report*2025*"
+	slcCandidates := []string{"annual report", "quarterly numbers",
+		"2025 plan", "quarterly report 2025", "misc"}
+	slcExpected := []string{"quarterly report 2025", "quarterly numbers",
+		"annual report", "2025 plan", "misc"}
+
+	sort.SliceStable(slcCandidates, func(i, j int) bool {
+		return LongestLiteralMatch(strWild, slcCandidates[i]) >
+			LongestLiteralMatch(strWild, slcCandidates[j])
+	})
+
+	if !slices.Equal(slcCandidates, slcExpected) {
+		t.Errorf("LongestLiteralMatch(%q) ranks %q, want %q", strWild,
+			slcCandidates, slcExpected)
+	}
+}
+
+// Tests for MatchValidated().
+func TestMatchValidated(t *testing.T) {
+	for _, testCase := range []struct {
+		strWild   string
+		strTame   string
+		bExpected bool
+		err       error
+	}{
+		{"mi*sip*", "mississippi", true, nil},
+		{"mi*sip", "mississippi", false, nil},
+
+		// A '[' that's never closed is just a literal.
+		{"[a-*", "[a-z]", true, nil},
+
+		// A range that ends before it starts is malformed.
+		{"*[z-a]", "abc", false, ErrEmptyRange},
+		{"*[a-c]", "abc", true, nil},
+	} {
+		if bMatch, err := MatchValidated(testCase.strWild,
+			testCase.strTame); bMatch != testCase.bExpected ||
+			!errors.Is(err, testCase.err) {
+			t.Errorf("MatchValidated(%q, %q) = %t, %v; want %t, %v",
+				testCase.strWild, testCase.strTame, bMatch, err,
+				testCase.bExpected, testCase.err)
+		}
+	}
+
+	// Well-formed patterns get the results of FastWildCompareAscii().
+	for _, strWild := range allStrings([]string{"*", "?", "a", "b",
+		"[ab]"}, 4) {
+		for _, strTame := range allStrings([]string{"a", "b"}, 5) {
+			bExpected := FastWildCompareAscii(strWild, strTame)
+
+			if bMatch, err := MatchValidated(strWild,
+				strTame); bMatch != bExpected || err != nil {
+				t.Errorf("MatchValidated(%q, %q) = %t, %v; want %t, nil",
+					strWild, strTame, bMatch, err, bExpected)
+			}
+		}
+	}
+}
+
+// Tests for MatchAnchored().
+func TestMatchAnchored(t *testing.T) {
+	for _, testCase := range []struct {
+		strWild   string
+		strTame   string
+		bExpected bool
+	}{
+		// Anchored at the start.
+		{"^abc", "abcdef", true},
+		{"^abc", "xabc", false},
+
+		// Anchored at the end.
+		{"abc$", "xyzabc", true},
+		{"abc$", "abcx", false},
+
+		// Anchored at both.
+		{"^abc$", "abc", true},
+		{"^abc$", "abcabc", false},
+		{"^$", "", true},
+		{"^$", "x", false},
+
+		// Anchored at neither.
+		{"abc", "xxabcxx", true},
+		{"abc", "abc", true},
+		{"abc", "ab c", false},
+		{"", "anything", true},
+		{"^", "anything", true},
+		{"$", "anything", true},
+
+		// Combined with '*' and '?'.
+		{"^mi*sip", "mississippi", true},
+		{"^mi*sip$", "mississippi", false},
+		{"s?ss$", "mississ", true},
+		{"s?p", "mississippi", true},
+
+		// Escaped anchors are literals.
+		{"\\^2", "x^2", true},
+		{"\\^2", "x2", false},
+		{"^cost: 5\\$", "cost: 5$ each", true},
+		{"^cost: 5\\$", "cost: 5", false},
+		{"^\\^$", "^", true},
+		{"a^b$c", "xa^b$cx", true},
+	} {
+		if MatchAnchored(testCase.strWild,
+			testCase.strTame) != testCase.bExpected {
+			t.Errorf("MatchAnchored(%q, %q) = %t, want %t",
+				testCase.strWild, testCase.strTame, !testCase.bExpected,
+				testCase.bExpected)
+		}
+	}
+}
+
+// Tests for MatchRangeInSorted(), which should find just what a scan of
+// every key finds.
+func TestMatchRangeInSorted(t *testing.T) {
+	slcKeys := []string{
+		"group:7", "user:", "user:1", "user:1:email", "user:1:name",
+		"user:12:name", "user:2", "user:2:email", "user:2:name", "user;",
+		"users", "zone:us-east", "zone:us-west",
+	}
+	sort.Strings(slcKeys)
+
+	for _, rangeCase := range []struct {
+		strWild     string
+		slcExpected []string
+	}{
+		{"user:1*", []string{"user:1", "user:12:name", "user:1:email",
+			"user:1:name"}},
+		{"user:?:name", []string{"user:1:name", "user:2:name"}},
+		{"zone:*", []string{"zone:us-east", "zone:us-west"}},
+		{"user:2", []string{"user:2"}},
+		{"user:3*", nil},
+		{"zzz*", nil},
+	} {
+		if slcMatches := MatchRangeInSorted(slcKeys,
+			rangeCase.strWild); !reflect.DeepEqual(slcMatches,
+			rangeCase.slcExpected) {
+			t.Errorf("MatchRangeInSorted(%q) = %q, want %q",
+				rangeCase.strWild, slcMatches, rangeCase.slcExpected)
+		}
+	}
+
+	if slcMatches := MatchRangeInSorted(nil, "*"); slcMatches != nil {
+		t.Errorf("MatchRangeInSorted(nil, \"*\") = %q", slcMatches)
+	}
+
+	for _, strWild := range []string{
+		"user:1*", "user:?:name", "user*", "user:*:email", "*name", "*",
+		"user:", "user", "u?er:1", "", "?", "zone:us-*t", "group:7*",
+		"user:2*", "users*", "a*",
+	} {
+		var slcExpected []string
+
+		for _, strKey := range slcKeys {
+			if FastWildCompareAscii(strWild, strKey) {
+				slcExpected = append(slcExpected, strKey)
+			}
+		}
+
+		if slcMatches := MatchRangeInSorted(slcKeys,
+			strWild); !reflect.DeepEqual(slcMatches, slcExpected) {
+			t.Errorf("MatchRangeInSorted(%q) = %q, want %q", strWild,
+				slcMatches, slcExpected)
+		}
+	}
+}
+
+// Tests for WindowMatch().
+func TestWindowMatch(t *testing.T) {
+	strStream := "INFO: started\nWARN: disk 91% full\nERROR: disk full\n" +
+		"INFO: retrying\n"
+
+	for _, testCase := range []struct {
+		strWild   string
+		strStream string
+		window    int
+		bExpected bool
+	}{
+		// The match appears midway through the stream, and fits the
+		// window.
+		{"ERROR*disk", strStream, 16, true},
+		{"ERROR: disk", strStream, 11, true},
+		{"ERROR: disk", strStream, 10, false},
+		{"WARN*ERROR", strStream, 30, true},
+		{"WARN*ERROR", strStream, 20, false},
+		{"disk ??%", strStream, 8, true},
+		{"disk ??%", strStream, 7, false},
+		{"FATAL", strStream, 100, false},
+		{"retrying*started", strStream, 1000, false},
+
+		// The latest possible start is what counts.
+		{"a*b", "a-----a-b", 3, true},
+		{"a*b", "a-----b-a", 3, false},
+
+		// Patterns that match an empty stretch match at once, and nothing
+		// else matches an empty stream.
+		{"*", "", 0, true},
+		{"", "abc", 0, true},
+		{"?", "", 10, false},
+	} {
+		bMatch, err := WindowMatch(testCase.strWild,
+			strings.NewReader(testCase.strStream), testCase.window)
+
+		if bMatch != testCase.bExpected || err != nil {
+			t.Errorf("WindowMatch(%q, %q, %d) = %t, %v; want %t, nil",
+				testCase.strWild, testCase.strStream, testCase.window,
+				bMatch, err, testCase.bExpected)
+		}
+	}
+
+	// Reading stops at the match, before the stream's error, and any other
+	// error is returned.
+	errStream := errors.New("stream failed")
+
+	for _, testCase := range []struct {
+		strWild string
+		bMatch  bool
+		errWant error
+	}{
+		{"ERROR*full", true, nil},
+		{"FATAL", false, errStream},
+	} {
+		bMatch, err := WindowMatch(testCase.strWild, io.MultiReader(
+			strings.NewReader(strStream), iotest.ErrReader(errStream)), 20)
+
+		if bMatch != testCase.bMatch || err != testCase.errWant {
+			t.Errorf("WindowMatch(%q) on a failing stream = %t, %v; want "+
+				"%t, %v", testCase.strWild, bMatch, err, testCase.bMatch,
+				testCase.errWant)
+		}
+	}
+
+	// A window at least as long as the content is just an unanchored
+	// match.
+	for _, strWild := range allStrings([]string{"*", "?", "a", "b"}, 4) {
+		for _, strTame := range allStrings([]string{"a", "b", "c"}, 5) {
+			bExpected := FastWildCompareAscii("*"+strWild+"*", strTame)
+			bMatch, err := WindowMatch(strWild, strings.NewReader(strTame),
+				len(strTame))
+
+			if bMatch != bExpected || err != nil {
+				t.Errorf("WindowMatch(%q, %q, %d) = %t, %v; want %t, nil",
+					strWild, strTame, len(strTame), bMatch, err, bExpected)
+			}
+		}
+	}
+}
+
+// Tests for MatchAnnotated().
+func TestMatchAnnotated(t *testing.T) {
+	for _, testCase := range []struct {
+		strWild   string
+		strTame   string
+		bExpected bool
+	}{
+		// A commented pattern matches as if the comment weren't there.
+		{"*.log # error logs", "app.log", true},
+		{"*.log # error logs", "app.log # error logs", false},
+		{"*.log\t\t# tabbed", "a.log", true},
+		{"a?c #", "abc", true},
+		{"# just a note", "", true},
+		{"# just a note", "x", false},
+
+		// An escaped '#', or one that doesn't follow whitespace, is literal.
+		{"* \\#1", "take #1", true},
+		{"* \\#1", "take 1", false},
+		{"\\#*", "#include", true},
+		{"issue#*", "issue#42", true},
+		{"* \\# # channel", "join #", true},
+		{"a\\b", "a\\b", true},
+
+		// Without a comment, trailing whitespace is part of the pattern.
+		{"ab ", "ab ", true},
+		{"ab ", "ab", false},
+	} {
+		if MatchAnnotated(testCase.strWild,
+			testCase.strTame) != testCase.bExpected {
+			t.Errorf("MatchAnnotated(%q, %q) = %t, want %t",
+				testCase.strWild, testCase.strTame, !testCase.bExpected,
+				testCase.bExpected)
+		}
+	}
+
+	// Patterns without comments match as usual.
+	for _, strWild := range allStrings([]string{"*", "?", "a", " "}, 4) {
+		for _, strTame := range allStrings([]string{"a", "b", " "}, 5) {
+			bExpected := FastWildCompareAscii(strWild, strTame)
+
+			if MatchAnnotated(strWild, strTame) != bExpected {
+				t.Errorf("MatchAnnotated(%q, %q) = %t, want %t", strWild,
+					strTame, !bExpected, bExpected)
+			}
+		}
+	}
+}